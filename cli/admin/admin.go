@@ -19,7 +19,7 @@ const (
 	adminCallFuncName = "__cartridge_admin_call"
 )
 
-type ProcessAdminFuncType func(conn *connector.Conn, funcName string, flagSet *pflag.FlagSet, args []string) error
+type ProcessAdminFuncType func(ctx *context.Ctx, conn *connector.Conn, funcName string, flagSet *pflag.FlagSet, args []string) error
 
 func Run(processAdminFunc ProcessAdminFuncType, ctx *context.Ctx, funcName string, flagSet *pflag.FlagSet, args []string) error {
 	if err := checkCtx(ctx); err != nil {
@@ -32,17 +32,17 @@ func Run(processAdminFunc ProcessAdminFuncType, ctx *context.Ctx, funcName strin
 	}
 	defer conn.Close()
 
-	return processAdminFunc(conn, funcName, flagSet, args)
+	return processAdminFunc(ctx, conn, funcName, flagSet, args)
 }
 
-func List(conn *connector.Conn, funcName string, flagSet *pflag.FlagSet, args []string) error {
+func List(ctx *context.Ctx, conn *connector.Conn, funcName string, flagSet *pflag.FlagSet, args []string) error {
 	return adminFuncList(conn)
 }
 
-func Help(conn *connector.Conn, funcName string, flagSet *pflag.FlagSet, args []string) error {
+func Help(ctx *context.Ctx, conn *connector.Conn, funcName string, flagSet *pflag.FlagSet, args []string) error {
 	return adminFuncHelp(conn, flagSet, funcName)
 }
 
-func Call(conn *connector.Conn, funcName string, flagSet *pflag.FlagSet, args []string) error {
-	return adminFuncCall(conn, funcName, flagSet, args)
+func Call(ctx *context.Ctx, conn *connector.Conn, funcName string, flagSet *pflag.FlagSet, args []string) error {
+	return adminFuncCall(ctx, conn, funcName, flagSet, args)
 }
@@ -1,11 +1,17 @@
 package admin
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 	"strings"
 
 	"github.com/apex/log"
+	"github.com/tarantool/cartridge-cli/cli/common"
 	"github.com/tarantool/cartridge-cli/cli/connector"
+	"github.com/tarantool/cartridge-cli/cli/context"
 	"github.com/tarantool/cartridge-cli/cli/project"
 	"gopkg.in/yaml.v2"
 
@@ -16,6 +22,25 @@ const (
 	argTypeString = "string"
 	argTypeNumber = "number"
 	argTypeBool   = "boolean"
+	argTypeTable  = "table"
+	argTypeArray  = "array"
+
+	TextOutput = "text"
+	JSONOutput = "json"
+)
+
+var (
+	supportedArgTypes = []string{argTypeString, argTypeNumber, argTypeBool, argTypeTable, argTypeArray}
+
+	argTypeDescriptions = map[string]string{
+		argTypeString: "a string",
+		argTypeNumber: "a number",
+		argTypeBool:   "a boolean",
+		argTypeTable:  "a table (JSON object or array)",
+		argTypeArray:  "an array (JSON array)",
+	}
+
+	Outputs = []string{TextOutput, JSONOutput}
 )
 
 type FuncCallArg struct {
@@ -25,6 +50,8 @@ type FuncCallArg struct {
 	StringValue string
 	NumberValue float64
 	BoolValue   bool
+	// JSONValue is a value of table or array argument
+	JSONValue string
 
 	// Changed is an equivalent for pflag.Flag.Changed
 	// It's true when user entered argument value
@@ -33,81 +60,239 @@ type FuncCallArg struct {
 	Changed bool
 }
 
-func adminFuncCall(conn *connector.Conn, funcName string, flagSet *pflag.FlagSet, args []string) error {
-	funcCallOpts, err := getFuncCallOpts(conn, funcName, flagSet, args)
+func adminFuncCall(ctx *context.Ctx, conn *connector.Conn, funcName string, flagSet *pflag.FlagSet, args []string) error {
+	funcCallOpts, err := getFuncCallOpts(ctx, conn, funcName, flagSet, args)
 	if err != nil {
 		return fmt.Errorf("Failed to parse function call args: %s", err)
 	}
 
-	callReq := connector.CallReq(adminCallFuncName, funcName, funcCallOpts)
-	callReq.SetPushCallback(func(pushedData interface{}) {
-		printMessage(pushedData)
+	if IsFanOut(ctx) {
+		return adminFuncCallFanOut(ctx, conn, funcName, funcCallOpts)
+	}
+
+	// in JSON mode stdout contains only the function return value
+	messagesWriter := io.Writer(os.Stdout)
+	if ctx.Admin.Output == JSONOutput {
+		messagesWriter = os.Stderr
+	}
+
+	callRes, err := callAdminFunc(ctx, conn, funcName, funcCallOpts, func(pushedData interface{}) {
+		printMessage(messagesWriter, pushedData)
 	})
+	if err != nil {
+		return err
+	}
+
+	if ctx.Admin.Output == JSONOutput {
+		return printCallResJSON(callRes)
+	}
+
+	printCallRes(callRes)
+
+	return nil
+}
+
+// callAdminFunc calls the admin function and returns its return value.
+// pushCallback is called for each intermediate message pushed by the function
+func callAdminFunc(ctx *context.Ctx, conn *connector.Conn, funcName string,
+	funcCallOpts map[string]interface{}, pushCallback func(interface{})) (interface{}, error) {
+
+	callReq := connector.CallReq(adminCallFuncName, funcName, funcCallOpts)
+	callReq.SetContext(ctx.Cli.Context)
+	callReq.SetPushCallback(pushCallback)
 
 	callResData, err := conn.Exec(callReq)
 
 	if err != nil {
-		return fmt.Errorf("Failed to call %q: %s", funcName, err)
+		if ctx.Cli.Context.Err() != nil {
+			return nil, fmt.Errorf("Failed to call %q: %s. The function can still be running on the instance", funcName, err)
+		}
+		return nil, fmt.Errorf("Failed to call %q: %s", funcName, err)
 	}
 
 	// it could be one of
 	// return res
 	// return nil, err
 	if len(callResData) < 1 || len(callResData) > 2 {
-		return fmt.Errorf("Bad data len: %d", len(callResData))
+		return nil, fmt.Errorf("Bad data len: %d", len(callResData))
 	}
 
 	if len(callResData) == 2 {
 		if funcErr := callResData[1]; funcErr != nil {
-			return fmt.Errorf("Failed to call %q: %s", funcName, funcErr)
+			return nil, fmt.Errorf("Failed to call %q: %s", funcName, funcErr)
 		}
 	}
 
-	callRes := callResData[0]
+	return callResData[0], nil
+}
 
-	printCallRes(callRes)
+// CheckOutput checks that specified admin function call output format is supported
+func CheckOutput(output string) error {
+	if !common.StringSliceContains(Outputs, output) {
+		return fmt.Errorf("Unknown output format %q. Supported formats: %s", output, strings.Join(Outputs, ", "))
+	}
 
 	return nil
 }
 
-func getFuncCallOpts(conn *connector.Conn, funcName string, flagSet *pflag.FlagSet, args []string) (map[string]interface{}, error) {
-	funcCallArgsList, err := getFuncCallArgsList(conn, funcName, flagSet, args)
+// getFuncCallOpts collects function call options from the JSON object
+// specified by --args-json or --args-file flag and arguments flags.
+// Arguments flags have priority
+func getFuncCallOpts(ctx *context.Ctx, conn *connector.Conn, funcName string,
+	flagSet *pflag.FlagSet, args []string) (map[string]interface{}, error) {
+
+	funcInfo, err := getFuncInfo(funcName, conn)
+	if err != nil {
+		return nil, getCliExtError("Failed to get function %q signature: %s", funcName, err)
+	}
+
+	funcCallArgsList, err := getFuncCallArgsList(funcInfo, flagSet, args)
 	if err != nil {
 		return nil, err
 	}
 
 	funcCallOpts := make(map[string]interface{})
 
+	argsJSON, err := getArgsJSON(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if argsJSON != nil {
+		if funcCallOpts, err = parseArgsJSON(argsJSON, funcInfo.Args); err != nil {
+			return nil, err
+		}
+	}
+
 	for _, funcCallArg := range funcCallArgsList {
 		if !funcCallArg.Changed {
 			continue
 		}
 
+		value, err := getFuncCallArgValue(funcCallArg)
+		if err != nil {
+			return nil, err
+		}
+
+		funcCallOpts[funcCallArg.Name] = value
+	}
+
+	return funcCallOpts, nil
+}
+
+func getFuncCallArgValue(funcCallArg FuncCallArg) (interface{}, error) {
+	switch funcCallArg.Type {
+	case argTypeString:
+		return funcCallArg.StringValue, nil
+	case argTypeNumber:
+		return funcCallArg.NumberValue, nil
+	case argTypeBool:
+		return funcCallArg.BoolValue, nil
+	case argTypeTable, argTypeArray:
 		var value interface{}
+		if err := json.Unmarshal([]byte(funcCallArg.JSONValue), &value); err != nil {
+			return nil, fmt.Errorf("Failed to parse %q argument value as JSON: %s", funcCallArg.Name, err)
+		}
 
-		switch funcCallArg.Type {
-		case argTypeString:
-			value = funcCallArg.StringValue
-		case argTypeNumber:
-			value = funcCallArg.NumberValue
-		case argTypeBool:
-			value = funcCallArg.BoolValue
-		default:
-			return nil, project.InternalError("Unknown argument type: %s", funcCallArg.Type)
+		if err := checkArgValueType(funcCallArg.Name, funcCallArg.Type, value); err != nil {
+			return nil, err
 		}
 
-		funcCallOpts[funcCallArg.Name] = value
+		return value, nil
+	default:
+		return nil, project.InternalError("Unknown argument type: %s", funcCallArg.Type)
+	}
+}
+
+func getArgsJSON(ctx *context.Ctx) ([]byte, error) {
+	if ctx.Admin.ArgsJSON != "" && ctx.Admin.ArgsFile != "" {
+		return nil, fmt.Errorf("You can specify only one of --args-json or --args-file")
+	}
+
+	if ctx.Admin.ArgsJSON != "" {
+		return []byte(ctx.Admin.ArgsJSON), nil
+	}
+
+	if ctx.Admin.ArgsFile != "" {
+		argsJSON, err := ioutil.ReadFile(ctx.Admin.ArgsFile)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read arguments file: %s", err)
+		}
+
+		return argsJSON, nil
+	}
+
+	return nil, nil
+}
+
+// parseArgsJSON parses function arguments specified as a JSON object
+// and checks them against the function arguments spec.
+// Argument names can be specified in the flag form (with dashes)
+func parseArgsJSON(argsJSON []byte, argsSpec ArgsSpec) (map[string]interface{}, error) {
+	var argsMap map[string]interface{}
+	if err := json.Unmarshal(argsJSON, &argsMap); err != nil {
+		return nil, fmt.Errorf("Arguments should be a JSON object: %s", err)
+	}
+
+	argNamesByNormalized := make(map[string]string)
+	for argName := range argsSpec {
+		argNamesByNormalized[normalizeFlagName(argName)] = argName
+	}
+
+	funcCallOpts := make(map[string]interface{})
+
+	for specifiedName, value := range argsMap {
+		argName, found := argNamesByNormalized[normalizeFlagName(specifiedName)]
+		if !found {
+			return nil, fmt.Errorf("Unknown argument %q", specifiedName)
+		}
+
+		// null means that argument isn't passed
+		if value == nil {
+			continue
+		}
+
+		if err := checkArgValueType(argName, argsSpec[argName].Type, value); err != nil {
+			return nil, err
+		}
+
+		funcCallOpts[argName] = value
 	}
 
 	return funcCallOpts, nil
 }
 
-func getFuncCallArgsList(conn *connector.Conn, funcName string, flagSet *pflag.FlagSet, args []string) ([]FuncCallArg, error) {
-	funcInfo, err := getFuncInfo(funcName, conn)
-	if err != nil {
-		return nil, getCliExtError("Failed to get function %q signature: %s", funcName, err)
+func checkArgValueType(argName string, argType string, value interface{}) error {
+	var ok bool
+
+	switch argType {
+	case argTypeString:
+		_, ok = value.(string)
+	case argTypeNumber:
+		_, ok = value.(float64)
+	case argTypeBool:
+		_, ok = value.(bool)
+	case argTypeTable:
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			ok = true
+		}
+	case argTypeArray:
+		_, ok = value.([]interface{})
+	default:
+		return fmt.Errorf("Argument %q has unsupported type: %s", argName, argType)
 	}
 
+	if !ok {
+		return fmt.Errorf("Argument %q should be %s", argName, argTypeDescriptions[argType])
+	}
+
+	return nil
+}
+
+func getFuncCallArgsList(funcInfo *FuncInfo, flagSet *pflag.FlagSet, args []string) ([]FuncCallArg, error) {
+	funcName := funcInfo.Name
+
 	conflictingFlagNames := getConflictingFlagNames(funcInfo.Args, flagSet)
 	if len(conflictingFlagNames) > 0 {
 		return nil, fmt.Errorf(
@@ -132,12 +317,14 @@ func getFuncCallArgsList(conn *connector.Conn, funcName string, flagSet *pflag.F
 			flagSet.Float64Var(&funcCallArgs[i].NumberValue, argName, 0, argSpec.Usage)
 		case argTypeBool:
 			flagSet.BoolVar(&funcCallArgs[i].BoolValue, argName, false, argSpec.Usage)
+		case argTypeTable, argTypeArray:
+			flagSet.StringVar(&funcCallArgs[i].JSONValue, argName, "", argSpec.Usage)
 		default:
 			return nil, fmt.Errorf(
 				"Admin function %q accepts value of unsupported type: %s "+
 					"(supported types are %s)",
 				funcName, argSpec.Type,
-				strings.Join([]string{argTypeString, argTypeNumber, argTypeBool}, ", "),
+				strings.Join(supportedArgTypes, ", "),
 			)
 		}
 
@@ -194,7 +381,18 @@ func printCallRes(callResRaw interface{}) {
 	}
 }
 
-func printMessage(pushedData interface{}) {
+func printCallResJSON(callRes interface{}) error {
+	callResEncoded, err := json.MarshalIndent(common.NormalizeValue(callRes), "", "  ")
+	if err != nil {
+		return fmt.Errorf("Failed to encode function return value to JSON: %s", err)
+	}
+
+	fmt.Println(string(callResEncoded))
+
+	return nil
+}
+
+func printMessage(w io.Writer, pushedData interface{}) {
 	msg, ok := pushedData.(string)
 	if !ok {
 		log.Warnf("Intermediate message should be a string, got %v", pushedData)
@@ -203,9 +401,9 @@ func printMessage(pushedData interface{}) {
 		if err != nil {
 			log.Errorf("Failed to encode received intermediate message: %s", err)
 		}
-		fmt.Printf("%s", msgEncoded)
+		fmt.Fprintf(w, "%s", msgEncoded)
 		return
 	}
 
-	fmt.Println(msg)
+	fmt.Fprintln(w, msg)
 }
@@ -0,0 +1,168 @@
+package admin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/tarantool/cartridge-cli/cli/context"
+)
+
+func TestParseArgsJSON(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	argsSpec := ArgsSpec{
+		"username":    {Type: argTypeString},
+		"age":         {Type: argTypeNumber},
+		"loves_cakes": {Type: argTypeBool},
+		"address":     {Type: argTypeTable},
+		"friends":     {Type: argTypeArray},
+	}
+
+	// all types
+	funcCallOpts, err := parseArgsJSON([]byte(`{
+		"username": "Elizabeth",
+		"age": 24,
+		"loves-cakes": true,
+		"address": {"city": "London", "zip": 12345},
+		"friends": ["Jane", "Mary"]
+	}`), argsSpec)
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{
+		"username":    "Elizabeth",
+		"age":         float64(24),
+		"loves_cakes": true,
+		"address":     map[string]interface{}{"city": "London", "zip": float64(12345)},
+		"friends":     []interface{}{"Jane", "Mary"},
+	}, funcCallOpts)
+
+	// table can be an array, null isn't passed
+	funcCallOpts, err = parseArgsJSON([]byte(`{"address": ["London"], "age": null}`), argsSpec)
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{
+		"address": []interface{}{"London"},
+	}, funcCallOpts)
+
+	// invalid values
+	_, err = parseArgsJSON([]byte(`["Elizabeth"]`), argsSpec)
+	assert.Contains(err.Error(), "Arguments should be a JSON object")
+
+	_, err = parseArgsJSON([]byte(`{"name": "Elizabeth"}`), argsSpec)
+	assert.EqualError(err, `Unknown argument "name"`)
+
+	_, err = parseArgsJSON([]byte(`{"age": "24"}`), argsSpec)
+	assert.EqualError(err, `Argument "age" should be a number`)
+
+	_, err = parseArgsJSON([]byte(`{"loves_cakes": "yes"}`), argsSpec)
+	assert.EqualError(err, `Argument "loves_cakes" should be a boolean`)
+
+	_, err = parseArgsJSON([]byte(`{"address": "London"}`), argsSpec)
+	assert.EqualError(err, `Argument "address" should be a table (JSON object or array)`)
+
+	_, err = parseArgsJSON([]byte(`{"friends": {"name": "Jane"}}`), argsSpec)
+	assert.EqualError(err, `Argument "friends" should be an array (JSON array)`)
+}
+
+func TestGetFuncCallArgValue(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	value, err := getFuncCallArgValue(FuncCallArg{Name: "age", Type: argTypeNumber, NumberValue: 24})
+	assert.Nil(err)
+	assert.Equal(float64(24), value)
+
+	value, err = getFuncCallArgValue(FuncCallArg{Name: "address", Type: argTypeTable, JSONValue: `{"city": "London"}`})
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{"city": "London"}, value)
+
+	value, err = getFuncCallArgValue(FuncCallArg{Name: "friends", Type: argTypeArray, JSONValue: `["Jane"]`})
+	assert.Nil(err)
+	assert.Equal([]interface{}{"Jane"}, value)
+
+	_, err = getFuncCallArgValue(FuncCallArg{Name: "friends", Type: argTypeArray, JSONValue: `{"name": "Jane"}`})
+	assert.EqualError(err, `Argument "friends" should be an array (JSON array)`)
+
+	_, err = getFuncCallArgValue(FuncCallArg{Name: "address", Type: argTypeTable, JSONValue: `{city}`})
+	assert.Contains(err.Error(), `Failed to parse "address" argument value as JSON`)
+}
+
+func TestGetFuncCallArgsList(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	funcInfo := &FuncInfo{
+		Name: "echo_user",
+		Args: ArgsSpec{
+			"username": {Type: argTypeString},
+			"address":  {Type: argTypeTable},
+		},
+	}
+
+	flagSet := pflag.NewFlagSet("admin", pflag.ContinueOnError)
+	funcCallArgs, err := getFuncCallArgsList(funcInfo, flagSet, []string{"--address", `{"city": "London"}`})
+	assert.Nil(err)
+
+	for _, funcCallArg := range funcCallArgs {
+		if funcCallArg.Name == "address" {
+			assert.True(funcCallArg.Changed)
+			assert.Equal(`{"city": "London"}`, funcCallArg.JSONValue)
+		} else {
+			assert.False(funcCallArg.Changed)
+		}
+	}
+
+	funcInfo.Args["friends"] = ArgSpec{Type: "set"}
+
+	flagSet = pflag.NewFlagSet("admin", pflag.ContinueOnError)
+	_, err = getFuncCallArgsList(funcInfo, flagSet, nil)
+	assert.Contains(err.Error(), "supported types are string, number, boolean, table, array")
+}
+
+func TestGetArgsJSON(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	var ctx context.Ctx
+
+	argsJSON, err := getArgsJSON(&ctx)
+	assert.Nil(err)
+	assert.Nil(argsJSON)
+
+	ctx.Admin.ArgsJSON = `{"username": "Elizabeth"}`
+	argsJSON, err = getArgsJSON(&ctx)
+	assert.Nil(err)
+	assert.Equal(`{"username": "Elizabeth"}`, string(argsJSON))
+
+	dir, err := ioutil.TempDir("", "admin-args")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	argsFile := filepath.Join(dir, "args.json")
+	assert.Nil(ioutil.WriteFile(argsFile, []byte(`{"age": 24}`), 0644))
+
+	ctx.Admin.ArgsFile = argsFile
+	_, err = getArgsJSON(&ctx)
+	assert.EqualError(err, "You can specify only one of --args-json or --args-file")
+
+	ctx.Admin.ArgsJSON = ""
+	argsJSON, err = getArgsJSON(&ctx)
+	assert.Nil(err)
+	assert.Equal(`{"age": 24}`, string(argsJSON))
+
+	ctx.Admin.ArgsFile = filepath.Join(dir, "non-existent.json")
+	_, err = getArgsJSON(&ctx)
+	assert.Contains(err.Error(), "Failed to read arguments file")
+}
+
+func TestCheckOutput(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	assert.Nil(CheckOutput(TextOutput))
+	assert.Nil(CheckOutput(JSONOutput))
+	assert.EqualError(CheckOutput("yaml"), `Unknown output format "yaml". Supported formats: text, json`)
+}
@@ -85,6 +85,10 @@ func checkCtx(ctx *context.Ctx) error {
 		return fmt.Errorf("Please, specify --name")
 	}
 
+	if IsFanOut(ctx) {
+		return checkFanOutCtx(ctx)
+	}
+
 	if ctx.Admin.ConnString != "" && ctx.Project.Name != "" {
 		log.Warnf("--name is ignored since --conn is specified")
 	}
@@ -103,7 +107,12 @@ func getAvaliableConn(ctx *context.Ctx) (*connector.Conn, error) {
 	}
 
 	if address != "" {
-		conn, err := connector.Connect(address, connector.Opts{})
+		conn, err := connector.ConnectContext(ctx.Cli.Context, address, connector.Opts{
+			Username:  ctx.Admin.Username,
+			Password:  ctx.Admin.Password,
+			Transport: ctx.Admin.Transport,
+			SSL:       connector.SSLOpts(ctx.Admin.SSL),
+		})
 		if err != nil {
 			return nil, fmt.Errorf("Failed to connect: %s", err)
 		}
@@ -118,7 +127,7 @@ func getAvaliableConn(ctx *context.Ctx) (*connector.Conn, error) {
 	}
 
 	for _, address := range addresses {
-		conn, err := connector.Connect(address, connector.Opts{})
+		conn, err := connector.ConnectContext(ctx.Cli.Context, address, connector.Opts{})
 		if err != nil {
 			log.Debugf("Failed to connect to %s: %s", address, err)
 			continue
@@ -0,0 +1,199 @@
+package admin
+
+import (
+	gocontext "context"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/tarantool/cartridge-cli/cli/common"
+	"github.com/tarantool/cartridge-cli/cli/connector"
+	"github.com/tarantool/cartridge-cli/cli/context"
+)
+
+const (
+	compCacheDir = ".cartridge/completion/admin"
+	compCacheTTL = 1 * time.Minute
+
+	compConnectTimeout = 1 * time.Second
+)
+
+// compCache describes cached admin functions signatures.
+// It's stored per connection target to make completion fast
+type compCache struct {
+	UpdatedAt time.Time
+	FuncInfos FuncInfos
+	// FuncArgs contains arguments of functions that were completed
+	FuncArgs map[string]ArgsSpec
+}
+
+// GetFuncNamesComp returns available admin functions names
+// with usages in the shell completion format
+func GetFuncNamesComp(ctx *context.Ctx) ([]string, error) {
+	cache, err := getCompCache(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	funcNames := make([]string, 0, len(cache.FuncInfos))
+	for funcName, funcInfo := range cache.FuncInfos {
+		funcNames = append(funcNames, fmt.Sprintf("%s\t%s", funcName, funcInfo.Usage))
+	}
+	sort.Strings(funcNames)
+
+	return funcNames, nil
+}
+
+// GetFuncArgsComp returns the admin function arguments spec
+func GetFuncArgsComp(ctx *context.Ctx, funcName string) (ArgsSpec, error) {
+	cache, err := getCompCache(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if argsSpec, found := cache.FuncArgs[funcName]; found {
+		return argsSpec, nil
+	}
+
+	if _, found := cache.FuncInfos[funcName]; !found {
+		return nil, fmt.Errorf("Function %q isn't found", funcName)
+	}
+
+	conn, err := getCompConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	funcInfo, err := getFuncInfo(funcName, conn)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get function %q signature: %s", funcName, err)
+	}
+
+	cache.FuncArgs[funcName] = funcInfo.Args
+	saveCompCache(ctx, cache)
+
+	return funcInfo.Args, nil
+}
+
+// getCompCache returns cached functions signatures.
+// If cache is outdated, functions list is requested from the instance
+func getCompCache(ctx *context.Ctx) (*compCache, error) {
+	if cache, err := readCompCache(ctx); err != nil {
+		log.Debugf("Failed to read completion cache: %s", err)
+	} else if time.Since(cache.UpdatedAt) < compCacheTTL {
+		return cache, nil
+	}
+
+	conn, err := getCompConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	funcInfos, err := getListFuncInfos(conn)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get functions list: %s", err)
+	}
+
+	cache := &compCache{
+		UpdatedAt: time.Now(),
+		FuncInfos: *funcInfos,
+		FuncArgs:  make(map[string]ArgsSpec),
+	}
+
+	saveCompCache(ctx, cache)
+
+	return cache, nil
+}
+
+func getCompConn(ctx *context.Ctx) (*connector.Conn, error) {
+	if err := checkCtx(ctx); err != nil {
+		return nil, err
+	}
+
+	compCtx := *ctx
+
+	var cancel gocontext.CancelFunc
+	compCtx.Cli.Context, cancel = gocontext.WithTimeout(ctx.Cli.Context, compConnectTimeout)
+	defer cancel()
+
+	return getAvaliableConn(&compCtx)
+}
+
+func readCompCache(ctx *context.Ctx) (*compCache, error) {
+	cachePath, err := getCompCachePath(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return readCompCacheFile(cachePath)
+}
+
+func readCompCacheFile(cachePath string) (*compCache, error) {
+	cacheData, err := ioutil.ReadFile(cachePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var cache compCache
+	if err := json.Unmarshal(cacheData, &cache); err != nil {
+		return nil, err
+	}
+
+	if cache.FuncArgs == nil {
+		cache.FuncArgs = make(map[string]ArgsSpec)
+	}
+
+	return &cache, nil
+}
+
+// saveCompCache saves functions signatures to the cache file.
+// Errors are only logged since completion can work without cache
+func saveCompCache(ctx *context.Ctx, cache *compCache) {
+	cachePath, err := getCompCachePath(ctx)
+	if err != nil {
+		log.Debugf("Failed to get completion cache path: %s", err)
+		return
+	}
+
+	if err := writeCompCacheFile(cachePath, cache); err != nil {
+		log.Debugf("Failed to write completion cache: %s", err)
+	}
+}
+
+func writeCompCacheFile(cachePath string, cache *compCache) error {
+	cacheData, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("Failed to encode cache: %s", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		return fmt.Errorf("Failed to create cache directory: %s", err)
+	}
+
+	return ioutil.WriteFile(cachePath, cacheData, 0644)
+}
+
+// getCompCachePath returns the cache file path
+// that depends on the connection target
+func getCompCachePath(ctx *context.Ctx) (string, error) {
+	homeDir, err := common.GetHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	target := fmt.Sprintf("%s\n%s\n%s\n%s",
+		ctx.Project.Name, ctx.Running.RunDir, ctx.Admin.InstanceName, ctx.Admin.ConnString,
+	)
+
+	cacheFileName := fmt.Sprintf("%x.json", sha1.Sum([]byte(target)))
+
+	return filepath.Join(homeDir, compCacheDir, cacheFileName), nil
+}
@@ -0,0 +1,75 @@
+package admin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tarantool/cartridge-cli/cli/context"
+)
+
+func TestCompCacheFile(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "admin-comp")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	cachePath := filepath.Join(dir, "completion", "cache.json")
+
+	_, err = readCompCacheFile(cachePath)
+	assert.True(os.IsNotExist(err))
+
+	cache := &compCache{
+		UpdatedAt: time.Now(),
+		FuncInfos: FuncInfos{
+			"echo_user": {Name: "echo_user", Usage: "echo_user usage"},
+			"probe":     {Name: "probe", Usage: "Probe instance"},
+		},
+		FuncArgs: map[string]ArgsSpec{
+			"probe": {"uri": {Usage: "Instance URI", Type: argTypeString}},
+		},
+	}
+
+	assert.Nil(writeCompCacheFile(cachePath, cache))
+
+	readCache, err := readCompCacheFile(cachePath)
+	assert.Nil(err)
+	assert.True(cache.UpdatedAt.Equal(readCache.UpdatedAt))
+	assert.Equal(cache.FuncInfos, readCache.FuncInfos)
+	assert.Equal(cache.FuncArgs, readCache.FuncArgs)
+
+	// FuncArgs is always initialized
+	assert.Nil(ioutil.WriteFile(cachePath, []byte(`{"FuncInfos": {}}`), 0644))
+	readCache, err = readCompCacheFile(cachePath)
+	assert.Nil(err)
+	assert.NotNil(readCache.FuncArgs)
+
+	assert.Nil(ioutil.WriteFile(cachePath, []byte(`not a cache`), 0644))
+	_, err = readCompCacheFile(cachePath)
+	assert.NotNil(err)
+}
+
+func TestGetCompCachePath(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	var ctx context.Ctx
+	ctx.Project.Name = "myapp"
+	ctx.Running.RunDir = "/var/run/tarantool"
+
+	cachePath, err := getCompCachePath(&ctx)
+	assert.Nil(err)
+	assert.Equal(".json", filepath.Ext(cachePath))
+
+	// cache depends on the connection target
+	ctx.Admin.InstanceName = "router"
+	instanceCachePath, err := getCompCachePath(&ctx)
+	assert.Nil(err)
+	assert.NotEqual(cachePath, instanceCachePath)
+	assert.Equal(filepath.Dir(cachePath), filepath.Dir(instanceCachePath))
+}
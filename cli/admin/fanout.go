@@ -0,0 +1,271 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/apex/log"
+	"github.com/tarantool/cartridge-cli/cli/common"
+	"github.com/tarantool/cartridge-cli/cli/connector"
+	"github.com/tarantool/cartridge-cli/cli/context"
+	"github.com/tarantool/cartridge-cli/cli/replicasets"
+)
+
+// instanceCallResult describes the result of the admin function call on one instance
+type instanceCallResult struct {
+	InstanceName string
+	Messages     []interface{}
+	Result       interface{}
+	Err          error
+}
+
+// IsFanOut returns true if the admin function should be called
+// on several instances
+func IsFanOut(ctx *context.Ctx) bool {
+	return ctx.Admin.All || ctx.Admin.ReplicasetName != "" || ctx.Admin.Role != ""
+}
+
+func checkFanOutCtx(ctx *context.Ctx) error {
+	if ctx.Admin.InstanceName != "" || ctx.Admin.ConnString != "" {
+		return fmt.Errorf("--all, --replicaset and --role can't be used with --instance or --conn")
+	}
+
+	if ctx.Project.Name == "" {
+		return fmt.Errorf("Please, specify --name")
+	}
+
+	if ctx.Admin.Parallel < 1 {
+		return fmt.Errorf("--parallel should be greater than zero")
+	}
+
+	return nil
+}
+
+// adminFuncCallFanOut calls the admin function on all local instances
+// (or on the instances selected by replica set or role) and prints
+// the aggregated report.
+// conn is used to get the current topology
+func adminFuncCallFanOut(ctx *context.Ctx, conn *connector.Conn, funcName string,
+	funcCallOpts map[string]interface{}) error {
+
+	socketPathsByInstance, notRunningInstancesNames, err := getFanOutInstancesSocketPaths(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	instancesNames := make([]string, 0, len(socketPathsByInstance))
+	for instanceName := range socketPathsByInstance {
+		instancesNames = append(instancesNames, instanceName)
+	}
+	sort.Strings(instancesNames)
+
+	results := make([]*instanceCallResult, len(instancesNames))
+
+	sem := make(chan struct{}, ctx.Admin.Parallel)
+
+	var wg sync.WaitGroup
+	for i, instanceName := range instancesNames {
+		wg.Add(1)
+		go func(i int, instanceName string) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			socketPath := socketPathsByInstance[instanceName]
+			results[i] = callAdminFuncOnInstance(ctx, instanceName, socketPath, funcName, funcCallOpts)
+		}(i, instanceName)
+	}
+	wg.Wait()
+
+	// instances that match the filters but aren't running are reported as failed
+	for _, instanceName := range notRunningInstancesNames {
+		results = append(results, &instanceCallResult{
+			InstanceName: instanceName,
+			Err:          fmt.Errorf("Instance isn't running"),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].InstanceName < results[j].InstanceName
+	})
+
+	if ctx.Admin.Output == JSONOutput {
+		if err := printFanOutReportJSON(results); err != nil {
+			return err
+		}
+	} else {
+		printFanOutReport(results)
+	}
+
+	var failedInstancesNames []string
+	for _, result := range results {
+		if result.Err != nil {
+			failedInstancesNames = append(failedInstancesNames, result.InstanceName)
+		}
+	}
+
+	if len(failedInstancesNames) > 0 {
+		return fmt.Errorf(
+			"Failed to call %q on %d instance(s): %s",
+			funcName, len(failedInstancesNames), strings.Join(failedInstancesNames, ", "),
+		)
+	}
+
+	return nil
+}
+
+func callAdminFuncOnInstance(ctx *context.Ctx, instanceName, socketPath, funcName string,
+	funcCallOpts map[string]interface{}) *instanceCallResult {
+
+	result := &instanceCallResult{
+		InstanceName: instanceName,
+	}
+
+	conn, err := connector.ConnectContext(ctx.Cli.Context, socketPath, connector.Opts{})
+	if err != nil {
+		result.Err = fmt.Errorf("Failed to connect to %s: %s", socketPath, err)
+		return result
+	}
+	defer conn.Close()
+
+	log.Debugf("Connected to %s", socketPath)
+
+	var messagesMutex sync.Mutex
+	result.Result, result.Err = callAdminFunc(ctx, conn, funcName, funcCallOpts, func(pushedData interface{}) {
+		messagesMutex.Lock()
+		defer messagesMutex.Unlock()
+
+		result.Messages = append(result.Messages, pushedData)
+	})
+
+	return result
+}
+
+// getFanOutInstancesSocketPaths returns console sockets of the running instances
+// by instance names.
+// If --replicaset or --role is specified, only matching instances are returned,
+// names of matching instances that aren't running are returned too
+func getFanOutInstancesSocketPaths(ctx *context.Ctx, conn *connector.Conn) (map[string]string, []string, error) {
+	socketPaths, err := getInstanceSocketPaths(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to get paths of application instances sockets: %s", err)
+	}
+
+	socketPathsByInstance := make(map[string]string, len(socketPaths))
+	for _, socketPath := range socketPaths {
+		instanceName := getInstanceNameBySocketPath(ctx.Project.Name, socketPath)
+		socketPathsByInstance[instanceName] = socketPath
+	}
+
+	var notRunningInstancesNames []string
+
+	if ctx.Admin.ReplicasetName != "" || ctx.Admin.Role != "" {
+		topologyReplicasets, err := replicasets.GetTopologyReplicasets(conn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed to get current topology replica sets: %s", err)
+		}
+
+		matchingInstances, err := getMatchingInstances(ctx, topologyReplicasets)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		notRunningInstancesNames = filterRunningInstances(socketPathsByInstance, matchingInstances)
+	}
+
+	if len(socketPathsByInstance) == 0 && len(notRunningInstancesNames) == 0 {
+		return nil, nil, fmt.Errorf("No running instances match the specified filters")
+	}
+
+	return socketPathsByInstance, notRunningInstancesNames, nil
+}
+
+// filterRunningInstances removes instances that don't match the filters
+// from socketPathsByInstance and returns sorted names
+// of the matching instances that aren't running
+func filterRunningInstances(socketPathsByInstance map[string]string, matchingInstances map[string]bool) []string {
+	for instanceName := range socketPathsByInstance {
+		if !matchingInstances[instanceName] {
+			delete(socketPathsByInstance, instanceName)
+		}
+	}
+
+	var notRunningInstancesNames []string
+	for instanceName := range matchingInstances {
+		if _, found := socketPathsByInstance[instanceName]; !found {
+			notRunningInstancesNames = append(notRunningInstancesNames, instanceName)
+		}
+	}
+	sort.Strings(notRunningInstancesNames)
+
+	return notRunningInstancesNames
+}
+
+func getInstanceNameBySocketPath(appName, socketPath string) string {
+	instanceName := filepath.Base(socketPath)
+	instanceName = strings.TrimPrefix(instanceName, fmt.Sprintf("%s.", appName))
+	instanceName = strings.TrimSuffix(instanceName, ".control")
+
+	return instanceName
+}
+
+func getMatchingInstances(ctx *context.Ctx,
+	topologyReplicasets *replicasets.TopologyReplicasets) (map[string]bool, error) {
+
+	return replicasets.FilterInstances(topologyReplicasets, ctx.Admin.ReplicasetName, ctx.Admin.Role, false)
+}
+
+func printFanOutReport(results []*instanceCallResult) {
+	for _, result := range results {
+		if result.Err != nil {
+			log.Errorf("%s: %s", result.InstanceName, result.Err)
+			continue
+		}
+
+		log.Infof("%s: OK", result.InstanceName)
+
+		for _, pushedData := range result.Messages {
+			printMessage(os.Stdout, pushedData)
+		}
+
+		printCallRes(result.Result)
+	}
+}
+
+func printFanOutReportJSON(results []*instanceCallResult) error {
+	resultsByInstance := make(map[string]interface{}, len(results))
+	for _, result := range results {
+		messages := make([]interface{}, len(result.Messages))
+		for i, pushedData := range result.Messages {
+			messages[i] = common.NormalizeValue(pushedData)
+		}
+
+		if result.Err != nil {
+			resultsByInstance[result.InstanceName] = map[string]interface{}{
+				"messages": messages,
+				"error":    result.Err.Error(),
+			}
+			continue
+		}
+
+		resultsByInstance[result.InstanceName] = map[string]interface{}{
+			"messages": messages,
+			"result":   common.NormalizeValue(result.Result),
+		}
+	}
+
+	encoded, err := json.MarshalIndent(resultsByInstance, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Failed to encode results to JSON: %s", err)
+	}
+
+	fmt.Println(string(encoded))
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tarantool/cartridge-cli/cli/context"
+	"github.com/tarantool/cartridge-cli/cli/replicasets"
+)
+
+func TestGetInstanceNameBySocketPath(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	assert.Equal("router", getInstanceNameBySocketPath("myapp", "/var/run/tarantool/myapp.router.control"))
+	assert.Equal("s1-master", getInstanceNameBySocketPath("myapp", "tmp/run/myapp.s1-master.control"))
+	assert.Equal("my.app.s1", getInstanceNameBySocketPath("myapp", "tmp/run/myapp.my.app.s1.control"))
+}
+
+func TestCheckFanOutCtx(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	var ctx context.Ctx
+
+	assert.False(IsFanOut(&ctx))
+
+	ctx.Admin.All = true
+	ctx.Admin.Parallel = 10
+	assert.True(IsFanOut(&ctx))
+	assert.EqualError(checkFanOutCtx(&ctx), "Please, specify --name")
+
+	ctx.Project.Name = "myapp"
+	assert.Nil(checkFanOutCtx(&ctx))
+
+	ctx.Admin.InstanceName = "router"
+	assert.EqualError(checkFanOutCtx(&ctx), "--all, --replicaset and --role can't be used with --instance or --conn")
+
+	ctx.Admin.InstanceName = ""
+	ctx.Admin.Parallel = 0
+	assert.EqualError(checkFanOutCtx(&ctx), "--parallel should be greater than zero")
+
+	ctx.Admin.All = false
+	ctx.Admin.Role = "vshard-storage"
+	assert.True(IsFanOut(&ctx))
+}
+
+func TestFilterRunningInstances(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	socketPathsByInstance := map[string]string{
+		"router":    "tmp/run/myapp.router.control",
+		"s1-master": "tmp/run/myapp.s1-master.control",
+	}
+
+	matchingInstances := map[string]bool{
+		"s1-master":  true,
+		"s1-replica": true,
+		"s2-master":  true,
+	}
+
+	notRunningInstancesNames := filterRunningInstances(socketPathsByInstance, matchingInstances)
+	assert.Equal([]string{"s1-replica", "s2-master"}, notRunningInstancesNames)
+	assert.Equal(map[string]string{"s1-master": "tmp/run/myapp.s1-master.control"}, socketPathsByInstance)
+}
+
+func TestGetMatchingInstances(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	topologyReplicasets := &replicasets.TopologyReplicasets{
+		"router-uuid": {
+			Alias: "router",
+			Roles: []string{"vshard-router", "app.roles.api"},
+			Instances: replicasets.TopologyInstances{
+				{Alias: "router"},
+			},
+		},
+		"s-1-uuid": {
+			Alias: "s-1",
+			Roles: []string{"vshard-storage"},
+			Instances: replicasets.TopologyInstances{
+				{Alias: "s1-master"},
+				{Alias: "s1-replica"},
+			},
+		},
+		"s-2-uuid": {
+			Alias: "s-2",
+			Roles: []string{"vshard-storage"},
+			Instances: replicasets.TopologyInstances{
+				{Alias: "s2-master"},
+			},
+		},
+	}
+
+	var ctx context.Ctx
+
+	// by role
+	ctx.Admin.Role = "vshard-storage"
+	matchingInstances, err := getMatchingInstances(&ctx, topologyReplicasets)
+	assert.Nil(err)
+	assert.Equal(map[string]bool{"s1-master": true, "s1-replica": true, "s2-master": true}, matchingInstances)
+
+	// by replica set and role
+	ctx.Admin.ReplicasetName = "s-1"
+	matchingInstances, err = getMatchingInstances(&ctx, topologyReplicasets)
+	assert.Nil(err)
+	assert.Equal(map[string]bool{"s1-master": true, "s1-replica": true}, matchingInstances)
+
+	// replica set doesn't have the role
+	ctx.Admin.ReplicasetName = "router"
+	matchingInstances, err = getMatchingInstances(&ctx, topologyReplicasets)
+	assert.Nil(err)
+	assert.Len(matchingInstances, 0)
+
+	// unknown replica set
+	ctx.Admin.ReplicasetName = "unknown"
+	_, err = getMatchingInstances(&ctx, topologyReplicasets)
+	assert.EqualError(err, "Replica set unknown isn't found in current topology")
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/FZambia/tarantool"
+	"github.com/tarantool/cartridge-cli/cli/connector"
 	"github.com/tarantool/cartridge-cli/cli/context"
 )
 
@@ -32,10 +33,21 @@ func verifyOperationsPercentage(ctx *context.BenchCtx) error {
 	return nil
 }
 
-// spacePreset prepares space for a benchmark.
-func spacePreset(tarantoolConnection *tarantool.Connection) error {
-	dropBenchmarkSpace(tarantoolConnection)
-	return createBenchmarkSpace(tarantoolConnection)
+// spacesPreset prepares the workload spaces for a benchmark.
+func spacesPreset(tarantoolConnection *tarantool.Connection, workload *Workload) error {
+	for _, space := range workload.Spaces {
+		if err := createBenchmarkSpace(tarantoolConnection, space); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropBenchmarkSpaces deletes the workload spaces created by the benchmark.
+func dropBenchmarkSpaces(tarantoolConnection *tarantool.Connection, workload *Workload) {
+	for _, space := range workload.Spaces {
+		dropBenchmarkSpace(tarantoolConnection, space)
+	}
 }
 
 // incrementRequest increases the counter of successful/failed requests depending on the presence of an error.
@@ -80,15 +92,40 @@ func connectionLoop(
 	connectionWait.Wait()
 }
 
-// preFillBenchmarkSpaceIfRequired fills benchmark space
-// if insert count = 0 or PreFillingCount flag is explicitly specified.
-func preFillBenchmarkSpaceIfRequired(ctx context.BenchCtx, connectionPool []*tarantool.Connection) error {
-	if ctx.InsertCount == 0 || ctx.PreFillingCount != PreFillingCount {
-		fmt.Println("\nThe pre-filling of the space has started,\n" +
-			"because the insert operation is not specified\n" +
-			"or there was an explicit instruction for pre-filling.")
+// getFillCount returns the number of records to pre-fill the space.
+// The fill count specified for the space is used if any.
+// Otherwise, the space is filled with PreFillingCount records
+// if there are no insert operations or PreFillingCount flag is explicitly specified.
+func getFillCount(ctx context.BenchCtx, workload *Workload, space *Space) int {
+	if space.Fill != nil {
+		return *space.Fill
+	}
+
+	if !workload.hasInsertOperations(space) || ctx.PreFillingCount != PreFillingCount {
+		return ctx.PreFillingCount
+	}
+
+	return 0
+}
+
+// preFillBenchmarkSpacesIfRequired fills benchmark spaces
+// if insert operations are not specified or the fill count is explicitly specified.
+func preFillBenchmarkSpacesIfRequired(
+	ctx context.BenchCtx,
+	connectionPool []*tarantool.Connection,
+	workload *Workload,
+) error {
+	for _, space := range workload.Spaces {
+		fillCount := getFillCount(ctx, workload, space)
+		if fillCount == 0 {
+			continue
+		}
+
+		fmt.Printf("\nThe pre-filling of the space %s has started,\n"+
+			"because the insert operation is not specified\n"+
+			"or there was an explicit instruction for pre-filling.\n", space.Name)
 		fmt.Println("...")
-		filledCount, err := fillBenchmarkSpace(ctx, connectionPool)
+		filledCount, err := fillBenchmarkSpace(ctx, connectionPool, space, fillCount)
 		if err != nil {
 			return err
 		}
@@ -97,16 +134,49 @@ func preFillBenchmarkSpaceIfRequired(ctx context.BenchCtx, connectionPool []*tar
 	return nil
 }
 
+// startTLSProxyIfRequired starts the TLS proxy if SSL transport is specified.
+// The address to connect to is returned (the proxy address for SSL transport).
+func startTLSProxyIfRequired(ctx context.BenchCtx) (string, *connector.TLSProxy, error) {
+	connOpts, err := connector.GetConnOpts(ctx.URL, connector.Opts{
+		Transport: ctx.Transport,
+		SSL:       connector.SSLOpts(ctx.SSL),
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed to parse Tarantool address: %s", err)
+	}
+
+	if connOpts.Transport != connector.SSLTransport {
+		return ctx.URL, nil, nil
+	}
+
+	tlsProxy, err := connector.NewTLSProxy(connOpts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return tlsProxy.ConnString(), tlsProxy, nil
+}
+
 // Main benchmark function.
 func Run(ctx context.BenchCtx) error {
 	rand.Seed(time.Now().UnixNano())
 
-	if err := verifyOperationsPercentage(&ctx); err != nil {
+	workload, err := getWorkload(&ctx)
+	if err != nil {
+		return err
+	}
+
+	// SSL connections are established via the local TLS proxy.
+	url, tlsProxy, err := startTLSProxyIfRequired(ctx)
+	if err != nil {
 		return err
 	}
+	if tlsProxy != nil {
+		defer tlsProxy.Close()
+	}
 
 	// Connect to tarantool and preset space for benchmark.
-	tarantoolConnection, err := tarantool.Connect(ctx.URL, tarantool.Opts{
+	tarantoolConnection, err := tarantool.Connect(url, tarantool.Opts{
 		User:     ctx.User,
 		Password: ctx.Password,
 	})
@@ -117,16 +187,19 @@ func Run(ctx context.BenchCtx) error {
 	}
 	defer tarantoolConnection.Close()
 
-	printConfig(ctx, tarantoolConnection)
+	printConfig(ctx, tarantoolConnection, workload)
+
+	// Spaces created by the benchmark are dropped even if it fails.
+	defer dropBenchmarkSpaces(tarantoolConnection, workload)
 
-	if err := spacePreset(tarantoolConnection); err != nil {
+	if err := spacesPreset(tarantoolConnection, workload); err != nil {
 		return err
 	}
 
 	/// Сreate a "connectionPool" before starting the benchmark to exclude the connection establishment time from measurements.
 	connectionPool := make([]*tarantool.Connection, ctx.Connections)
 	for i := 0; i < ctx.Connections; i++ {
-		connectionPool[i], err = tarantool.Connect(ctx.URL, tarantool.Opts{
+		connectionPool[i], err = tarantool.Connect(url, tarantool.Opts{
 			User:     ctx.User,
 			Password: ctx.Password,
 		})
@@ -136,7 +209,7 @@ func Run(ctx context.BenchCtx) error {
 		defer connectionPool[i].Close()
 	}
 
-	if err := preFillBenchmarkSpaceIfRequired(ctx, connectionPool); err != nil {
+	if err := preFillBenchmarkSpacesIfRequired(ctx, connectionPool, workload); err != nil {
 		return err
 	}
 
@@ -156,41 +229,8 @@ func Run(ctx context.BenchCtx) error {
 		waitGroup.Add(1)
 		go func(connection *tarantool.Connection) {
 			defer waitGroup.Done()
-			requestsSequence := RequestsSequence{
-				[]RequestsGenerator{
-					{
-						Request{
-							insertOperation,
-							ctx,
-							connection,
-							&results,
-						},
-						ctx.InsertCount,
-					},
-					{
-						Request{
-							selectOperation,
-							ctx,
-							connection,
-							&results,
-						},
-						ctx.SelectCount,
-					},
-					{
-						Request{
-							updateOperation,
-							ctx,
-							connection,
-							&results,
-						},
-						ctx.UpdateCount,
-					},
-				},
-				0,
-				ctx.InsertCount,
-				sync.Mutex{},
-			}
-			connectionLoop(&ctx, &requestsSequence, backgroundCtx)
+			requestsSequence := newRequestsSequence(workload, connection, &results)
+			connectionLoop(&ctx, requestsSequence, backgroundCtx)
 		}(connectionPool[i])
 	}
 	// Sends "signal" to all "connectionLoop" and waits for them to complete.
@@ -201,7 +241,7 @@ func Run(ctx context.BenchCtx) error {
 	results.duration = time.Since(startTime).Seconds()
 	results.requestsPerSecond = int(float64(results.handledRequestsCount) / results.duration)
 
-	dropBenchmarkSpace(tarantoolConnection)
+	dropBenchmarkSpaces(tarantoolConnection, workload)
 	fmt.Println("Benchmark stop")
 
 	printResults(results)
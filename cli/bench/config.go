@@ -13,15 +13,10 @@ var (
 	benchSpaceName             = "__benchmark_space__"
 	benchSpacePrimaryIndexName = "__bench_primary_key__"
 	PreFillingCount            = 1000000
-	getRandomTupleCommand      = fmt.Sprintf(
-		"box.space.%s.index.%s:random",
-		benchSpaceName,
-		benchSpacePrimaryIndexName,
-	)
 )
 
 // printConfig output formatted config parameters.
-func printConfig(ctx context.BenchCtx, tarantoolConnection *tarantool.Connection) {
+func printConfig(ctx context.BenchCtx, tarantoolConnection *tarantool.Connection, workload *Workload) {
 	fmt.Printf("%s\n", tarantoolConnection.Greeting().Version)
 	fmt.Printf("Parameters:\n")
 	fmt.Printf("\tURL: %s\n", ctx.URL)
@@ -29,16 +24,63 @@ func printConfig(ctx context.BenchCtx, tarantoolConnection *tarantool.Connection
 	fmt.Printf("\tconnections: %d\n", ctx.Connections)
 	fmt.Printf("\tsimultaneous requests: %d\n", ctx.SimultaneousRequests)
 	fmt.Printf("\tduration: %d seconds\n", ctx.Duration)
-	fmt.Printf("\tkey size: %d bytes\n", ctx.KeySize)
-	fmt.Printf("\tdata size: %d bytes\n", ctx.DataSize)
-	fmt.Printf("\tinsert: %d percentages\n", ctx.InsertCount)
-	fmt.Printf("\tselect: %d percentages\n", ctx.SelectCount)
-	fmt.Printf("\tupdate: %d percentages\n\n", ctx.UpdateCount)
 
-	fmt.Printf("Data schema\n")
+	if ctx.WorkloadFile == "" {
+		fmt.Printf("\tkey size: %d bytes\n", ctx.KeySize)
+		fmt.Printf("\tdata size: %d bytes\n", ctx.DataSize)
+		fmt.Printf("\tinsert: %d percentages\n", ctx.InsertCount)
+		fmt.Printf("\tselect: %d percentages\n", ctx.SelectCount)
+		fmt.Printf("\tupdate: %d percentages\n\n", ctx.UpdateCount)
+	} else {
+		fmt.Printf("\tworkload: %s\n\n", ctx.WorkloadFile)
+		printOperations(workload)
+	}
+
+	for _, space := range workload.Spaces {
+		printSpaceSchema(space)
+	}
+}
+
+// printOperations output operations with their percentages.
+func printOperations(workload *Workload) {
+	totalWeight := 0
+	for _, operation := range workload.Operations {
+		totalWeight += operation.Weight
+	}
+
+	fmt.Printf("Operations:\n")
+	for _, operation := range workload.Operations {
+		target := operation.Space
+		if operation.Type == callOperationType {
+			target = operation.Function
+		}
+
+		fmt.Printf("\t%s %s: %.2f percentages\n",
+			operation.Type, target, float64(operation.Weight)*100/float64(totalWeight))
+	}
+	fmt.Println()
+}
+
+// printSpaceSchema output formatted space fields and their generators.
+func printSpaceSchema(space *Space) {
+	if space.Name == benchSpaceName {
+		fmt.Printf("Data schema\n")
+	} else {
+		fmt.Printf("Data schema of %s\n", space.Name)
+	}
+
 	w := tabwriter.NewWriter(os.Stdout, 1, 1, 1, ' ', 0)
-	fmt.Fprintf(w, "|\tkey\t|\tvalue\n")
-	fmt.Fprintf(w, "|\t------------------------------\t|\t------------------------------\n")
-	fmt.Fprintf(w, "|\trandom(%d)\t|\trandom(%d)\n", ctx.KeySize, ctx.DataSize)
+	for _, field := range space.Format {
+		fmt.Fprintf(w, "|\t%s\t", field.Name)
+	}
+	fmt.Fprintf(w, "\n")
+	for range space.Format {
+		fmt.Fprintf(w, "|\t------------------------------\t")
+	}
+	fmt.Fprintf(w, "\n")
+	for _, gen := range space.generators {
+		fmt.Fprintf(w, "|\t%s\t", gen)
+	}
+	fmt.Fprintf(w, "\n")
 	w.Flush()
 }
@@ -0,0 +1,148 @@
+package bench
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync/atomic"
+
+	"github.com/tarantool/cartridge-cli/cli/common"
+)
+
+const (
+	intGenerator      = "int"
+	uuidGenerator     = "uuid"
+	stringGenerator   = "string"
+	sequenceGenerator = "sequence"
+)
+
+// valueGenerator generates values of space fields and stored procedures arguments.
+// Generators are shared by all connections, so they should be goroutine-safe.
+type valueGenerator interface {
+	generate() interface{}
+	String() string
+}
+
+// intRangeGenerator generates random integers in [min, max].
+type intRangeGenerator struct {
+	min int64
+	max int64
+}
+
+func (gen *intRangeGenerator) generate() interface{} {
+	// The range size is computed in unsigned arithmetic to avoid overflow.
+	// It's zero for the full int64 range.
+	rangeSize := uint64(gen.max) - uint64(gen.min) + 1
+
+	var offset uint64
+	switch {
+	case rangeSize == 0:
+		offset = rand.Uint64()
+	case rangeSize <= math.MaxInt64:
+		offset = uint64(rand.Int63n(int64(rangeSize)))
+	default:
+		// More than a half of uint64 values are in the range.
+		for offset = rand.Uint64(); offset >= rangeSize; offset = rand.Uint64() {
+		}
+	}
+
+	return intValue(gen.min + int64(offset))
+}
+
+func (gen *intRangeGenerator) String() string {
+	return fmt.Sprintf("int(%d..%d)", gen.min, gen.max)
+}
+
+// uuidValueGenerator generates random UUIDs (version 4) in the string representation.
+type uuidValueGenerator struct{}
+
+func (gen *uuidValueGenerator) generate() interface{} {
+	uuid := make([]byte, 16)
+	rand.Read(uuid)
+
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:])
+}
+
+func (gen *uuidValueGenerator) String() string {
+	return "uuid"
+}
+
+// stringValueGenerator generates random strings of the specified size.
+type stringValueGenerator struct {
+	size int
+}
+
+func (gen *stringValueGenerator) generate() interface{} {
+	return common.RandomString(gen.size)
+}
+
+func (gen *stringValueGenerator) String() string {
+	return fmt.Sprintf("random(%d)", gen.size)
+}
+
+// sequenceValueGenerator generates increasing integers starting from start.
+type sequenceValueGenerator struct {
+	start   int64
+	counter int64
+}
+
+func (gen *sequenceValueGenerator) generate() interface{} {
+	return intValue(gen.start + atomic.AddInt64(&gen.counter, 1) - 1)
+}
+
+func (gen *sequenceValueGenerator) String() string {
+	return fmt.Sprintf("sequence(%d)", gen.start)
+}
+
+// newValueGenerator creates the value generator described by the configuration.
+func newValueGenerator(conf *GeneratorConf) (valueGenerator, error) {
+	switch conf.Type {
+	case intGenerator:
+		if conf.Min == nil || conf.Max == nil {
+			return nil, fmt.Errorf("Min and max should be specified")
+		}
+		if *conf.Min > *conf.Max {
+			return nil, fmt.Errorf("Min should be less than or equal to max")
+		}
+		return &intRangeGenerator{min: *conf.Min, max: *conf.Max}, nil
+	case uuidGenerator:
+		return &uuidValueGenerator{}, nil
+	case stringGenerator:
+		if conf.Size <= 0 {
+			return nil, fmt.Errorf("Size should be greater than zero")
+		}
+		return &stringValueGenerator{size: conf.Size}, nil
+	case sequenceGenerator:
+		return &sequenceValueGenerator{start: conf.Start}, nil
+	case "":
+		return nil, fmt.Errorf("Generator type should be specified")
+	default:
+		return nil, fmt.Errorf(
+			"Unknown generator type %q. Supported types: %s, %s, %s, %s",
+			conf.Type, intGenerator, uuidGenerator, stringGenerator, sequenceGenerator,
+		)
+	}
+}
+
+// intValue returns non-negative integers as unsigned ones.
+// Signed integers are encoded with MessagePack int format
+// that isn't accepted by unsigned space fields.
+func intValue(n int64) interface{} {
+	if n >= 0 {
+		return uint64(n)
+	}
+	return n
+}
+
+// generateValues generates values by all specified generators.
+func generateValues(generators []valueGenerator) []interface{} {
+	values := make([]interface{}, len(generators))
+	for i, gen := range generators {
+		values[i] = gen.generate()
+	}
+
+	return values
+}
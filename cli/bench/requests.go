@@ -1,56 +1,143 @@
 package bench
 
 import (
+	"fmt"
 	"math/rand"
-	"reflect"
 
 	"github.com/FZambia/tarantool"
-	"github.com/tarantool/cartridge-cli/cli/common"
 )
 
+// requestOperations describes the request operation of each operation type.
+var requestOperations = map[string]RequestOperaion{
+	insertOperationType:  insertOperation,
+	selectOperationType:  selectOperation,
+	updateOperationType:  updateOperation,
+	deleteOperationType:  deleteOperation,
+	replaceOperationType: replaceOperation,
+	upsertOperationType:  upsertOperation,
+	callOperationType:    callOperation,
+}
+
+// getRandomTupleCommand returns the function that returns a random tuple of the space index.
+func getRandomTupleCommand(spaceName, indexName string) string {
+	return fmt.Sprintf("box.space.%s.index.%s:random", spaceName, indexName)
+}
+
+// getUpdateOps returns ops that assign new values to the operation fields.
+func getUpdateOps(workloadOperation *Operation) []tarantool.Op {
+	space := workloadOperation.space
+
+	ops := make([]tarantool.Op, len(workloadOperation.updateFieldNos))
+	for i, fieldNo := range workloadOperation.updateFieldNos {
+		ops[i] = tarantool.OpAssign(uint64(fieldNo), space.generators[fieldNo].generate())
+	}
+
+	return ops
+}
+
+// getRandomTupleKey returns the primary key of a random space tuple.
+func getRandomTupleKey(request *Request) ([]interface{}, error) {
+	space := request.workloadOperation.space
+
+	getRandomTupleResponse, err := request.tarantoolConnection.Exec(
+		tarantool.Call(getRandomTupleCommand(space.Name, space.Indexes[0].Name),
+			[]interface{}{rand.Int()}))
+	if err != nil {
+		return nil, err
+	}
+
+	data := getRandomTupleResponse.Data
+	if len(data) == 0 {
+		return nil, fmt.Errorf("Space %s is empty", space.Name)
+	}
+
+	tuple, ok := data[0].([]interface{})
+	if !ok || len(tuple) == 0 {
+		return nil, fmt.Errorf("Space %s is empty", space.Name)
+	}
+
+	return space.getPrimaryKey(tuple)
+}
+
 // insertOperation execute insert operation.
 func insertOperation(request *Request) {
+	space := request.workloadOperation.space
+
 	_, err := request.tarantoolConnection.Exec(
-		tarantool.Insert(
-			benchSpaceName,
-			[]interface{}{
-				common.RandomString(request.ctx.KeySize),
-				common.RandomString(request.ctx.DataSize),
-			}))
+		tarantool.Insert(space.Name, space.generateTuple()))
 	request.results.incrementRequestsCounters(err)
 }
 
 // selectOperation execute select operation.
 func selectOperation(request *Request) {
+	workloadOperation := request.workloadOperation
+
 	_, err := request.tarantoolConnection.Exec(tarantool.Call(
-		getRandomTupleCommand,
+		getRandomTupleCommand(workloadOperation.space.Name, workloadOperation.indexName),
 		[]interface{}{rand.Int()}))
 	request.results.incrementRequestsCounters(err)
 }
 
 // updateOperation execute update operation.
 func updateOperation(request *Request) {
-	getRandomTupleResponse, err := request.tarantoolConnection.Exec(
-		tarantool.Call(getRandomTupleCommand,
-			[]interface{}{rand.Int()}))
+	space := request.workloadOperation.space
+
+	key, err := getRandomTupleKey(request)
 	if err == nil {
-		data := getRandomTupleResponse.Data
-		if len(data) > 0 {
-			key := reflect.ValueOf(data[0]).Index(0).Elem().String()
-			_, err := request.tarantoolConnection.Exec(
-				tarantool.Update(
-					benchSpaceName,
-					benchSpacePrimaryIndexName,
-					[]interface{}{key},
-					[]tarantool.Op{tarantool.Op(
-						tarantool.OpAssign(
-							2,
-							common.RandomString(request.ctx.DataSize)))}))
-			request.results.incrementRequestsCounters(err)
-		}
+		_, err := request.tarantoolConnection.Exec(
+			tarantool.Update(
+				space.Name,
+				space.Indexes[0].Name,
+				key,
+				getUpdateOps(request.workloadOperation)))
+		request.results.incrementRequestsCounters(err)
 	}
 }
 
+// deleteOperation execute delete operation.
+func deleteOperation(request *Request) {
+	space := request.workloadOperation.space
+
+	key, err := getRandomTupleKey(request)
+	if err == nil {
+		_, err := request.tarantoolConnection.Exec(
+			tarantool.Delete(space.Name, space.Indexes[0].Name, key))
+		request.results.incrementRequestsCounters(err)
+	}
+}
+
+// replaceOperation execute replace operation.
+func replaceOperation(request *Request) {
+	space := request.workloadOperation.space
+
+	_, err := request.tarantoolConnection.Exec(
+		tarantool.Replace(space.Name, space.generateTuple()))
+	request.results.incrementRequestsCounters(err)
+}
+
+// upsertOperation execute upsert operation.
+func upsertOperation(request *Request) {
+	space := request.workloadOperation.space
+
+	_, err := request.tarantoolConnection.Exec(
+		tarantool.Upsert(
+			space.Name,
+			space.generateTuple(),
+			getUpdateOps(request.workloadOperation)))
+	request.results.incrementRequestsCounters(err)
+}
+
+// callOperation execute call of the stored procedure.
+func callOperation(request *Request) {
+	workloadOperation := request.workloadOperation
+
+	_, err := request.tarantoolConnection.Exec(
+		tarantool.Call(
+			workloadOperation.Function,
+			generateValues(workloadOperation.argsGenerators)))
+	request.results.incrementRequestsCounters(err)
+}
+
 // getNext return next operation in operations sequence.
 func (requestsSequence *RequestsSequence) getNext() Request {
 	// If at the moment the number of remaining requests = 0,
@@ -71,3 +158,30 @@ func (requestsSequence *RequestsSequence) getNext() Request {
 	requestsSequence.currentCounter--
 	return requestsSequence.requests[requestsSequence.currentRequestIndex].request
 }
+
+// newRequestsSequence creates the sequence of the workload operations requests
+// issued in proportion to the operations weights.
+func newRequestsSequence(
+	workload *Workload,
+	tarantoolConnection *tarantool.Connection,
+	results *Results,
+) *RequestsSequence {
+	requestsGenerators := make([]RequestsGenerator, len(workload.Operations))
+	for i, workloadOperation := range workload.Operations {
+		requestsGenerators[i] = RequestsGenerator{
+			Request{
+				requestOperations[workloadOperation.Type],
+				workloadOperation,
+				tarantoolConnection,
+				results,
+			},
+			workloadOperation.Weight,
+		}
+	}
+
+	return &RequestsSequence{
+		requests:            requestsGenerators,
+		currentRequestIndex: 0,
+		currentCounter:      requestsGenerators[0].count,
+	}
+}
@@ -3,65 +3,138 @@ package bench
 import (
 	bctx "context"
 	"fmt"
-	"reflect"
 	"sync"
 
 	"github.com/FZambia/tarantool"
-	"github.com/tarantool/cartridge-cli/cli/common"
 	"github.com/tarantool/cartridge-cli/cli/context"
 )
 
-// createBenchmarkSpace creates benchmark space with formatting and primary index.
-func createBenchmarkSpace(tarantoolConnection *tarantool.Connection) error {
-	// Creating space.
-	createCommand := "return box.schema.space.create(...).name"
-	_, err := tarantoolConnection.Exec(tarantool.Eval(createCommand, []interface{}{benchSpaceName, map[string]bool{"if_not_exists": true}}))
+// getSpacePrimaryIndexBody returns if the space exists and the name of its primary index.
+var getSpacePrimaryIndexBody = `
+local space = box.space[...]
+if space == nil then
+    return false, ''
+end
+local primary_index = space.index[0]
+return true, primary_index ~= nil and primary_index.name or ''
+`
+
+// createSpaceBody creates the space with the format and indexes.
+var createSpaceBody = `
+local name, format, indexes = ...
+local space = box.schema.space.create(name, {format = format})
+for _, index in ipairs(indexes) do
+    space:create_index(index.name, index.opts)
+end
+return space.name
+`
+
+// getExistingSpace checks if the space exists and returns the name of its primary index.
+func getExistingSpace(tarantoolConnection *tarantool.Connection, spaceName string) (bool, string, error) {
+	resp, err := tarantoolConnection.Exec(tarantool.Eval(getSpacePrimaryIndexBody, []interface{}{spaceName}))
 	if err != nil {
-		return err
+		return false, "", err
+	}
+
+	if len(resp.Data) != 2 {
+		return false, "", fmt.Errorf("Unexpected response: %v", resp.Data)
 	}
 
-	// Formatting space.
-	formatCommand := fmt.Sprintf("box.space.%s:format", benchSpaceName)
-	_, err = tarantoolConnection.Exec(tarantool.Call(formatCommand, [][]map[string]string{
-		{
-			{"name": "key", "type": "string"},
-			{"name": "value", "type": "string"},
-		},
-	}))
+	exists, _ := resp.Data[0].(bool)
+	primaryIndexName, _ := resp.Data[1].(string)
+
+	return exists, primaryIndexName, nil
+}
+
+// canDropExistingSpace checks if the existing space can be dropped before the benchmark.
+// The user space is dropped only if it's explicitly allowed by drop_existing option.
+// The default benchmark space is identified by its primary index name.
+func canDropExistingSpace(space *Space, primaryIndexName string) bool {
+	if space.DropExisting {
+		return true
+	}
+
+	return space.isDefault && primaryIndexName == space.Indexes[0].Name
+}
+
+// createBenchmarkSpace creates benchmark space with formatting and indexes.
+// If the space already exists, it's dropped only if it's allowed.
+func createBenchmarkSpace(tarantoolConnection *tarantool.Connection, space *Space) error {
+	exists, primaryIndexName, err := getExistingSpace(tarantoolConnection, space.Name)
 	if err != nil {
-		return err
+		return fmt.Errorf("Failed to check space %s: %s", space.Name, err)
+	}
+
+	if exists {
+		if !canDropExistingSpace(space, primaryIndexName) {
+			return fmt.Errorf(
+				"Space %s already exists. Specify drop_existing: true in the workload to drop it before the benchmark",
+				space.Name,
+			)
+		}
+
+		dropCommand := fmt.Sprintf("box.space.%s:drop", space.Name)
+		if _, err := tarantoolConnection.Exec(tarantool.Call(dropCommand, []interface{}{})); err != nil {
+			return fmt.Errorf("Failed to drop existing space %s: %s", space.Name, err)
+		}
+	}
+
+	format := make([]map[string]string, len(space.Format))
+	for i, field := range space.Format {
+		format[i] = map[string]string{"name": field.Name, "type": field.Type}
+	}
+
+	indexes := make([]map[string]interface{}, len(space.Indexes))
+	for i, index := range space.Indexes {
+		opts := map[string]interface{}{
+			"parts": index.Parts,
+		}
+		if index.Type != "" {
+			opts["type"] = index.Type
+		}
+		if index.Unique != nil {
+			opts["unique"] = *index.Unique
+		}
+
+		indexes[i] = map[string]interface{}{"name": index.Name, "opts": opts}
 	}
 
-	// Creating primary index.
-	createIndexCommand := fmt.Sprintf("box.space.%s:create_index", benchSpaceName)
-	_, err = tarantoolConnection.Exec(tarantool.Call(createIndexCommand, []interface{}{
-		benchSpacePrimaryIndexName,
-		map[string]interface{}{
-			"parts":         []string{"key"},
-			"if_not_exists": true,
-		},
-	}))
-	return err
+	_, err = tarantoolConnection.Exec(tarantool.Eval(createSpaceBody, []interface{}{space.Name, format, indexes}))
+	if err != nil {
+		// The space can be created even if some index creation failed
+		space.created, _, _ = getExistingSpace(tarantoolConnection, space.Name)
+		return fmt.Errorf("Failed to create space %s: %s", space.Name, err)
+	}
+
+	space.created = true
+	return nil
 }
 
 // dropBenchmarkSpace deletes benchmark space.
-func dropBenchmarkSpace(tarantoolConnection *tarantool.Connection) error {
-	checkCommand := fmt.Sprintf("return box.space.%s.index[0].name", benchSpaceName)
-	indexName, err := tarantoolConnection.Exec(tarantool.Eval(checkCommand, []interface{}{}))
-	if err != nil {
-		return err
+// Only the space created by this benchmark run is dropped.
+func dropBenchmarkSpace(tarantoolConnection *tarantool.Connection, space *Space) error {
+	if !space.created {
+		return nil
 	}
-	if reflect.ValueOf(indexName.Data).Index(0).Elem().String() == benchSpacePrimaryIndexName {
-		dropCommand := fmt.Sprintf("box.space.%s:drop", benchSpaceName)
-		_, err := tarantoolConnection.Exec(tarantool.Call(dropCommand, []interface{}{}))
+
+	dropCommand := fmt.Sprintf("box.space.%s:drop", space.Name)
+	if _, err := tarantoolConnection.Exec(tarantool.Call(dropCommand, []interface{}{})); err != nil {
 		return err
 	}
+
+	space.created = false
 	return nil
 }
 
-// fillBenchmarkSpace fills benchmark space with a PreFillingCount number of records
+// fillBenchmarkSpace fills benchmark space with a fillCount number of records
 // using connectionPool for fast filling.
-func fillBenchmarkSpace(ctx context.BenchCtx, connectionPool []*tarantool.Connection) (int, error) {
+// Records are replaced, so keys generated twice by random generators don't fail the filling.
+func fillBenchmarkSpace(
+	ctx context.BenchCtx,
+	connectionPool []*tarantool.Connection,
+	space *Space,
+	fillCount int,
+) (int, error) {
 	var insertMutex sync.Mutex
 	var waitGroup sync.WaitGroup
 	filledCount := 0
@@ -72,25 +145,22 @@ func fillBenchmarkSpace(ctx context.BenchCtx, connectionPool []*tarantool.Connec
 		waitGroup.Add(1)
 		go func(tarantoolConnection *tarantool.Connection) {
 			defer waitGroup.Done()
-			for filledCount < ctx.PreFillingCount && len(errorChan) == 0 {
+			for filledCount < fillCount && len(errorChan) == 0 {
 				select {
 				case <-backgroundCtx.Done():
 					return
 				default:
 					// Lock mutex for checking extra iteration and increment counter.
 					insertMutex.Lock()
-					if filledCount == ctx.PreFillingCount {
+					if filledCount == fillCount {
 						insertMutex.Unlock()
 						return
 					}
 					filledCount++
 					insertMutex.Unlock()
-					_, err := tarantoolConnection.Exec(tarantool.Insert(
-						benchSpaceName,
-						[]interface{}{
-							common.RandomString(ctx.KeySize),
-							common.RandomString(ctx.DataSize),
-						},
+					_, err := tarantoolConnection.Exec(tarantool.Replace(
+						space.Name,
+						space.generateTuple(),
 					))
 					if err != nil {
 						fmt.Println(err)
@@ -4,7 +4,6 @@ import (
 	"sync"
 
 	"github.com/FZambia/tarantool"
-	"github.com/tarantool/cartridge-cli/cli/context"
 )
 
 // Results describes set of benchmark results.
@@ -16,13 +15,13 @@ type Results struct {
 	requestsPerSecond    int     // Cumber of requests per second - the main measured value.
 }
 
-// RequestOperaion describes insert, select or update operation in request.
+// RequestOperaion describes insert, select, update, delete, replace, upsert or call operation in request.
 type RequestOperaion func(*Request)
 
 // Request describes various types of requests.
 type Request struct {
-	operation           RequestOperaion // insertOperation, selectOperation or updateOperation.
-	ctx                 context.BenchCtx
+	operation           RequestOperaion // insertOperation, selectOperation, updateOperation, etc.
+	workloadOperation   *Operation      // workloadOperation describes the operation space and generators.
 	tarantoolConnection *tarantool.Connection
 	results             *Results
 }
@@ -0,0 +1,449 @@
+package bench
+
+import (
+	"fmt"
+
+	"github.com/tarantool/cartridge-cli/cli/common"
+	"github.com/tarantool/cartridge-cli/cli/context"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	insertOperationType  = "insert"
+	selectOperationType  = "select"
+	updateOperationType  = "update"
+	deleteOperationType  = "delete"
+	replaceOperationType = "replace"
+	upsertOperationType  = "upsert"
+	callOperationType    = "call"
+
+	defaultPrimaryIndexName = "primary"
+)
+
+// Workload describes benchmark spaces and operations performed on them.
+//
+// Example:
+//
+//	spaces:
+//	  - name: users
+//	    format:
+//	      - name: id
+//	        type: unsigned
+//	        gen: {type: sequence, start: 1}
+//	      - name: name
+//	        type: string
+//	        gen: {type: string, size: 16}
+//	      - name: age
+//	        type: unsigned
+//	        gen: {type: int, min: 18, max: 99}
+//	    indexes:
+//	      - name: primary
+//	        parts: [id]
+//	      - name: age
+//	        parts: [age]
+//	        unique: false
+//	    fill: 10000
+//	operations:
+//	  - type: insert
+//	    space: users
+//	    weight: 20
+//	  - type: select
+//	    space: users
+//	    index: age
+//	    weight: 70
+//	  - type: call
+//	    function: get_user
+//	    args:
+//	      - {type: int, min: 1, max: 10000}
+//	    weight: 10
+type Workload struct {
+	Spaces     []*Space     `yaml:"spaces"`
+	Operations []*Operation `yaml:"operations"`
+}
+
+// Space describes benchmark space.
+// It's created before the benchmark and dropped after it.
+// The benchmark fails if the space already exists,
+// unless DropExisting is set.
+type Space struct {
+	Name    string   `yaml:"name"`
+	Format  []*Field `yaml:"format"`
+	Indexes []*Index `yaml:"indexes"`
+	// Fill is the number of records to pre-fill the space.
+	Fill *int `yaml:"fill"`
+	// DropExisting allows to drop the existing space before the benchmark.
+	DropExisting bool `yaml:"drop_existing"`
+
+	// isDefault is set for the default benchmark space
+	// that is identified by its primary index name.
+	isDefault bool
+	// created is set if the space was created by the benchmark.
+	created bool
+
+	fieldNos   map[string]int
+	generators []valueGenerator
+	// primaryKeyFieldNos describes fields of the primary index parts.
+	primaryKeyFieldNos []int
+}
+
+// Field describes space field and generator of its values.
+type Field struct {
+	Name      string        `yaml:"name"`
+	Type      string        `yaml:"type"`
+	Generator GeneratorConf `yaml:"gen"`
+}
+
+// Index describes space index. The first index of the space is primary.
+type Index struct {
+	Name   string   `yaml:"name"`
+	Type   string   `yaml:"type"`
+	Unique *bool    `yaml:"unique"`
+	Parts  []string `yaml:"parts"`
+}
+
+// GeneratorConf describes values generator:
+//   - int - random integer in [min, max], both bounds are required;
+//   - uuid - random UUID string;
+//   - string - random string of size bytes;
+//   - sequence - increasing integers starting from start.
+type GeneratorConf struct {
+	Type  string `yaml:"type"`
+	Min   *int64 `yaml:"min"`
+	Max   *int64 `yaml:"max"`
+	Size  int    `yaml:"size"`
+	Start int64  `yaml:"start"`
+}
+
+// Operation describes benchmark operation.
+// Operations are issued in proportion to their weights.
+type Operation struct {
+	Type  string `yaml:"type"`
+	Space string `yaml:"space"`
+	// Index is used by select operation, defaults to the primary index.
+	Index string `yaml:"index"`
+	// Fields are assigned by update and upsert operations,
+	// default to all fields that aren't parts of the primary index.
+	Fields []string `yaml:"fields"`
+	// Function and Args are used by call operation.
+	Function string          `yaml:"function"`
+	Args     []GeneratorConf `yaml:"args"`
+	Weight   int             `yaml:"weight"`
+
+	space          *Space
+	indexName      string
+	updateFieldNos []int
+	argsGenerators []valueGenerator
+}
+
+// getWorkload returns the benchmark workload.
+// It's read from the workload file if specified, otherwise
+// the default workload is described by the insert, select and update flags.
+func getWorkload(ctx *context.BenchCtx) (*Workload, error) {
+	var workload *Workload
+
+	if ctx.WorkloadFile != "" {
+		var err error
+		if workload, err = readWorkloadFile(ctx.WorkloadFile); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := verifyOperationsPercentage(ctx); err != nil {
+			return nil, err
+		}
+
+		workload = getDefaultWorkload(ctx)
+	}
+
+	if err := workload.prepare(); err != nil {
+		return nil, fmt.Errorf("Invalid workload: %s", err)
+	}
+
+	return workload, nil
+}
+
+// getDefaultWorkload returns the workload with one space
+// with random string keys and values.
+func getDefaultWorkload(ctx *context.BenchCtx) *Workload {
+	return &Workload{
+		Spaces: []*Space{
+			{
+				Name: benchSpaceName,
+				Format: []*Field{
+					{Name: "key", Type: "string", Generator: GeneratorConf{Type: stringGenerator, Size: ctx.KeySize}},
+					{Name: "value", Type: "string", Generator: GeneratorConf{Type: stringGenerator, Size: ctx.DataSize}},
+				},
+				Indexes: []*Index{
+					{Name: benchSpacePrimaryIndexName, Parts: []string{"key"}},
+				},
+				isDefault: true,
+			},
+		},
+		Operations: []*Operation{
+			{Type: insertOperationType, Space: benchSpaceName, Weight: ctx.InsertCount},
+			{Type: selectOperationType, Space: benchSpaceName, Weight: ctx.SelectCount},
+			{Type: updateOperationType, Space: benchSpaceName, Weight: ctx.UpdateCount},
+		},
+	}
+}
+
+// readWorkloadFile reads the workload from the YAML file.
+func readWorkloadFile(workloadFilePath string) (*Workload, error) {
+	content, err := common.GetFileContentBytes(workloadFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read workload file: %s", err)
+	}
+
+	var workload Workload
+	if err := yaml.UnmarshalStrict(content, &workload); err != nil {
+		return nil, fmt.Errorf("Failed to parse workload file: %s", err)
+	}
+
+	return &workload, nil
+}
+
+// prepare checks the workload and creates values generators.
+func (workload *Workload) prepare() error {
+	if len(workload.Spaces) == 0 && len(workload.Operations) == 0 {
+		return fmt.Errorf("Workload is empty")
+	}
+
+	spaces := make(map[string]*Space)
+	for _, space := range workload.Spaces {
+		if err := space.prepare(); err != nil {
+			return fmt.Errorf("Invalid space %q: %s", space.Name, err)
+		}
+
+		if _, found := spaces[space.Name]; found {
+			return fmt.Errorf("Space %q is specified twice", space.Name)
+		}
+		spaces[space.Name] = space
+	}
+
+	totalWeight := 0
+	for i, operation := range workload.Operations {
+		if err := operation.prepare(spaces); err != nil {
+			return fmt.Errorf("Invalid operation #%d (%s): %s", i+1, operation.Type, err)
+		}
+
+		totalWeight += operation.Weight
+	}
+
+	if totalWeight == 0 {
+		return fmt.Errorf("Total operations weight should be greater than zero")
+	}
+
+	return nil
+}
+
+func (space *Space) prepare() error {
+	if space.Name == "" {
+		return fmt.Errorf("Space name should be specified")
+	}
+
+	if len(space.Format) == 0 {
+		return fmt.Errorf("Space format should be specified")
+	}
+
+	if space.Fill != nil && *space.Fill < 0 {
+		return fmt.Errorf("Fill shouldn't be negative")
+	}
+
+	space.fieldNos = make(map[string]int)
+	space.generators = make([]valueGenerator, len(space.Format))
+
+	for i, field := range space.Format {
+		if field.Name == "" || field.Type == "" {
+			return fmt.Errorf("Field #%d: name and type should be specified", i+1)
+		}
+
+		if _, found := space.fieldNos[field.Name]; found {
+			return fmt.Errorf("Field %q is specified twice", field.Name)
+		}
+		space.fieldNos[field.Name] = i
+
+		gen, err := newValueGenerator(&field.Generator)
+		if err != nil {
+			return fmt.Errorf("Field %q: %s", field.Name, err)
+		}
+		space.generators[i] = gen
+	}
+
+	if len(space.Indexes) == 0 {
+		space.Indexes = []*Index{{Parts: []string{space.Format[0].Name}}}
+	}
+
+	if space.Indexes[0].Name == "" {
+		space.Indexes[0].Name = defaultPrimaryIndexName
+	}
+
+	for i, index := range space.Indexes {
+		if index.Name == "" {
+			return fmt.Errorf("Index #%d: name should be specified", i+1)
+		}
+
+		if len(index.Parts) == 0 {
+			return fmt.Errorf("Index %q: parts should be specified", index.Name)
+		}
+
+		for _, part := range index.Parts {
+			if _, found := space.fieldNos[part]; !found {
+				return fmt.Errorf("Index %q: field %q isn't found in space format", index.Name, part)
+			}
+		}
+	}
+
+	primaryIndex := space.Indexes[0]
+	if primaryIndex.Unique != nil && !*primaryIndex.Unique {
+		return fmt.Errorf("Primary index %q should be unique", primaryIndex.Name)
+	}
+
+	space.primaryKeyFieldNos = make([]int, len(primaryIndex.Parts))
+	for i, part := range primaryIndex.Parts {
+		space.primaryKeyFieldNos[i] = space.fieldNos[part]
+	}
+
+	return nil
+}
+
+func (space *Space) getIndex(indexName string) *Index {
+	for _, index := range space.Indexes {
+		if index.Name == indexName {
+			return index
+		}
+	}
+
+	return nil
+}
+
+// isPrimaryKeyField checks if the field is a part of the primary index.
+func (space *Space) isPrimaryKeyField(fieldNo int) bool {
+	for _, primaryKeyFieldNo := range space.primaryKeyFieldNos {
+		if fieldNo == primaryKeyFieldNo {
+			return true
+		}
+	}
+
+	return false
+}
+
+// generateTuple generates tuple values by the space fields generators.
+func (space *Space) generateTuple() []interface{} {
+	return generateValues(space.generators)
+}
+
+// getPrimaryKey returns the primary key of the tuple.
+func (space *Space) getPrimaryKey(tuple []interface{}) ([]interface{}, error) {
+	key := make([]interface{}, len(space.primaryKeyFieldNos))
+	for i, fieldNo := range space.primaryKeyFieldNos {
+		if fieldNo >= len(tuple) {
+			return nil, fmt.Errorf("Tuple doesn't contain primary key field #%d", fieldNo+1)
+		}
+		key[i] = tuple[fieldNo]
+	}
+
+	return key, nil
+}
+
+func (operation *Operation) prepare(spaces map[string]*Space) error {
+	if operation.Weight < 0 {
+		return fmt.Errorf("Weight shouldn't be negative")
+	}
+
+	switch operation.Type {
+	case callOperationType:
+		if operation.Function == "" {
+			return fmt.Errorf("Function should be specified")
+		}
+
+		operation.argsGenerators = make([]valueGenerator, len(operation.Args))
+		for i := range operation.Args {
+			gen, err := newValueGenerator(&operation.Args[i])
+			if err != nil {
+				return fmt.Errorf("Argument #%d: %s", i+1, err)
+			}
+			operation.argsGenerators[i] = gen
+		}
+
+		return nil
+	case insertOperationType, selectOperationType, updateOperationType,
+		deleteOperationType, replaceOperationType, upsertOperationType:
+	default:
+		return fmt.Errorf(
+			"Unknown operation type. Supported types: %s, %s, %s, %s, %s, %s, %s",
+			insertOperationType, selectOperationType, updateOperationType, deleteOperationType,
+			replaceOperationType, upsertOperationType, callOperationType,
+		)
+	}
+
+	space, found := spaces[operation.Space]
+	if !found {
+		return fmt.Errorf("Space %q isn't described in the workload", operation.Space)
+	}
+	operation.space = space
+
+	operation.indexName = space.Indexes[0].Name
+	if operation.Index != "" {
+		if operation.Type != selectOperationType {
+			return fmt.Errorf("Index can be specified only for %s operation", selectOperationType)
+		}
+
+		if space.getIndex(operation.Index) == nil {
+			return fmt.Errorf("Index %q isn't found in space %q", operation.Index, space.Name)
+		}
+		operation.indexName = operation.Index
+	}
+
+	if operation.Type != updateOperationType && operation.Type != upsertOperationType {
+		if len(operation.Fields) > 0 {
+			return fmt.Errorf(
+				"Fields can be specified only for %s and %s operations", updateOperationType, upsertOperationType,
+			)
+		}
+
+		return nil
+	}
+
+	if len(operation.Fields) == 0 {
+		for fieldNo := range space.Format {
+			if !space.isPrimaryKeyField(fieldNo) {
+				operation.updateFieldNos = append(operation.updateFieldNos, fieldNo)
+			}
+		}
+	}
+
+	for _, fieldName := range operation.Fields {
+		fieldNo, found := space.fieldNos[fieldName]
+		if !found {
+			return fmt.Errorf("Field %q isn't found in space %q format", fieldName, space.Name)
+		}
+
+		if space.isPrimaryKeyField(fieldNo) {
+			return fmt.Errorf("Primary key field %q can't be updated", fieldName)
+		}
+
+		operation.updateFieldNos = append(operation.updateFieldNos, fieldNo)
+	}
+
+	if len(operation.updateFieldNos) == 0 {
+		return fmt.Errorf("Space %q has no fields to update", space.Name)
+	}
+
+	return nil
+}
+
+// hasInsertOperations checks if the workload has operations
+// that insert new tuples into the space.
+func (workload *Workload) hasInsertOperations(space *Space) bool {
+	for _, operation := range workload.Operations {
+		if operation.space != space || operation.Weight == 0 {
+			continue
+		}
+
+		switch operation.Type {
+		case insertOperationType, replaceOperationType, upsertOperationType:
+			return true
+		}
+	}
+
+	return false
+}
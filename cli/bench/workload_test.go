@@ -0,0 +1,307 @@
+package bench
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tarantool/cartridge-cli/cli/context"
+)
+
+const testWorkload = `
+spaces:
+  - name: users
+    format:
+      - name: id
+        type: unsigned
+        gen: {type: sequence, start: 1}
+      - name: uuid
+        type: string
+        gen: {type: uuid}
+      - name: name
+        type: string
+        gen: {type: string, size: 16}
+      - name: age
+        type: unsigned
+        gen: {type: int, min: 18, max: 99}
+    indexes:
+      - name: primary
+        parts: [id]
+      - name: age
+        parts: [age]
+        unique: false
+    fill: 100
+operations:
+  - type: insert
+    space: users
+    weight: 20
+  - type: select
+    space: users
+    index: age
+    weight: 60
+  - type: update
+    space: users
+    fields: [age]
+    weight: 10
+  - type: upsert
+    space: users
+    weight: 5
+  - type: delete
+    space: users
+    weight: 5
+  - type: call
+    function: get_user
+    args:
+      - {type: int, min: 1, max: 100}
+    weight: 10
+`
+
+func writeWorkloadFile(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "bench")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	workloadFilePath := filepath.Join(dir, "bench.yml")
+	if err := ioutil.WriteFile(workloadFilePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return workloadFilePath
+}
+
+func TestGetWorkloadFromFile(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	ctx := context.BenchCtx{WorkloadFile: writeWorkloadFile(t, testWorkload)}
+
+	workload, err := getWorkload(&ctx)
+	assert.Nil(err)
+
+	assert.Len(workload.Spaces, 1)
+	space := workload.Spaces[0]
+	assert.Equal([]int{0}, space.primaryKeyFieldNos)
+	assert.Equal(100, *space.Fill)
+
+	tuple := space.generateTuple()
+	assert.Len(tuple, 4)
+	assert.Equal(uint64(1), tuple[0])
+	assert.Len(tuple[1], 36)
+	assert.Len(tuple[2], 16)
+	assert.GreaterOrEqual(tuple[3], uint64(18))
+	assert.LessOrEqual(tuple[3], uint64(99))
+
+	key, err := space.getPrimaryKey(tuple)
+	assert.Nil(err)
+	assert.Equal([]interface{}{uint64(1)}, key)
+
+	assert.Len(workload.Operations, 6)
+	assert.Equal("age", workload.Operations[1].indexName)
+	// update fields are specified explicitly
+	assert.Equal([]int{3}, workload.Operations[2].updateFieldNos)
+	// upsert assigns all fields except the primary key ones
+	assert.Equal([]int{1, 2, 3}, workload.Operations[3].updateFieldNos)
+	assert.Equal("primary", workload.Operations[4].indexName)
+	assert.Len(workload.Operations[5].argsGenerators, 1)
+
+	assert.True(workload.hasInsertOperations(space))
+	assert.Equal(100, getFillCount(ctx, workload, space))
+}
+
+func TestGetDefaultWorkload(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	ctx := context.BenchCtx{
+		KeySize:         10,
+		DataSize:        20,
+		InsertCount:     50,
+		SelectCount:     30,
+		UpdateCount:     20,
+		PreFillingCount: PreFillingCount,
+	}
+
+	workload, err := getWorkload(&ctx)
+	assert.Nil(err)
+
+	assert.Len(workload.Spaces, 1)
+	space := workload.Spaces[0]
+	assert.Equal(benchSpaceName, space.Name)
+	assert.Equal(benchSpacePrimaryIndexName, space.Indexes[0].Name)
+
+	tuple := space.generateTuple()
+	assert.Len(tuple[0], 10)
+	assert.Len(tuple[1], 20)
+
+	// update assigns the value field
+	assert.Equal([]int{1}, workload.Operations[2].updateFieldNos)
+
+	assert.Equal(0, getFillCount(ctx, workload, space))
+
+	ctx.InsertCount = 0
+	ctx.SelectCount = 80
+	workload, err = getWorkload(&ctx)
+	assert.Nil(err)
+	assert.Equal(PreFillingCount, getFillCount(ctx, workload, workload.Spaces[0]))
+
+	ctx.SelectCount = 10
+	_, err = getWorkload(&ctx)
+	assert.EqualError(err, "The number of operations as a percentage should be equal to 100, "+
+		"note that by default the percentage of inserts is 100")
+}
+
+func TestGetWorkloadErrors(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	space := `
+spaces:
+  - name: s
+    format:
+      - {name: id, type: unsigned, gen: {type: sequence}}
+      - {name: value, type: string, gen: {type: string, size: 10}}
+`
+
+	testCases := []struct {
+		content string
+		err     string
+	}{
+		{
+			"",
+			"Invalid workload: Workload is empty",
+		},
+		{
+			"unknown: true",
+			"Failed to parse workload file: yaml: unmarshal errors:\n  line 1: field unknown not found in type bench.Workload",
+		},
+		{
+			space,
+			"Invalid workload: Total operations weight should be greater than zero",
+		},
+		{
+			space + "operations: [{type: truncate, space: s, weight: 1}]",
+			"Invalid workload: Invalid operation #1 (truncate): Unknown operation type. " +
+				"Supported types: insert, select, update, delete, replace, upsert, call",
+		},
+		{
+			space + "operations: [{type: insert, space: unknown, weight: 1}]",
+			`Invalid workload: Invalid operation #1 (insert): Space "unknown" isn't described in the workload`,
+		},
+		{
+			space + "operations: [{type: select, space: s, index: unknown, weight: 1}]",
+			`Invalid workload: Invalid operation #1 (select): Index "unknown" isn't found in space "s"`,
+		},
+		{
+			space + "operations: [{type: update, space: s, fields: [id], weight: 1}]",
+			`Invalid workload: Invalid operation #1 (update): Primary key field "id" can't be updated`,
+		},
+		{
+			space + "operations: [{type: call, weight: 1}]",
+			"Invalid workload: Invalid operation #1 (call): Function should be specified",
+		},
+		{
+			"spaces: [{name: s, format: [{name: id, type: unsigned, gen: {type: int, min: 2, max: 1}}]}]",
+			`Invalid workload: Invalid space "s": Field "id": Min should be less than or equal to max`,
+		},
+		{
+			"spaces: [{name: s, format: [{name: id, type: unsigned, gen: {type: random}}]}]",
+			`Invalid workload: Invalid space "s": Field "id": Unknown generator type "random". ` +
+				"Supported types: int, uuid, string, sequence",
+		},
+		{
+			"spaces: [{name: s, format: [{name: id, type: unsigned, gen: {type: sequence}}], " +
+				"indexes: [{name: pk, parts: [key]}]}]",
+			`Invalid workload: Invalid space "s": Index "pk": field "key" isn't found in space format`,
+		},
+	}
+
+	for _, tc := range testCases {
+		ctx := context.BenchCtx{WorkloadFile: writeWorkloadFile(t, tc.content)}
+
+		_, err := getWorkload(&ctx)
+		assert.EqualError(err, tc.err, tc.content)
+	}
+}
+
+func TestValueGenerators(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	gen, err := newValueGenerator(&GeneratorConf{Type: sequenceGenerator, Start: 10})
+	assert.Nil(err)
+	assert.Equal(uint64(10), gen.generate())
+	assert.Equal(uint64(11), gen.generate())
+	assert.Equal("sequence(10)", gen.String())
+
+	intConf := func(min, max int64) *GeneratorConf {
+		return &GeneratorConf{Type: intGenerator, Min: &min, Max: &max}
+	}
+
+	gen, err = newValueGenerator(intConf(5, 5))
+	assert.Nil(err)
+	assert.Equal(uint64(5), gen.generate())
+
+	gen, err = newValueGenerator(intConf(-5, -5))
+	assert.Nil(err)
+	assert.Equal(int64(-5), gen.generate())
+
+	// full and wide ranges don't overflow
+	for _, conf := range []*GeneratorConf{
+		intConf(math.MinInt64, math.MaxInt64),
+		intConf(-1, math.MaxInt64),
+		intConf(math.MinInt64, 1),
+	} {
+		gen, err = newValueGenerator(conf)
+		assert.Nil(err)
+		for i := 0; i < 100; i++ {
+			assert.NotPanics(func() { gen.generate() })
+		}
+	}
+
+	gen, err = newValueGenerator(intConf(math.MaxInt64-1, math.MaxInt64))
+	assert.Nil(err)
+	assert.GreaterOrEqual(gen.generate(), uint64(math.MaxInt64-1))
+
+	_, err = newValueGenerator(&GeneratorConf{Type: intGenerator})
+	assert.EqualError(err, "Min and max should be specified")
+
+	gen, err = newValueGenerator(&GeneratorConf{Type: uuidGenerator})
+	assert.Nil(err)
+	assert.Regexp(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, gen.generate())
+
+	_, err = newValueGenerator(&GeneratorConf{Type: stringGenerator})
+	assert.EqualError(err, "Size should be greater than zero")
+
+	_, err = newValueGenerator(&GeneratorConf{})
+	assert.EqualError(err, "Generator type should be specified")
+}
+
+func TestCanDropExistingSpace(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	ctx := context.BenchCtx{KeySize: 10, DataSize: 20, InsertCount: 100}
+	workload, err := getWorkload(&ctx)
+	assert.Nil(err)
+
+	// default space is dropped only if it has the benchmark primary index
+	defaultSpace := workload.Spaces[0]
+	assert.True(canDropExistingSpace(defaultSpace, benchSpacePrimaryIndexName))
+	assert.False(canDropExistingSpace(defaultSpace, "primary"))
+
+	ctx = context.BenchCtx{WorkloadFile: writeWorkloadFile(t, testWorkload)}
+	workload, err = getWorkload(&ctx)
+	assert.Nil(err)
+
+	// user space is dropped only if it's explicitly allowed
+	userSpace := workload.Spaces[0]
+	assert.False(canDropExistingSpace(userSpace, "primary"))
+
+	userSpace.DropExisting = true
+	assert.True(canDropExistingSpace(userSpace, "primary"))
+}
@@ -1,12 +1,14 @@
 package cluster
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/apex/log"
 	"github.com/tarantool/cartridge-cli/cli/common"
 	"github.com/tarantool/cartridge-cli/cli/connector"
-	"github.com/tarantool/cartridge-cli/cli/context"
+	cliContext "github.com/tarantool/cartridge-cli/cli/context"
+	"github.com/tarantool/cartridge-cli/cli/project"
 	"github.com/vmihailenco/msgpack/v5"
 )
 
@@ -39,7 +41,7 @@ func GetRandomInstanceName(instances map[string]string) string {
 // First, it connects all instances to membership (probes all running instances by one
 // of them). Then, it gets all membership instances members.
 // filters are an Options pattern to perform modifications of the instances container.
-func GetMembershipInstances(instancesConf *InstancesConf, ctx *context.Ctx,
+func GetMembershipInstances(instancesConf *InstancesConf, ctx *cliContext.Ctx,
 	filters ...InstancesFilter) (*MembershipInstances, error) {
 	runningInstances := getRunningInstances(instancesConf, ctx)
 	instanceName := GetRandomInstanceName(runningInstances)
@@ -47,18 +49,18 @@ func GetMembershipInstances(instancesConf *InstancesConf, ctx *context.Ctx,
 		return nil, fmt.Errorf("No running instances found")
 	}
 
-	conn, err := ConnectToInstance(instanceName, ctx)
-	if err != nil {
-		return nil, err
-	}
+	pool := connector.NewPool(connector.PoolOpts{})
+	defer pool.Close()
+
+	consoleSockPath := project.GetInstanceConsoleSock(ctx, instanceName)
 
 	log.Debugf("Connect all instances to membership")
 
-	if err := ConnectToMembership(conn, runningInstances, instancesConf); err != nil {
+	if err := ConnectToMembership(pool, consoleSockPath, runningInstances, instancesConf); err != nil {
 		return nil, fmt.Errorf("Failed to connect instances to membership: %s", err)
 	}
 
-	membershipInstances, err := GetMembershipInstancesFromConn(conn)
+	membershipInstances, err := getMembershipInstancesFromPool(pool, consoleSockPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get membership instances: %s", err)
 	}
@@ -70,10 +72,13 @@ func GetMembershipInstances(instancesConf *InstancesConf, ctx *context.Ctx,
 	return membershipInstances, nil
 }
 
-func ConnectToMembership(conn *connector.Conn, runningInstancesNames map[string]string,
-	instancesConf *InstancesConf) error {
+// ConnectToMembership probes all running instances by the instance
+// available by the specified console socket.
+// Instances are probed concurrently via several connections from the pool
+func ConnectToMembership(pool *connector.Pool, consoleSockPath string,
+	runningInstancesNames map[string]string, instancesConf *InstancesConf) error {
 	// Probe all running instances mentioned in topology.
-	var urisToProbe []string
+	futures := make(map[string]*connector.Future, len(runningInstancesNames))
 
 	for _, instanceName := range runningInstancesNames {
 		instanceConf, found := (*instancesConf)[instanceName]
@@ -81,21 +86,32 @@ func ConnectToMembership(conn *connector.Conn, runningInstancesNames map[string]
 			return fmt.Errorf("Instance %s isn't found in instances config", instanceName)
 		}
 
-		urisToProbe = append(urisToProbe, instanceConf.URI)
+		req := connector.EvalReq(probeInstancesBody, []string{instanceConf.URI})
+		futures[instanceConf.URI] = pool.ExecAsync(context.Background(), consoleSockPath, req)
 	}
 
-	if _, err := conn.Exec(connector.EvalReq(probeInstancesBody, urisToProbe)); err != nil {
-		return fmt.Errorf("Failed to probe all instances mentioned in replica sets: %s", err)
+	var probeErr error
+	for uri, future := range futures {
+		if _, err := future.Get(); err != nil && probeErr == nil {
+			probeErr = fmt.Errorf("Failed to probe %s: %s", uri, err)
+		}
+	}
+
+	if probeErr != nil {
+		return fmt.Errorf("Failed to probe all instances mentioned in replica sets: %s", probeErr)
 	}
 
 	return nil
 }
 
-func GetMembershipInstancesFromConn(conn *connector.Conn) (*MembershipInstances, error) {
+func getMembershipInstancesFromPool(pool *connector.Pool, consoleSockPath string) (*MembershipInstances, error) {
 	var membershipInstancesSlice []*MembershipInstance
 
-	req := connector.EvalReq(getMembershipInstancesBody).SetReadTimeout(SimpleOperationTimeout)
-	if err := conn.ExecTyped(req, &membershipInstancesSlice); err != nil {
+	ctx, cancel := context.WithTimeout(context.Background(), SimpleOperationTimeout)
+	defer cancel()
+
+	req := connector.EvalReq(getMembershipInstancesBody)
+	if err := pool.ExecTyped(ctx, consoleSockPath, req, &membershipInstancesSlice); err != nil {
 		return nil, fmt.Errorf("Failed to get membership members: %s", err)
 	}
 
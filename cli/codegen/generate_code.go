@@ -30,6 +30,8 @@ var luaCodeFiles = []generateLuaCodeOpts{
 		FileName:    "cli/connect/lua_code_gen.go",
 		VariablesMap: map[string]string{
 			"evalFuncBody":           "cli/connect/lua/eval_func_body.lua",
+			"evalRawFuncBody":        "cli/connect/lua/eval_raw_func_body.lua",
+			"executeSQLFuncBody":     "cli/connect/lua/execute_sql_func_body.lua",
 			"getSuggestionsFuncBody": "cli/connect/lua/get_suggestions_func_body.lua",
 			"getTitleFuncBody":       "cli/connect/lua/get_title_func_body.lua",
 		},
@@ -60,6 +62,7 @@ var luaCodeFiles = []generateLuaCodeOpts{
 			"formatTopologyReplicasetFuncTemplate": "cli/replicasets/lua/format_topology_replicaset_func_template.lua",
 			"getKnownRolesBody":                    "cli/replicasets/lua/get_known_roles_body.lua",
 			"getKnownVshardGroupsBody":             "cli/replicasets/lua/get_known_vshard_groups_body.lua",
+			"getInstancesUpstreamsBody":            "cli/replicasets/lua/get_instances_upstreams_body.lua",
 			"getTopologyReplicasetsBodyTemplate":   "cli/replicasets/lua/get_topology_replicasets_body_template.lua",
 		},
 	},
@@ -71,12 +74,22 @@ var luaCodeFiles = []generateLuaCodeOpts{
 			"probeInstancesBody":         "cli/cluster/lua/probe_instances_body.lua",
 		},
 	},
+	{
+		PackageName: "vshard",
+		FileName:    "cli/vshard/lua_code_gen.go",
+		VariablesMap: map[string]string{
+			"getVshardStatusBody": "cli/vshard/lua/get_vshard_status_body.lua",
+		},
+	},
 	{
 		PackageName: "failover",
 		FileName:    "cli/failover/lua_code_gen.go",
 		VariablesMap: map[string]string{
-			"manageFailoverBody":    "cli/failover/lua/manage_failover_body.lua",
-			"getFailoverParamsBody": "cli/failover/lua/get_failover_params_body.lua",
+			"manageFailoverBody":                "cli/failover/lua/manage_failover_body.lua",
+			"getFailoverParamsBody":             "cli/failover/lua/get_failover_params_body.lua",
+			"promoteLeaderBody":                 "cli/failover/lua/promote_leader_body.lua",
+			"getInstancesReplicationStatusBody": "cli/failover/lua/get_instances_replication_status_body.lua",
+			"getFailoverStateBody":              "cli/failover/lua/get_failover_state_body.lua",
 		},
 	},
 }
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"fmt"
 	"path/filepath"
 	"strings"
 
@@ -10,6 +11,10 @@ import (
 	"github.com/tarantool/cartridge-cli/cli/admin"
 )
 
+var (
+	adminCallTimeoutStr string
+)
+
 func init() {
 	var adminCmd = &cobra.Command{
 		Use:   "admin [ADMIN_FUNC_NAME]",
@@ -17,7 +22,9 @@ func init() {
 		Long: `Call admin function on application instance
 IF --conn flag is specified, CLI connects to instance by specified address.
 If --instance flag is specified, then <run-dir>/<app-name>.<instance>.control socket is used.
-Otherwise, first available socket from all <run-dir>/<app-name>.*.control is used.`,
+Otherwise, first available socket from all <run-dir>/<app-name>.*.control is used.
+If --all, --replicaset or --role flag is specified, the function is called
+on all matching running instances and the results are aggregated in one report.`,
 
 		Run: func(cmd *cobra.Command, args []string) {
 			err := runAdminCommand(cmd, args)
@@ -26,6 +33,7 @@ Otherwise, first available socket from all <run-dir>/<app-name>.*.control is use
 			}
 		},
 		DisableFlagParsing: true,
+		ValidArgsFunction:  ShellCompAdminFuncs,
 	}
 
 	rootCmd.AddCommand(adminCmd)
@@ -45,8 +53,20 @@ func addAdminFlags(flagSet *pflag.FlagSet) {
 
 	flagSet.StringVar(&ctx.Admin.InstanceName, "instance", "", "Instance to connect to")
 	flagSet.StringVarP(&ctx.Admin.ConnString, "conn", "c", "", "Address to connect to")
+	addSSLFlags(flagSet, &ctx.Admin.Transport, &ctx.Admin.SSL)
+	addProfileFlag(flagSet)
 
 	flagSet.StringVar(&ctx.Running.RunDir, "run-dir", "", prodRunDirUsage)
+	flagSet.StringVar(&adminCallTimeoutStr, "call-timeout", "", adminCallTimeoutUsage)
+
+	flagSet.StringVar(&ctx.Admin.ArgsJSON, "args-json", "", adminArgsJSONUsage)
+	flagSet.StringVar(&ctx.Admin.ArgsFile, "args-file", "", adminArgsFileUsage)
+	flagSet.StringVar(&ctx.Admin.Output, "output", admin.TextOutput, adminOutputUsage)
+
+	flagSet.BoolVar(&ctx.Admin.All, "all", false, adminAllUsage)
+	flagSet.StringVar(&ctx.Admin.ReplicasetName, "replicaset", "", adminReplicasetUsage)
+	flagSet.StringVar(&ctx.Admin.Role, "role", "", adminRoleUsage)
+	flagSet.IntVar(&ctx.Admin.Parallel, "parallel", defaultAdminParallel, adminParallelUsage)
 
 	flagSet.SortFlags = false
 }
@@ -76,6 +96,24 @@ func runAdminCommand(cmd *cobra.Command, args []string) error {
 	// log level is usually set in rootCmd.PersistentPreRun
 	setLogLevel()
 
+	if adminCallTimeoutStr != "" {
+		var err error
+		if ctx.Admin.CallTimeout, err = getDuration(adminCallTimeoutStr); err != nil {
+			return fmt.Errorf(`Invalid argument %q for "--%s" flag: %s`, adminCallTimeoutStr, "call-timeout", err)
+		}
+	}
+
+	if err := admin.CheckOutput(ctx.Admin.Output); err != nil {
+		return err
+	}
+
+	stopCommandContext := initCommandContext(ctx.Admin.CallTimeout)
+	defer stopCommandContext()
+
+	if err := applyAdminProfile(); err != nil {
+		return err
+	}
+
 	if ctx.Admin.List && !ctx.Admin.Help {
 		return admin.Run(admin.List, &ctx, "", nil, nil)
 	}
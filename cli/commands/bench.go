@@ -1,17 +1,35 @@
 package commands
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/apex/log"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/tarantool/cartridge-cli/cli/bench"
 )
 
+var (
+	// benchDefaultWorkloadFlags describe the default benchmark workload,
+	// they can't be used with --workload
+	benchDefaultWorkloadFlags = []string{"keysize", "datasize", "insert", "select", "update"}
+)
+
 func init() {
 	var benchCmd = &cobra.Command{
 		Use:   "bench",
 		Short: "Util for running benchmarks for Tarantool",
 		Long:  "Benchmark utility that simulates running commands done by N clients at the same time sending M simultaneous queries",
 		Run: func(cmd *cobra.Command, args []string) {
+			if err := checkBenchWorkloadFlags(cmd.Flags()); err != nil {
+				log.Fatalf(err.Error())
+			}
+
+			if err := applyBenchProfile(cmd.Flags()); err != nil {
+				log.Fatalf(err.Error())
+			}
+
 			if err := bench.Run(ctx.Bench); err != nil {
 				log.Fatalf(err.Error())
 			}
@@ -22,8 +40,10 @@ func init() {
 	configureFlags(benchCmd)
 
 	benchCmd.Flags().StringVar(&ctx.Bench.URL, "url", "127.0.0.1:3301", "Tarantool address")
-	benchCmd.Flags().StringVar(&ctx.Bench.User, "user", "guest", "Tarantool user for connection")
+	benchCmd.Flags().StringVar(&ctx.Bench.User, "user", defaultBenchUser, "Tarantool user for connection")
 	benchCmd.Flags().StringVar(&ctx.Bench.Password, "password", "", "Tarantool password for connection")
+	addSSLFlags(benchCmd.Flags(), &ctx.Bench.Transport, &ctx.Bench.SSL)
+	addProfileFlag(benchCmd.Flags())
 
 	benchCmd.Flags().IntVar(&ctx.Bench.Connections, "connections", 10, "Number of concurrent connections")
 	benchCmd.Flags().IntVar(&ctx.Bench.SimultaneousRequests, "requests", 10, "Number of simultaneous requests per connection")
@@ -36,4 +56,27 @@ func init() {
 	benchCmd.Flags().IntVar(&ctx.Bench.UpdateCount, "update", 0, "percentage of updates")
 	benchCmd.Flags().IntVar(&ctx.Bench.PreFillingCount, "fill", bench.PreFillingCount, "number of records to pre-fill the space")
 
+	benchCmd.Flags().StringVar(&ctx.Bench.WorkloadFile, "workload", "", benchWorkloadUsage)
+
+}
+
+// checkBenchWorkloadFlags checks that the default workload flags
+// aren't specified together with the workload file
+func checkBenchWorkloadFlags(flagSet *pflag.FlagSet) error {
+	if !flagSet.Changed("workload") {
+		return nil
+	}
+
+	var specifiedFlags []string
+	for _, flagName := range benchDefaultWorkloadFlags {
+		if flagSet.Changed(flagName) {
+			specifiedFlags = append(specifiedFlags, fmt.Sprintf("--%s", flagName))
+		}
+	}
+
+	if len(specifiedFlags) > 0 {
+		return fmt.Errorf("%s can't be used with --workload", strings.Join(specifiedFlags, ", "))
+	}
+
+	return nil
 }
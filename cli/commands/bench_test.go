@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckBenchWorkloadFlags(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	getFlagSet := func(args ...string) *pflag.FlagSet {
+		flagSet := pflag.NewFlagSet("bench", pflag.ContinueOnError)
+		flagSet.String("workload", "", "")
+		flagSet.Int("keysize", 10, "")
+		flagSet.Int("datasize", 20, "")
+		flagSet.Int("insert", 100, "")
+		flagSet.Int("select", 0, "")
+		flagSet.Int("update", 0, "")
+		flagSet.Int("fill", 1000000, "")
+
+		if err := flagSet.Parse(args); err != nil {
+			t.Fatal(err)
+		}
+
+		return flagSet
+	}
+
+	assert.Nil(checkBenchWorkloadFlags(getFlagSet()))
+	assert.Nil(checkBenchWorkloadFlags(getFlagSet("--insert", "50", "--select", "50")))
+	assert.Nil(checkBenchWorkloadFlags(getFlagSet("--workload", "workload.yml", "--fill", "10")))
+
+	assert.EqualError(
+		checkBenchWorkloadFlags(getFlagSet("--workload", "workload.yml", "--keysize", "5")),
+		"--keysize can't be used with --workload",
+	)
+
+	assert.EqualError(
+		checkBenchWorkloadFlags(getFlagSet("--insert", "50", "--workload", "workload.yml", "--update", "50")),
+		"--insert, --update can't be used with --workload",
+	)
+}
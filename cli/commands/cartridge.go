@@ -1,6 +1,8 @@
 package commands
 
 import (
+	gocontext "context"
+
 	"github.com/apex/log"
 	"github.com/apex/log/handlers/cli"
 	"github.com/spf13/cobra"
@@ -15,10 +17,6 @@ var (
 		Use:   "cartridge",
 		Short: "Tarantool Cartridge command-line interface",
 
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			setLogLevel()
-		},
-
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) == 0 && !needVersion {
 				cmd.Help()
@@ -30,6 +28,17 @@ var (
 )
 
 func init() {
+	ctx.Cli.Context = gocontext.Background()
+
+	// PersistentPreRun is set here to avoid rootCmd initialization cycle
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		setLogLevel()
+
+		if cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd {
+			prepareShellComp(cmd, args)
+		}
+	}
+
 	rootCmd.SetVersionTemplate("{{ .Version }}\n")
 
 	rootCmd.PersistentFlags().BoolVar(&ctx.Cli.Verbose, "verbose", false, "Verbose output")
@@ -34,6 +34,9 @@ func init() {
 	// stateboard flags
 	addStateboardRunningFlags(cleanCmd)
 
+	// etcd flags
+	addEtcdRunningFlags(cleanCmd)
+
 	// clean-specific paths
 	cleanCmd.Flags().StringVar(&ctx.Running.LogDir, "log-dir", "", logDirUsage)
 	cleanCmd.Flags().StringVar(&ctx.Running.DataDir, "data-dir", "", dataDirUsage)
@@ -43,6 +46,7 @@ func init() {
 
 func runCleanCmd(cmd *cobra.Command, args []string) error {
 	setStateboardFlagIsSet(cmd)
+	setEtcdFlagIsSet(cmd)
 
 	if err := running.FillCtx(&ctx, args); err != nil {
 		return err
@@ -1,12 +1,16 @@
 package commands
 
 import (
+	gocontext "context"
 	"fmt"
+	"os"
+	"os/signal"
 	"strconv"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"github.com/tarantool/cartridge-cli/cli/context"
 )
 
 func setDefaultValue(flags *pflag.FlagSet, name string, value string) error {
@@ -39,6 +43,31 @@ func getDuration(durationStr string) (time.Duration, error) {
 	return duration, nil
 }
 
+// initCommandContext sets the context that is done on SIGINT
+// or when the timeout is exceeded (if it's specified).
+// After the context is done, the next SIGINT terminates the process.
+// Returned function should be called when the command is finished
+func initCommandContext(timeout time.Duration) func() {
+	cmdContext, stopNotify := signal.NotifyContext(gocontext.Background(), os.Interrupt)
+
+	cancel := func() {}
+	if timeout > 0 {
+		cmdContext, cancel = gocontext.WithTimeout(cmdContext, timeout)
+	}
+
+	go func() {
+		<-cmdContext.Done()
+		stopNotify()
+	}()
+
+	ctx.Cli.Context = cmdContext
+
+	return func() {
+		cancel()
+		stopNotify()
+	}
+}
+
 func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().SortFlags = false
 }
@@ -52,6 +81,10 @@ func addStateboardRunningFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&ctx.Running.StateboardOnly, "stateboard-only", false, stateboardOnlyUsage)
 }
 
+func addEtcdRunningFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&ctx.Running.WithEtcd, "etcd", false, etcdUsage)
+}
+
 func addCommonRunningPathsFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&ctx.Running.RunDir, "run-dir", "", runDirUsage)
 	cmd.Flags().StringVar(&ctx.Running.ConfPath, "cfg", "", cfgUsage)
@@ -61,6 +94,7 @@ func addCommonRepairFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&ctx.Project.Name, "name", "", "Application name")
 	cmd.Flags().BoolVarP(&ctx.Repair.Force, "force", "f", false, repairForceUsage)
 	cmd.Flags().StringVar(&ctx.Running.DataDir, "data-dir", "", prodDataDirUsage)
+	cmd.Flags().StringVar(&ctx.Repair.Inventory, "inventory", "", repairInventoryUsage)
 }
 
 func addCommonRepairPatchFlags(cmd *cobra.Command) {
@@ -81,8 +115,22 @@ func addSpecFlag(cmd *cobra.Command) {
 
 func addReplicasetFlag(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&ctx.Replicasets.ReplicasetName, "replicaset", "", replicasetNameUsage)
+	registerFlagComp(cmd, "replicaset", shellCompReplicasetFlag)
+}
+
+func addSSLFlags(flagSet *pflag.FlagSet, transport *string, sslCtx *context.SSLCtx) {
+	flagSet.StringVar(transport, "transport", "", transportUsage)
+	flagSet.StringVar(&sslCtx.CaFile, "ssl-ca-file", "", sslCaFileUsage)
+	flagSet.StringVar(&sslCtx.CertFile, "ssl-cert-file", "", sslCertFileUsage)
+	flagSet.StringVar(&sslCtx.KeyFile, "ssl-key-file", "", sslKeyFileUsage)
+	flagSet.StringVar(&sslCtx.Ciphers, "ssl-ciphers", "", sslCiphersUsage)
+	flagSet.StringVar(&sslCtx.ServerName, "ssl-server-name", "", sslServerNameUsage)
 }
 
 func setStateboardFlagIsSet(cmd *cobra.Command) {
 	ctx.Running.StateboardFlagIsSet = cmd.Flags().Changed("stateboard")
 }
+
+func setEtcdFlagIsSet(cmd *cobra.Command) {
+	ctx.Running.EtcdFlagIsSet = cmd.Flags().Changed("etcd")
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	gocontext "context"
 	"strings"
 	"testing"
 	"time"
@@ -35,3 +36,25 @@ func TestGetDuration(t *testing.T) {
 	assert.NotNil(err)
 	assert.True(strings.Contains(err.Error(), `Negative duration is specified`), err.Error())
 }
+
+func TestInitCommandContext(t *testing.T) {
+	assert := assert.New(t)
+
+	// no timeout
+	stopCommandContext := initCommandContext(0)
+	assert.Nil(ctx.Cli.Context.Err())
+	_, hasDeadline := ctx.Cli.Context.Deadline()
+	assert.False(hasDeadline)
+
+	stopCommandContext()
+	assert.Equal(gocontext.Canceled, ctx.Cli.Context.Err())
+
+	// timeout
+	stopCommandContext = initCommandContext(50 * time.Millisecond)
+	defer stopCommandContext()
+
+	<-ctx.Cli.Context.Done()
+	assert.Equal(gocontext.DeadlineExceeded, ctx.Cli.Context.Err())
+
+	ctx.Cli.Context = gocontext.Background()
+}
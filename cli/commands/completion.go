@@ -2,6 +2,10 @@ package commands
 
 import (
 	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/tarantool/cartridge-cli/cli/admin"
 
 	"github.com/tarantool/cartridge-cli/cli/common"
 	"github.com/tarantool/cartridge-cli/cli/replicasets"
@@ -137,6 +141,198 @@ func ShellCompRolesToAdd(cmd *cobra.Command, args []string, toComplete string) (
 	return filteredRoles, cobra.ShellCompDirectiveNoFileComp
 }
 
+func ShellCompReplicasets(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return shellCompReplicasetFlag(cmd, args, toComplete)
+}
+
+func ShellCompReplicasetInstance(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		// first argument - replica set alias
+		return shellCompReplicasetFlag(cmd, args, toComplete)
+	}
+
+	if len(args) > 1 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// second argument - replica set instance
+	instanceAliases, err := replicasets.GetReplicasetInstancesComp(&ctx, args[0])
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return instanceAliases, cobra.ShellCompDirectiveNoFileComp
+}
+
+// FLAGS
+
+func shellCompReplicasetFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	replicasetAliases, err := replicasets.GetReplicasetsComp(&ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return replicasetAliases, cobra.ShellCompDirectiveNoFileComp
+}
+
+func shellCompRoleFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	roleNames, err := replicasets.GetKnownRolesComp(&ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return roleNames, cobra.ShellCompDirectiveNoFileComp
+}
+
+func shellCompVshardGroupFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	vshardGroups, err := replicasets.GetVshardGroupsComp(&ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return vshardGroups, cobra.ShellCompDirectiveNoFileComp
+}
+
+// registerFlagComp registers the completion function for the command flag
+func registerFlagComp(cmd *cobra.Command, flagName string,
+	compFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)) {
+
+	if err := cmd.RegisterFlagCompletionFunc(flagName, compFunc); err != nil {
+		panic(project.InternalError("Failed to register %q flag completion: %s", flagName, err))
+	}
+}
+
+// ADMIN
+
+func ShellCompAdminFuncs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	// flag values are completed here since cobra doesn't parse `cartridge admin` flags
+	if len(args) > 0 {
+		switch args[len(args)-1] {
+		case "--instance":
+			return ShellCompRunningInstances(cmd, nil, toComplete)
+		case "--replicaset":
+			return shellCompReplicasetFlag(cmd, nil, toComplete)
+		case "--role":
+			return shellCompRoleFlag(cmd, nil, toComplete)
+		}
+	}
+
+	// flags aren't parsed since DisableFlagParsing is set for `cartridge admin`
+	flagSet, err := parseAdminCompArgs(args)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	if len(flagSet.Args()) > 0 {
+		// function name is already specified, its arguments are
+		// completed as flags (see addAdminFuncArgsCompFlags)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	funcNames, err := admin.GetFuncNamesComp(&ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return funcNames, cobra.ShellCompDirectiveNoFileComp
+}
+
+// addAdminFuncArgsCompFlags adds flags for the specified admin function arguments
+// to make them completed as regular command flags
+func addAdminFuncArgsCompFlags(cmd *cobra.Command, args []string) {
+	flagSet, err := parseAdminCompArgs(args)
+	if err != nil || len(flagSet.Args()) == 0 {
+		return
+	}
+
+	funcName := strings.Join(flagSet.Args(), ".")
+
+	argsSpec, err := admin.GetFuncArgsComp(&ctx, funcName)
+	if err != nil {
+		return
+	}
+
+	for argName, argSpec := range argsSpec {
+		flagName := strings.ReplaceAll(argName, "_", "-")
+		if cmd.Flags().Lookup(flagName) != nil {
+			continue
+		}
+
+		if argSpec.Type == "boolean" {
+			cmd.Flags().Bool(flagName, false, argSpec.Usage)
+		} else {
+			cmd.Flags().String(flagName, "", argSpec.Usage)
+		}
+	}
+}
+
+// parseAdminCompArgs parses `cartridge admin` flags specified on completion.
+// If application name and run directory aren't specified,
+// the application in the current directory is used
+func parseAdminCompArgs(args []string) (*pflag.FlagSet, error) {
+	flagSet := pflag.NewFlagSet("admin", pflag.ContinueOnError)
+	addAdminFlags(flagSet)
+
+	flagSet.ParseErrorsWhitelist = pflag.ParseErrorsWhitelist{
+		UnknownFlags: true,
+	}
+
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if err := applyAdminProfile(); err != nil {
+		return nil, err
+	}
+
+	if ctx.Admin.ConnString != "" {
+		return flagSet, nil
+	}
+
+	if ctx.Project.Name == "" {
+		curDir, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+
+		if ctx.Project.Name, err = project.DetectName(curDir); err != nil {
+			return nil, err
+		}
+	}
+
+	if ctx.Running.RunDir == "" {
+		if err := project.SetLocalRunningPaths(&ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return flagSet, nil
+}
+
+// COMPLETION REQUEST
+
+// prepareShellComp is called before handling the completion request.
+// It's used to add dynamic flags to the completed command
+func prepareShellComp(completeCmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		return
+	}
+
+	// the last argument is the completed one
+	cmd, cmdArgs, err := completeCmd.Root().Find(args[:len(args)-1])
+	if err != nil {
+		return
+	}
+
+	if cmd.Name() == "admin" {
+		addAdminFuncArgsCompFlags(cmd, cmdArgs)
+	}
+}
+
 // COMMON
 
 func filterSpecifiedArgs(suggestedArgs, specifiedArgs []string) []string {
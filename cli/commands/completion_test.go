@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandsHaveShellComp(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	commandsWithArgsComp := [][]string{
+		{"start"},
+		{"stop"},
+		{"status"},
+		{"log"},
+		{"clean"},
+		{"enter"},
+		{"admin"},
+		{"replicasets", "join"},
+		{"replicasets", "expel"},
+		{"replicasets", "add-roles"},
+		{"replicasets", "remove-roles"},
+		{"replicasets", "set-failover-priority"},
+		{"replicasets", "decommission"},
+		{"failover", "promote"},
+		{"failover", "switchover"},
+	}
+
+	for _, cmdPath := range commandsWithArgsComp {
+		cmd, _, err := rootCmd.Find(cmdPath)
+		assert.Nil(err)
+		assert.NotNil(cmd.ValidArgsFunction, "%v", cmdPath)
+	}
+}
+
+func TestShellCompArgsCount(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	var cmd cobra.Command
+
+	// replica set is already specified
+	comps, directive := ShellCompReplicasets(&cmd, []string{"router"}, "")
+	assert.Nil(comps)
+	assert.Equal(cobra.ShellCompDirectiveNoFileComp, directive)
+
+	// replica set and instance are already specified
+	comps, directive = ShellCompReplicasetInstance(&cmd, []string{"router", "router-1"}, "")
+	assert.Nil(comps)
+	assert.Equal(cobra.ShellCompDirectiveNoFileComp, directive)
+}
@@ -8,15 +8,17 @@ import (
 
 func init() {
 	var enterCmd = &cobra.Command{
-		Use:   "enter INSTANCE_NAME",
+		Use:   "enter INSTANCE_NAME...",
 		Short: "Enter to application instance console",
+		Long: `Enter to application instance console.
+If --eval or --file flag is specified, the code is evaluated
+on each specified instance and the result is printed`,
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := connect.Enter(&ctx, args); err != nil {
 				log.Fatalf(err.Error())
 			}
 		},
 		ValidArgsFunction: ShellCompRunningInstances,
-		Args:              cobra.MaximumNArgs(1),
 	}
 
 	rootCmd.AddCommand(enterCmd)
@@ -28,16 +30,27 @@ func init() {
 	addNameFlag(enterCmd)
 	// run-dir flag
 	enterCmd.Flags().StringVar(&ctx.Running.RunDir, "run-dir", "", runDirUsage)
+	// language flag
+	enterCmd.Flags().StringVar(&ctx.Connect.Language, "language", "", connectLanguageUsage)
+	addConsoleEvalFlags(enterCmd)
 
 	var connectCmd = &cobra.Command{
-		Use:   "connect URI",
+		Use:   "connect [URI...]",
 		Short: "Connect to specified URI",
+		Long: `Connect to specified URI.
+If --eval or --file flag is specified, the code is evaluated
+on each specified URI and the result is printed.
+If --profile flag is specified, hosts names from the profile can be used as URIs`,
 		Run: func(cmd *cobra.Command, args []string) {
+			args, err := applyConnectProfile(args)
+			if err != nil {
+				log.Fatalf(err.Error())
+			}
+
 			if err := connect.Connect(&ctx, args); err != nil {
 				log.Fatalf(err.Error())
 			}
 		},
-		Args: cobra.MaximumNArgs(1),
 	}
 
 	rootCmd.AddCommand(connectCmd)
@@ -49,4 +62,17 @@ func init() {
 	connectCmd.Flags().StringVarP(&ctx.Connect.Username, "username", "u", "", connectUsernameUsage)
 	// password flag
 	connectCmd.Flags().StringVarP(&ctx.Connect.Password, "password", "p", "", connectPasswordUsage)
+	// language flag
+	connectCmd.Flags().StringVar(&ctx.Connect.Language, "language", "", connectLanguageUsage)
+	addConsoleEvalFlags(connectCmd)
+	// SSL flags
+	addSSLFlags(connectCmd.Flags(), &ctx.Connect.Transport, &ctx.Connect.SSL)
+	// profile flag
+	addProfileFlag(connectCmd.Flags())
+}
+
+func addConsoleEvalFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&ctx.Connect.Output, "output", "", connectOutputUsage)
+	cmd.Flags().StringVarP(&ctx.Connect.Eval, "eval", "e", "", connectEvalUsage)
+	cmd.Flags().StringVarP(&ctx.Connect.EvalFile, "file", "f", "", connectEvalFileUsage)
 }
@@ -6,6 +6,17 @@ import "time"
 const (
 	defaultStartTimeout = 1 * time.Minute
 	defaultLogLines     = 15
+
+	defaultWaitBalancedTimeout = 5 * time.Minute
+	defaultDecommissionTimeout = 10 * time.Minute
+	defaultClusterEvalTimeout  = 10 * time.Second
+	defaultSwitchoverTimeout   = 30 * time.Second
+
+	defaultFailoverWatchInterval = 2 * time.Second
+
+	defaultAdminParallel = 10
+
+	defaultBenchUser = "guest"
 )
 
 // ENV
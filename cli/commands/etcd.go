@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"github.com/apex/log"
+	"github.com/spf13/cobra"
+
+	"github.com/tarantool/cartridge-cli/cli/etcd"
+)
+
+func init() {
+	// etcd-standin command is used by `cartridge start --etcd`
+	// to run the built-in etcd v2 stand-in in a separate process
+	var etcdStandInCmd = &cobra.Command{
+		Use:    "etcd-standin",
+		Short:  "Run built-in etcd v2 stand-in",
+		Hidden: true,
+		Args:   cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := etcd.RunStandIn(&ctx); err != nil {
+				log.Fatalf(err.Error())
+			}
+		},
+	}
+
+	rootCmd.AddCommand(etcdStandInCmd)
+
+	etcdStandInCmd.Flags().StringVar(&ctx.Etcd.Name, "name", "default", etcdStandInNameUsage)
+	etcdStandInCmd.Flags().StringVar(&ctx.Etcd.Listen, "listen", "localhost:2379", etcdStandInListenUsage)
+	etcdStandInCmd.Flags().StringVar(&ctx.Etcd.DataDir, "data-dir", "default.etcd", etcdStandInDataDirUsage)
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/apex/log"
+	"github.com/spf13/cobra"
+	"github.com/tarantool/cartridge-cli/cli/connect"
+	"github.com/tarantool/cartridge-cli/cli/project"
+)
+
+var (
+	clusterEvalTimeoutStr string
+)
+
+func init() {
+	var evalCmd = &cobra.Command{
+		Use:   "eval [EXPRESSION]",
+		Short: "Evaluate code on application instances",
+		Long: `Evaluate code on application instances.
+The code is evaluated on all instances described in the instances configuration file.
+Use --replicaset, --role and --leaders-only flags to select instances by current topology`,
+
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runEvalCmd(cmd, args); err != nil {
+				log.Fatalf(err.Error())
+			}
+		},
+	}
+
+	rootCmd.AddCommand(evalCmd)
+
+	// FLAGS
+	configureFlags(evalCmd)
+	addCommonReplicasetsFlags(evalCmd)
+
+	addReplicasetFlag(evalCmd)
+	evalCmd.Flags().StringVar(&ctx.Eval.Role, "role", "", clusterEvalRoleUsage)
+	registerFlagComp(evalCmd, "role", shellCompRoleFlag)
+	evalCmd.Flags().BoolVar(&ctx.Eval.LeadersOnly, "leaders-only", false, clusterEvalLeadersOnlyUsage)
+
+	evalCmd.Flags().StringVarP(&ctx.Connect.EvalFile, "file", "f", "", connectEvalFileUsage)
+	evalCmd.Flags().StringVar(&ctx.Connect.Output, "output", "", clusterEvalOutputUsage)
+	evalCmd.Flags().StringVar(&clusterEvalTimeoutStr, "timeout", "", clusterEvalTimeoutUsage)
+}
+
+func runEvalCmd(cmd *cobra.Command, args []string) error {
+	var err error
+
+	if err := setDefaultValue(cmd.Flags(), "timeout", defaultClusterEvalTimeout.String()); err != nil {
+		return project.InternalError("Failed to set default timeout value: %s", err)
+	}
+
+	if ctx.Eval.Timeout, err = getDuration(clusterEvalTimeoutStr); err != nil {
+		cmd.Usage()
+		return fmt.Errorf(`Invalid argument %q for "--%s" flag: %s`, clusterEvalTimeoutStr, "timeout", err)
+	}
+
+	return connect.EvalCluster(&ctx, args)
+}
@@ -1,15 +1,20 @@
 package commands
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/apex/log"
 	"github.com/spf13/cobra"
 	"github.com/tarantool/cartridge-cli/cli/failover"
+	"github.com/tarantool/cartridge-cli/cli/project"
 )
 
 var (
 	failoverModes = []string{"stateful", "eventual", "disabled", "raft"}
+
+	switchoverTimeoutStr     string
+	failoverWatchIntervalStr string
 )
 
 func init() {
@@ -76,20 +81,65 @@ func init() {
 	var statusCmd = &cobra.Command{
 		Use:   "status",
 		Short: "Get the current failover status",
+		Long: `Get the current failover parameters and the live failover state:
+active leaders, coordinator, state provider connectivity,
+suspected instances and failover suppression`,
 
 		Args: cobra.ExactValidArgs(0),
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := failover.Status(&ctx); err != nil {
+			if err := runFailoverStatusCmd(cmd); err != nil {
+				log.Fatalf(err.Error())
+			}
+		},
+	}
+
+	statusCmd.Flags().BoolVarP(&ctx.Failover.Watch, "watch", "w", false, failoverWatchUsage)
+	statusCmd.Flags().StringVar(&failoverWatchIntervalStr, "interval", "", failoverWatchIntervalUsage)
+
+	var promoteCmd = &cobra.Command{
+		Use:   "promote REPLICASET INSTANCE",
+		Short: "Promote the instance to be a replica set leader",
+		Long: `Promote the instance to be a replica set leader
+Works only for stateful failover`,
+
+		Args: cobra.ExactValidArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := failover.Promote(&ctx, args); err != nil {
+				log.Fatalf(err.Error())
+			}
+		},
+
+		ValidArgsFunction: ShellCompReplicasetInstance,
+	}
+
+	promoteCmd.Flags().BoolVar(&ctx.Failover.ForceInconsistency, "force-inconsistency", false, forceInconsistencyUsage)
+
+	var switchoverCmd = &cobra.Command{
+		Use:   "switchover REPLICASET",
+		Short: "Switch the replica set leader to the most up-to-date replica",
+		Long: `Promote the replica with the most recent vclock to be a replica set leader
+and wait until the new leader becomes writable
+Works only for stateful failover`,
+
+		Args: cobra.ExactValidArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runSwitchoverCmd(cmd, args); err != nil {
 				log.Fatalf(err.Error())
 			}
 		},
+
+		ValidArgsFunction: ShellCompReplicasets,
 	}
 
+	switchoverCmd.Flags().StringVar(&switchoverTimeoutStr, "timeout", "", switchoverTimeoutUsage)
+
 	failoverSubCommands := []*cobra.Command{
 		setupCmd,
 		disableCmd,
 		setCmd,
 		statusCmd,
+		promoteCmd,
+		switchoverCmd,
 	}
 
 	for _, cmd := range failoverSubCommands {
@@ -98,3 +148,42 @@ func init() {
 		addNameFlag(cmd)
 	}
 }
+
+func runFailoverStatusCmd(cmd *cobra.Command) error {
+	var err error
+
+	if err := setDefaultValue(cmd.Flags(), "interval", defaultFailoverWatchInterval.String()); err != nil {
+		return project.InternalError("Failed to set default interval value: %s", err)
+	}
+
+	if ctx.Failover.WatchInterval, err = getDuration(failoverWatchIntervalStr); err != nil {
+		cmd.Usage()
+		return fmt.Errorf(`Invalid argument %q for "--%s" flag: %s`, failoverWatchIntervalStr, "interval", err)
+	}
+
+	if ctx.Failover.WatchInterval == 0 {
+		return fmt.Errorf(`"--interval" flag value should be positive`)
+	}
+
+	if ctx.Failover.Watch {
+		stopCommandContext := initCommandContext(0)
+		defer stopCommandContext()
+	}
+
+	return failover.Status(&ctx)
+}
+
+func runSwitchoverCmd(cmd *cobra.Command, args []string) error {
+	var err error
+
+	if err := setDefaultValue(cmd.Flags(), "timeout", defaultSwitchoverTimeout.String()); err != nil {
+		return project.InternalError("Failed to set default timeout value: %s", err)
+	}
+
+	if ctx.Failover.SwitchoverTimeout, err = getDuration(switchoverTimeoutStr); err != nil {
+		cmd.Usage()
+		return fmt.Errorf(`Invalid argument %q for "--%s" flag: %s`, switchoverTimeoutStr, "timeout", err)
+	}
+
+	return failover.Switchover(&ctx, args)
+}
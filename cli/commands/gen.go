@@ -1,7 +1,9 @@
 package commands
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -10,28 +12,54 @@ import (
 	"github.com/apex/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
-	"github.com/tarantool/cartridge-cli/cli/common"
+	"github.com/tarantool/cartridge-cli/cli/templates"
 )
 
 var (
 	completionsDirName = "completion"
 
-	bashCompFilePath string
-	zshCompFilePath  string
+	bashCompFilePath       string
+	zshCompFilePath        string
+	fishCompFilePath       string
+	powerShellCompFilePath string
 
-	defaultBashCompFilePath string
-	defaultZshCompFilePath  string
+	defaultBashCompFilePath       string
+	defaultZshCompFilePath        string
+	defaultFishCompFilePath       string
+	defaultPowerShellCompFilePath string
 
-	skipBash bool
-	skipZsh  bool
+	skipBash       bool
+	skipZsh        bool
+	skipFish       bool
+	skipPowerShell bool
+
+	compStdoutShell string
+)
+
+const (
+	bashShell       = "bash"
+	zshShell        = "zsh"
+	fishShell       = "fish"
+	powerShellShell = "powershell"
 )
 
+// completionShell describes the shell completion script to generate
+type completionShell struct {
+	Name     string
+	FilePath string
+	Skip     bool
+	Gen      func(rootCmd *cobra.Command, w io.Writer) error
+}
+
 /*
  * `cartridge gen` command is used to generate shell
- * autocompletions for Bash and Zsh.
+ * autocompletions for Bash, Zsh, Fish and PowerShell.
  *
  * Autocompletion is generated by cobra, see
  * https://github.com/spf13/cobra/blob/master/shell_completions.md.
+ * Fish and PowerShell scripts request completions from
+ * `cartridge __complete` command, so dynamic completions
+ * (instances, replica sets, admin functions) work for them too.
  *
  * Bash completion is delivered with the RPM and DEB packages
  * (see .goreleaser.yml).
@@ -43,8 +71,12 @@ var (
  */
 
 func init() {
-	defaultBashCompFilePath = filepath.Join(completionsDirName, "bash", rootCmd.Name())
-	defaultZshCompFilePath = filepath.Join(completionsDirName, "zsh", fmt.Sprintf("_%s", rootCmd.Name()))
+	defaultBashCompFilePath = filepath.Join(completionsDirName, bashShell, rootCmd.Name())
+	defaultZshCompFilePath = filepath.Join(completionsDirName, zshShell, fmt.Sprintf("_%s", rootCmd.Name()))
+	defaultFishCompFilePath = filepath.Join(completionsDirName, fishShell, fmt.Sprintf("%s.fish", rootCmd.Name()))
+	defaultPowerShellCompFilePath = filepath.Join(
+		completionsDirName, powerShellShell, fmt.Sprintf("%s.ps1", rootCmd.Name()),
+	)
 
 	var genCmd = &cobra.Command{
 		Use:   "gen",
@@ -60,7 +92,9 @@ func init() {
 	var genCompletionCmd = &cobra.Command{
 		Use:   "completion",
 		Short: "Generate shell autocompletion scripts",
-		Args:  cobra.MaximumNArgs(0),
+		Long: `Generate shell autocompletion scripts for Bash, Zsh, Fish and PowerShell.
+Use --stdout flag to print the script for the specified shell`,
+		Args: cobra.MaximumNArgs(0),
 		PreRun: func(cmd *cobra.Command, args []string) {
 			cutFlagsDescription(rootCmd)
 		},
@@ -74,9 +108,17 @@ func init() {
 
 	genCompletionCmd.Flags().StringVar(&bashCompFilePath, "bash", defaultBashCompFilePath, "Bash completion file path")
 	genCompletionCmd.Flags().StringVar(&zshCompFilePath, "zsh", defaultZshCompFilePath, "Zsh completion file path")
+	genCompletionCmd.Flags().StringVar(&fishCompFilePath, "fish", defaultFishCompFilePath, "Fish completion file path")
+	genCompletionCmd.Flags().StringVar(
+		&powerShellCompFilePath, "powershell", defaultPowerShellCompFilePath, "PowerShell completion file path",
+	)
 
 	genCompletionCmd.Flags().BoolVar(&skipBash, "skip-bash", false, "Do not generate bash completion")
 	genCompletionCmd.Flags().BoolVar(&skipZsh, "skip-zsh", false, "Do not generate zsh completion")
+	genCompletionCmd.Flags().BoolVar(&skipFish, "skip-fish", false, "Do not generate fish completion")
+	genCompletionCmd.Flags().BoolVar(&skipPowerShell, "skip-powershell", false, "Do not generate PowerShell completion")
+
+	genCompletionCmd.Flags().StringVar(&compStdoutShell, "stdout", "", genCompletionStdoutUsage)
 
 	genSubCommands := []*cobra.Command{
 		genCompletionCmd,
@@ -101,52 +143,187 @@ func cutFlagsDescription(cmd *cobra.Command) {
 	}
 }
 
+func getCompletionShells() []completionShell {
+	return []completionShell{
+		{Name: bashShell, FilePath: bashCompFilePath, Skip: skipBash, Gen: genBashCompletion},
+		{Name: zshShell, FilePath: zshCompFilePath, Skip: skipZsh, Gen: genZshCompletion},
+		{Name: fishShell, FilePath: fishCompFilePath, Skip: skipFish, Gen: genFishCompletion},
+		{Name: powerShellShell, FilePath: powerShellCompFilePath, Skip: skipPowerShell, Gen: genPowerShellCompletion},
+	}
+}
+
 func genCompletion(cmd *cobra.Command, args []string) error {
+	completionShells := getCompletionShells()
+
+	if compStdoutShell != "" {
+		for _, shell := range completionShells {
+			if shell.Name == compStdoutShell {
+				if err := shell.Gen(cmd.Root(), os.Stdout); err != nil {
+					return fmt.Errorf("Failed to generate %s completion: %s", shell.Name, err)
+				}
+
+				return nil
+			}
+		}
+
+		return fmt.Errorf(
+			"Unknown shell %q. Supported shells: %s, %s, %s, %s",
+			compStdoutShell, bashShell, zshShell, fishShell, powerShellShell,
+		)
+	}
+
 	curDir, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("Cailed to get current directory path: %s", err)
 	}
 
-	bashCompFilePath := filepath.Join(curDir, bashCompFilePath)
-	zshCompFilePath := filepath.Join(curDir, zshCompFilePath)
+	for _, shell := range completionShells {
+		if shell.Skip {
+			continue
+		}
 
-	// create directories
-	bashCompFileDir := filepath.Dir(bashCompFilePath)
-	if err := os.MkdirAll(bashCompFileDir, 0755); err != nil {
-		return fmt.Errorf("Failed to create bash completion directory: %s", err)
-	}
+		compFilePath := filepath.Join(curDir, shell.FilePath)
 
-	zshCompFileDir := filepath.Dir(zshCompFilePath)
-	if err := os.MkdirAll(zshCompFileDir, 0755); err != nil {
-		return fmt.Errorf("Failed to create zsh completion directory: %s", err)
+		if err := genCompletionFile(cmd.Root(), shell, compFilePath); err != nil {
+			return err
+		}
 	}
 
-	// gen completions
-	if !skipBash {
-		if err := os.RemoveAll(bashCompFilePath); err != nil {
-			return fmt.Errorf("Failed to remove existent bash completion: %s", err)
-		}
+	return nil
+}
 
-		if err := cmd.Root().GenBashCompletionFile(bashCompFilePath); err != nil {
-			return fmt.Errorf("Failed to generate bash completion: %s", err)
-		}
+func genCompletionFile(rootCmd *cobra.Command, shell completionShell, compFilePath string) error {
+	// create directory
+	compFileDir := filepath.Dir(compFilePath)
+	if err := os.MkdirAll(compFileDir, 0755); err != nil {
+		return fmt.Errorf("Failed to create %s completion directory: %s", shell.Name, err)
+	}
 
-		// bash: remove flags duplicates (e.g. '--name', '--name=')
-		twoWordsFlagRgx := regexp.MustCompile(`(two_word_flags\+=\("--[\w-]+"\))`)
-		if err := common.ReplaceFileLinesByRe(bashCompFilePath, twoWordsFlagRgx, "# $1"); err != nil {
-			return fmt.Errorf("Failed to comment two words flags in the bash completion: %s", err)
-		}
+	if err := os.RemoveAll(compFilePath); err != nil {
+		return fmt.Errorf("Failed to remove existent %s completion: %s", shell.Name, err)
 	}
 
-	if !skipZsh {
-		if err := os.RemoveAll(zshCompFilePath); err != nil {
-			return fmt.Errorf("Failed to remove existent zsh completion: %s", err)
-		}
+	compFile, err := os.Create(compFilePath)
+	if err != nil {
+		return fmt.Errorf("Failed to create %s completion file: %s", shell.Name, err)
+	}
+	defer compFile.Close()
 
-		if err := cmd.Root().GenZshCompletionFile(zshCompFilePath); err != nil {
-			return fmt.Errorf("Failed to generate zsh completion: %s", err)
-		}
+	if err := shell.Gen(rootCmd, compFile); err != nil {
+		return fmt.Errorf("Failed to generate %s completion: %s", shell.Name, err)
 	}
 
 	return nil
 }
+
+func genBashCompletion(rootCmd *cobra.Command, w io.Writer) error {
+	var buf bytes.Buffer
+	if err := rootCmd.GenBashCompletion(&buf); err != nil {
+		return err
+	}
+
+	// bash: remove flags duplicates (e.g. '--name', '--name=')
+	twoWordsFlagRgx := regexp.MustCompile(`(two_word_flags\+=\("--[\w-]+"\))`)
+	bashCompletion := twoWordsFlagRgx.ReplaceAll(buf.Bytes(), []byte("# $1"))
+
+	_, err := w.Write(bashCompletion)
+	return err
+}
+
+func genZshCompletion(rootCmd *cobra.Command, w io.Writer) error {
+	return rootCmd.GenZshCompletion(w)
+}
+
+func genFishCompletion(rootCmd *cobra.Command, w io.Writer) error {
+	return rootCmd.GenFishCompletion(w, true)
+}
+
+// genPowerShellCompletion generates PowerShell completion script.
+// Cobra PowerShell completion doesn't support custom completions,
+// so the script that requests `cartridge __complete` is used
+func genPowerShellCompletion(rootCmd *cobra.Command, w io.Writer) error {
+	powerShellCompletion, err := templates.GetTemplatedStr(&powerShellCompletionTmpl, map[string]string{
+		"Name":                rootCmd.Name(),
+		"CompRequestCmd":      cobra.ShellCompRequestCmd,
+		"DirectiveError":      fmt.Sprintf("%d", cobra.ShellCompDirectiveError),
+		"DirectiveNoSpace":    fmt.Sprintf("%d", cobra.ShellCompDirectiveNoSpace),
+		"DirectiveNoFileComp": fmt.Sprintf("%d", cobra.ShellCompDirectiveNoFileComp),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, powerShellCompletion)
+	return err
+}
+
+var (
+	powerShellCompletionTmpl = `# PowerShell completion for {{ .Name }}
+
+Register-ArgumentCompleter -Native -CommandName '{{ .Name }}' -ScriptBlock {
+    param($WordToComplete, $CommandAst, $CursorPosition)
+
+    # arguments are taken from the parsed command elements,
+    # so the command line isn't evaluated on completion
+    $GetElementValue = {
+        param($Element)
+        if ($Element -is [System.Management.Automation.Language.StringConstantExpressionAst]) {
+            $Element.Value
+        } else {
+            $Element.Extent.Text
+        }
+    }
+
+    $Program = & $GetElementValue $CommandAst.CommandElements[0]
+    $Arguments = @($CommandAst.CommandElements | Select-Object -Skip 1 | Where-Object {
+        $_.Extent.EndOffset -lt $CursorPosition
+    } | ForEach-Object { & $GetElementValue $_ })
+
+    # the last argument is the word to complete, it's empty if the previous one is completed
+    if ($WordToComplete -ne "") {
+        $Arguments += $WordToComplete
+    } elseif ($PSVersionTable.PSVersion -lt [version]'7.3' -or $PSNativeCommandArgumentPassing -eq 'Legacy') {
+        # empty arguments aren't passed to native commands by legacy argument passing
+        $Arguments += '""'
+    } else {
+        $Arguments += ''
+    }
+
+    $Out = @(& $Program {{ .CompRequestCmd }} @Arguments 2>$null)
+    if ($Out.Count -eq 0) {
+        return
+    }
+
+    # the last line is the completion directive
+    $Directive = [int]($Out[-1].TrimStart(':'))
+    $Values = @($Out | Select-Object -SkipLast 1)
+
+    if (($Directive -band {{ .DirectiveError }}) -ne 0) {
+        return
+    }
+
+    $Suffix = " "
+    if (($Directive -band {{ .DirectiveNoSpace }}) -ne 0) {
+        $Suffix = ""
+    }
+
+    $Completions = @($Values | Where-Object { $_ -like "$WordToComplete*" } | ForEach-Object {
+        $Value, $Description = $_.Split("` + "`" + `t", 2)
+        if (-not $Description) {
+            $Description = $Value
+        }
+
+        [System.Management.Automation.CompletionResult]::new(
+            "$Value$Suffix", $Value, 'ParameterValue', $Description
+        )
+    })
+
+    # prevent files completion
+    if ($Completions.Count -eq 0 -and ($Directive -band {{ .DirectiveNoFileComp }}) -ne 0) {
+        return ""
+    }
+
+    $Completions
+}
+`
+)
@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenCompletionScripts(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	for _, shell := range getCompletionShells() {
+		var buf bytes.Buffer
+		assert.Nil(shell.Gen(rootCmd, &buf), shell.Name)
+		assert.NotEmpty(buf.String(), shell.Name)
+
+		switch shell.Name {
+		case bashShell:
+			// flags duplicates are commented
+			assert.NotRegexp(`(?m)^\s*two_word_flags\+=\("--`, buf.String())
+		case fishShell, powerShellShell:
+			// dynamic completion is requested from the binary
+			assert.Contains(buf.String(), "__complete", shell.Name)
+		}
+
+		if shell.Name == powerShellShell {
+			// command line isn't evaluated on completion
+			assert.NotContains(buf.String(), "Invoke-Expression")
+			assert.Contains(buf.String(), "& $Program __complete @Arguments")
+		}
+	}
+}
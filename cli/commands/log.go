@@ -40,6 +40,9 @@ func init() {
 	// stateboard flags
 	addStateboardRunningFlags(logCmd)
 
+	// etcd flags
+	addEtcdRunningFlags(logCmd)
+
 	// log-specific paths
 	logCmd.Flags().StringVar(&ctx.Running.LogDir, "log-dir", "", logDirUsage)
 	// common running paths
@@ -48,6 +51,7 @@ func init() {
 
 func runLogCmd(cmd *cobra.Command, args []string) error {
 	setStateboardFlagIsSet(cmd)
+	setEtcdFlagIsSet(cmd)
 
 	if err := setDefaultValue(cmd.Flags(), "lines", strconv.Itoa(defaultLogLines)); err != nil {
 		return project.InternalError("Failed to set default lines value: %s", err)
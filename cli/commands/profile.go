@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"github.com/spf13/pflag"
+	"github.com/tarantool/cartridge-cli/cli/admin"
+	"github.com/tarantool/cartridge-cli/cli/context"
+	"github.com/tarantool/cartridge-cli/cli/profile"
+)
+
+var (
+	profileName string
+)
+
+func addProfileFlag(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&profileName, "profile", "", profileUsage)
+}
+
+// applyConnectProfile fills `cartridge connect` connection options from the profile
+// and replaces hosts names in args with their URIs.
+// If no URI is specified, the default instance URI is used
+func applyConnectProfile(args []string) ([]string, error) {
+	if profileName == "" {
+		return args, nil
+	}
+
+	connProfile, err := profile.GetProfile(profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = fillConnOptsFromProfile(connProfile,
+		&ctx.Connect.Username, &ctx.Connect.Password, &ctx.Connect.Transport, &ctx.Connect.SSL)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) == 0 {
+		return []string{connProfile.GetURI("")}, nil
+	}
+
+	uris := make([]string, len(args))
+	for i, arg := range args {
+		uris[i] = connProfile.GetURI(arg)
+	}
+
+	return uris, nil
+}
+
+// applyAdminProfile fills `cartridge admin` connection options from the profile.
+// If neither --conn nor --instance is specified, the default instance URI is used.
+// The URI isn't set if the function is called on several instances,
+// since local instances sockets are used in this case
+func applyAdminProfile() error {
+	if profileName == "" {
+		return nil
+	}
+
+	connProfile, err := profile.GetProfile(profileName)
+	if err != nil {
+		return err
+	}
+
+	err = fillConnOptsFromProfile(connProfile,
+		&ctx.Admin.Username, &ctx.Admin.Password, &ctx.Admin.Transport, &ctx.Admin.SSL)
+	if err != nil {
+		return err
+	}
+
+	if admin.IsFanOut(&ctx) {
+		return nil
+	}
+
+	if ctx.Admin.ConnString != "" || ctx.Admin.InstanceName == "" {
+		ctx.Admin.ConnString = connProfile.GetURI(ctx.Admin.ConnString)
+	}
+
+	return nil
+}
+
+// applyBenchProfile fills `cartridge bench` connection options from the profile.
+// Values of the explicitly specified flags aren't overridden
+func applyBenchProfile(flagSet *pflag.FlagSet) error {
+	if profileName == "" {
+		return nil
+	}
+
+	connProfile, err := profile.GetProfile(profileName)
+	if err != nil {
+		return err
+	}
+
+	if !flagSet.Changed("user") {
+		ctx.Bench.User = ""
+	}
+
+	err = fillConnOptsFromProfile(connProfile,
+		&ctx.Bench.User, &ctx.Bench.Password, &ctx.Bench.Transport, &ctx.Bench.SSL)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bench.User == "" {
+		ctx.Bench.User = defaultBenchUser
+	}
+
+	if flagSet.Changed("url") {
+		ctx.Bench.URL = connProfile.GetURI(ctx.Bench.URL)
+	} else {
+		ctx.Bench.URL = connProfile.GetURI("")
+	}
+
+	return nil
+}
+
+// fillConnOptsFromProfile sets options that aren't specified yet
+func fillConnOptsFromProfile(connProfile *profile.Profile,
+	username, password, transport *string, sslCtx *context.SSLCtx) error {
+
+	if *username == "" {
+		*username = connProfile.User
+	}
+
+	if *password == "" {
+		var err error
+		if *password, err = connProfile.GetPassword(); err != nil {
+			return err
+		}
+	}
+
+	if *transport == "" {
+		*transport = connProfile.Transport
+	}
+
+	if *sslCtx == (context.SSLCtx{}) {
+		var err error
+		if *sslCtx, err = connProfile.GetSSLCtx(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
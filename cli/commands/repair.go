@@ -33,6 +33,8 @@ func init() {
 		},
 	}
 
+	repairListCmd.Flags().StringVar(&ctx.Repair.ListFormat, "format", "", topologyFormatUsage)
+
 	// change advertise URI
 	var repairURICmd = &cobra.Command{
 		Use:   "set-advertise-uri INSTANCE-UUID NEW-URI",
@@ -92,11 +94,29 @@ All configuration files across directories <data-dir>/<app-name>.* are patched.`
 	}
 	addCommonRepairPatchFlags(repairSetLeaderCmd)
 
+	// sync diverged configs
+	var repairSyncCmd = &cobra.Command{
+		Use:   "sync",
+		Short: "Synchronize diverged cluster-wide configs",
+		Long: `Overwrite diverged instances config files with the source one.
+All configuration files across directories <data-dir>/<app-name>.* are patched.`,
+
+		Args: cobra.ExactValidArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runRepairCommand(repair.Sync); err != nil {
+				log.Fatalf(err.Error())
+			}
+		},
+	}
+	repairSyncCmd.Flags().StringVar(&ctx.Repair.SyncSource, "source", "", repairSyncSourceUsage)
+	addCommonRepairPatchFlags(repairSyncCmd)
+
 	repairSubCommands := []*cobra.Command{
 		repairListCmd,
 		repairURICmd,
 		repairRemoveCmd,
 		repairSetLeaderCmd,
+		repairSyncCmd,
 	}
 
 	for _, cmd := range repairSubCommands {
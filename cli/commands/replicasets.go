@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/apex/log"
 	"github.com/spf13/cobra"
 	"github.com/tarantool/cartridge-cli/cli/context"
@@ -8,6 +10,11 @@ import (
 	"github.com/tarantool/cartridge-cli/cli/replicasets"
 )
 
+var (
+	decommissionTimeoutStr string
+	setupTimeoutStr        string
+)
+
 func init() {
 	var replicasetsCmd = &cobra.Command{
 		Use:   "replicasets",
@@ -31,6 +38,8 @@ func init() {
 		},
 	}
 
+	listCmd.Flags().StringVar(&ctx.Replicasets.ListFormat, "format", "", topologyFormatUsage)
+
 	// setup topology from file
 	var setupCmd = &cobra.Command{
 		Use:   "setup",
@@ -38,7 +47,7 @@ func init() {
 
 		Args: cobra.ExactValidArgs(0),
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := runReplicasetsCommand(replicasets.Setup, args); err != nil {
+			if err := runSetupCmd(cmd, args); err != nil {
 				log.Fatalf(err.Error())
 			}
 		},
@@ -47,6 +56,7 @@ func init() {
 	setupCmd.Flags().BoolVar(
 		&ctx.Replicasets.BootstrapVshard, "bootstrap-vshard", false, replicasetsBootstrapVshardUsage,
 	)
+	setupCmd.Flags().StringVar(&setupTimeoutStr, "timeout", "", replicasetsSetupTimeoutUsage)
 
 	// save topology to file
 	var saveCmd = &cobra.Command{
@@ -122,6 +132,7 @@ func init() {
 
 	addReplicasetFlag(addRolesCmd)
 	addRolesCmd.Flags().StringVar(&ctx.Replicasets.VshardGroup, "vshard-group", "", vshardGroupUsage)
+	registerFlagComp(addRolesCmd, "vshard-group", shellCompVshardGroupFlag)
 
 	// remove roles from replicaset
 	var removeRolesCmd = &cobra.Command{
@@ -198,6 +209,27 @@ func init() {
 		},
 	}
 
+	// decommission replicaset
+	var decommissionCmd = &cobra.Command{
+		Use:   "decommission REPLICASET_NAME",
+		Short: "Remove replica set from cluster",
+		Long: `Remove replica set from cluster.
+For vshard-storage replica set weight is set to 0 and all buckets
+are waited to be moved to other replica sets.
+Then replica set instances are disabled and expelled`,
+
+		Args: cobra.ExactValidArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runDecommissionCmd(cmd, args); err != nil {
+				log.Fatalf(err.Error())
+			}
+		},
+
+		ValidArgsFunction: ShellCompReplicasets,
+	}
+
+	decommissionCmd.Flags().StringVar(&decommissionTimeoutStr, "timeout", "", decommissionTimeoutUsage)
+
 	// add all sub-commands
 
 	replicasetsSubCommands := []*cobra.Command{
@@ -213,6 +245,7 @@ func init() {
 		bootstrapVshardCmd,
 		setWeightCmd,
 		listVshardGroupsCmd,
+		decommissionCmd,
 	}
 
 	for _, cmd := range replicasetsSubCommands {
@@ -233,3 +266,33 @@ func runReplicasetsCommand(replicasetsFunc func(ctx *context.Ctx, args []string)
 
 	return nil
 }
+
+func runSetupCmd(cmd *cobra.Command, args []string) error {
+	if setupTimeoutStr != "" {
+		var err error
+		if ctx.Replicasets.SetupTimeout, err = getDuration(setupTimeoutStr); err != nil {
+			cmd.Usage()
+			return fmt.Errorf(`Invalid argument %q for "--%s" flag: %s`, setupTimeoutStr, "timeout", err)
+		}
+	}
+
+	stopCommandContext := initCommandContext(ctx.Replicasets.SetupTimeout)
+	defer stopCommandContext()
+
+	return runReplicasetsCommand(replicasets.Setup, args)
+}
+
+func runDecommissionCmd(cmd *cobra.Command, args []string) error {
+	var err error
+
+	if err := setDefaultValue(cmd.Flags(), "timeout", defaultDecommissionTimeout.String()); err != nil {
+		return project.InternalError("Failed to set default timeout value: %s", err)
+	}
+
+	if ctx.Replicasets.DecommissionTimeout, err = getDuration(decommissionTimeoutStr); err != nil {
+		cmd.Usage()
+		return fmt.Errorf(`Invalid argument %q for "--%s" flag: %s`, decommissionTimeoutStr, "timeout", err)
+	}
+
+	return runReplicasetsCommand(replicasets.Decommission, args)
+}
@@ -43,6 +43,9 @@ func init() {
 	// stateboard flags
 	addStateboardRunningFlags(startCmd)
 
+	// etcd flags
+	addEtcdRunningFlags(startCmd)
+
 	// Disable instance name prefix in logs flag
 	startCmd.Flags().BoolVar(&ctx.Running.DisableLogPrefix, "no-log-prefix", false, disableLogPrefixUsage)
 
@@ -75,6 +78,7 @@ func runStartCmd(cmd *cobra.Command, args []string) error {
 	}
 
 	setStateboardFlagIsSet(cmd)
+	setEtcdFlagIsSet(cmd)
 
 	if err := running.FillCtx(&ctx, args); err != nil {
 		return err
@@ -34,12 +34,16 @@ func init() {
 	// stateboard flags
 	addStateboardRunningFlags(statusCmd)
 
+	// etcd flags
+	addEtcdRunningFlags(statusCmd)
+
 	// common running paths
 	addCommonRunningPathsFlags(statusCmd)
 }
 
 func runStatusCmd(cmd *cobra.Command, args []string) error {
 	setStateboardFlagIsSet(cmd)
+	setEtcdFlagIsSet(cmd)
 
 	if err := running.FillCtx(&ctx, args); err != nil {
 		return err
@@ -34,6 +34,9 @@ func init() {
 	// stateboard flags
 	addStateboardRunningFlags(stopCmd)
 
+	// etcd flags
+	addEtcdRunningFlags(stopCmd)
+
 	// common running paths
 	addCommonRunningPathsFlags(stopCmd)
 
@@ -43,6 +46,7 @@ func init() {
 
 func runStopCmd(cmd *cobra.Command, args []string) error {
 	setStateboardFlagIsSet(cmd)
+	setEtcdFlagIsSet(cmd)
 
 	if err := running.FillCtx(&ctx, args); err != nil {
 		return err
@@ -106,6 +106,9 @@ defaults to ./instances.yml ("cfg" in .cartridge.yml)`
 
 	stateboardOnlyUsage = `Manage only application stateboard`
 
+	etcdUsage = `Manage local etcd (or built-in etcd v2 stand-in)
+as well as instances ("etcd" in .cartridge.yml)`
+
 	logFollowUsage = `Output appended data as the log grows`
 
 	stopForceUsage = `Force instance(s) stop (sends SIGKILL)`
@@ -125,6 +128,9 @@ Defaults to replicasets.yml`
 
 	replicasetNameUsage = `Name of replica set`
 	vshardGroupUsage    = `Vshard group for vshard-storage replica set`
+
+	topologyFormatUsage = `Output topology as a graph in the specified format
+Supported formats: dot (Graphviz), mermaid`
 )
 
 // PROD
@@ -144,12 +150,79 @@ Show changes but don't apply them`
 	repairForceUsage = `Repair different configs separately`
 
 	repairReloadUsage = `Reload config on instances after patch`
+
+	repairSyncSourceUsage = `Instance name or config hash (prefix) to use as a source
+By default, config used by the majority of instances is chosen`
+
+	repairInventoryUsage = `Path to the inventory file that describes hosts
+where application instances are placed.
+Instances data directories are accessed via SSH`
 )
 
 // CONNECT
 const (
 	connectUsernameUsage = `Username`
 	connectPasswordUsage = `Password`
+	connectLanguageUsage = `Console language (lua or sql)
+Defaults to lua`
+	connectOutputUsage = `Output format (yaml, lua, json or table)
+Defaults to yaml`
+	connectEvalUsage     = `Lua expression or code chunk to evaluate`
+	connectEvalFileUsage = `Lua script to evaluate`
+)
+
+// ADMIN
+const (
+	adminCallTimeoutUsage = `Time to wait for the admin function call
+By default, the call isn't limited in time`
+
+	adminArgsJSONUsage = `Function arguments as a JSON object
+Arguments specified by flags have priority`
+
+	adminArgsFileUsage = `File with function arguments as a JSON object`
+
+	adminOutputUsage = `Output format: text or json
+In json mode, the function return value is printed as JSON
+and intermediate messages are printed to stderr`
+
+	adminAllUsage = `Call the function on all running instances of the application`
+
+	adminReplicasetUsage = `Call the function on running instances of the specified replica set`
+
+	adminRoleUsage = `Call the function on running instances of replica sets with the specified role`
+)
+
+var (
+	adminParallelUsage = fmt.Sprintf(`Maximum number of instances the function is called on simultaneously
+Used with --all, --replicaset or --role, defaults to %d`, defaultAdminParallel)
+)
+
+// PROFILE
+const (
+	profileUsage = `Connection profile name from ~/.config/cartridge/profiles.yml
+Explicitly specified flags have priority over the profile`
+)
+
+// SSL
+const (
+	transportUsage = `Connection transport (plain or ssl)
+Defaults to ssl if some SSL option is specified`
+	sslCaFileUsage     = `Path to the trusted certificate authorities file`
+	sslCertFileUsage   = `Path to the client SSL certificate file`
+	sslKeyFileUsage    = `Path to the client SSL private key file`
+	sslCiphersUsage    = `Colon-separated list of SSL cipher suites`
+	sslServerNameUsage = `Server name used to verify the server certificate
+Defaults to the connection host`
+)
+
+// EVAL
+var (
+	clusterEvalRoleUsage        = `Evaluate code only on instances of replica sets with specified role`
+	clusterEvalLeadersOnlyUsage = `Evaluate code only on replica sets leaders`
+	clusterEvalOutputUsage      = `Output format (table or json)
+Defaults to table`
+	clusterEvalTimeoutUsage = fmt.Sprintf(`Time to wait for evaluation result on each instance
+defaults to %s`, defaultClusterEvalTimeout.String())
 )
 
 // VERSION
@@ -169,6 +242,12 @@ parameters in JSON format`
 
 	failoverSetupFileUsage = `File where failover configuration is described
 Defaults to failover.yml`
+
+	forceInconsistencyUsage = `Promote the instance even if it hasn't
+received all changes from the current leader`
+
+	failoverWatchUsage = `Refresh the failover status periodically
+until Ctrl+C is pressed`
 )
 
 var (
@@ -177,4 +256,44 @@ defaults to %s`, defaultStartTimeout.String())
 
 	logLinesUsage = fmt.Sprintf(`Count of last lines to output
 defaults to %d`, defaultLogLines)
+
+	waitBalancedTimeoutUsage = fmt.Sprintf(`Time to wait for buckets to be balanced
+defaults to %s`, defaultWaitBalancedTimeout.String())
+
+	decommissionTimeoutUsage = fmt.Sprintf(`Time to wait for buckets to be moved from replica set
+defaults to %s`, defaultDecommissionTimeout.String())
+
+	switchoverTimeoutUsage = fmt.Sprintf(`Time to wait for the new leader to become writable
+defaults to %s`, defaultSwitchoverTimeout.String())
+
+	failoverWatchIntervalUsage = fmt.Sprintf(`Refresh interval for --watch mode
+defaults to %s`, defaultFailoverWatchInterval.String())
+)
+
+// SETUP
+const (
+	replicasetsSetupTimeoutUsage = `Time to wait for replica sets to be set up
+By default, the setup isn't limited in time`
+)
+
+// ETCD STAND-IN
+const (
+	etcdStandInNameUsage = `etcd member name`
+
+	etcdStandInListenUsage = `Address to listen for client requests`
+
+	etcdStandInDataDirUsage = `Directory to store data in`
+)
+
+// BENCH
+const (
+	benchWorkloadUsage = `YAML file that describes benchmark spaces and operations
+(can't be used with --keysize, --datasize, --insert, --select and --update flags)`
+)
+
+// GEN
+const (
+	genCompletionStdoutUsage = `Print completion script for the specified shell
+to stdout instead of writing files.
+Supported shells: bash, zsh, fish, powershell`
 )
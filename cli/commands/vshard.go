@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/apex/log"
+	"github.com/spf13/cobra"
+
+	"github.com/tarantool/cartridge-cli/cli/project"
+	"github.com/tarantool/cartridge-cli/cli/vshard"
+)
+
+var (
+	waitBalancedTimeoutStr string
+)
+
+func init() {
+	var vshardCmd = &cobra.Command{
+		Use:   "vshard",
+		Short: "Inspect vshard buckets distribution",
+	}
+
+	rootCmd.AddCommand(vshardCmd)
+
+	// vshard sub-commands
+
+	// get buckets status
+	var statusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "Get buckets status for each replica set",
+		Long: `Get buckets status for each vshard-storage replica set,
+rebalancer state and routers discovery status`,
+
+		Args: cobra.ExactValidArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := vshard.Status(&ctx); err != nil {
+				log.Fatalf(err.Error())
+			}
+		},
+	}
+
+	// wait until buckets are balanced
+	var waitBalancedCmd = &cobra.Command{
+		Use:   "wait-balanced",
+		Short: "Wait until buckets are balanced",
+		Long: `Wait until there are no buckets in transfer and buckets
+are distributed between replica sets according to their weights`,
+
+		Args: cobra.ExactValidArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runWaitBalancedCmd(cmd); err != nil {
+				log.Fatalf(err.Error())
+			}
+		},
+	}
+
+	waitBalancedCmd.Flags().StringVar(&waitBalancedTimeoutStr, "timeout", "", waitBalancedTimeoutUsage)
+
+	vshardSubCommands := []*cobra.Command{
+		statusCmd,
+		waitBalancedCmd,
+	}
+
+	for _, cmd := range vshardSubCommands {
+		vshardCmd.AddCommand(cmd)
+		configureFlags(cmd)
+		addCommonReplicasetsFlags(cmd)
+	}
+}
+
+func runWaitBalancedCmd(cmd *cobra.Command) error {
+	var err error
+
+	if err := setDefaultValue(cmd.Flags(), "timeout", defaultWaitBalancedTimeout.String()); err != nil {
+		return project.InternalError("Failed to set default timeout value: %s", err)
+	}
+
+	if ctx.Vshard.WaitTimeout, err = getDuration(waitBalancedTimeoutStr); err != nil {
+		cmd.Usage()
+		return fmt.Errorf(`Invalid argument %q for "--%s" flag: %s`, waitBalancedTimeoutStr, "timeout", err)
+	}
+
+	if err := vshard.WaitBalanced(&ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -26,6 +26,12 @@ func FileSHA256Hex(path string) (string, error) {
 	return fmt.Sprintf("%x", hasher.Sum(nil)), nil
 }
 
+// BytesSHA256Hex computes SHA256 for a given bytes slice.
+// The result is returned in a hex form
+func BytesSHA256Hex(data []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
 // FileSHA1Hex computes SHA1 for a given file.
 // The result is returned in a hex form
 func FileSHA1Hex(path string) (string, error) {
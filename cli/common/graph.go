@@ -0,0 +1,399 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	GraphFormatDot     = "dot"
+	GraphFormatMermaid = "mermaid"
+
+	leaderMarker = "★"
+
+	followUpstreamStatus = "follow"
+)
+
+var (
+	GraphFormats = []string{GraphFormatDot, GraphFormatMermaid}
+)
+
+// GraphInstance describes a topology instance to be shown on the graph
+type GraphInstance struct {
+	ID    string
+	Alias string
+	URI   string
+	Zone  string
+
+	Disabled bool
+
+	// Upstreams are the instance replication upstreams,
+	// they are shown only if graph ShowReplication is set
+	Upstreams []*GraphUpstream
+}
+
+// GraphUpstream describes a replication upstream of the instance
+type GraphUpstream struct {
+	// ID is an upstream instance ID
+	ID     string
+	Status string
+}
+
+// GraphReplicaset describes a topology replicaset to be shown on the graph
+type GraphReplicaset struct {
+	ID    string
+	Alias string
+	Roles []string
+
+	VshardGroup string
+	Weight      *float64
+	AllRW       bool
+
+	LeaderID  string
+	Instances []*GraphInstance
+}
+
+// TopologyGraph describes cluster topology.
+// Each replicaset is shown as a cluster of instances.
+// If ShowReplication is set, replication links are directed
+// from the upstream to the instance and labeled with the upstream status.
+// Otherwise, leadership links are directed from the configured leader
+// to other instances, they don't show actual replication upstreams
+type TopologyGraph struct {
+	Replicasets []*GraphReplicaset
+
+	ShowReplication bool
+}
+
+// RenderTopologyGraph returns a topology graph in the specified format
+func RenderTopologyGraph(graph *TopologyGraph, format string) (string, error) {
+	switch format {
+	case GraphFormatDot:
+		return graph.Dot(), nil
+	case GraphFormatMermaid:
+		return graph.Mermaid(), nil
+	default:
+		return "", getUnknownGraphFormatError(format)
+	}
+}
+
+// CheckGraphFormat checks that specified graph format is supported
+func CheckGraphFormat(format string) error {
+	if !StringSliceContains(GraphFormats, format) {
+		return getUnknownGraphFormatError(format)
+	}
+
+	return nil
+}
+
+func getUnknownGraphFormatError(format string) error {
+	return fmt.Errorf("Unknown graph format %q. Supported formats: %s", format, strings.Join(GraphFormats, ", "))
+}
+
+func (replicaset *GraphReplicaset) title() string {
+	if replicaset.Alias != "" {
+		return replicaset.Alias
+	}
+
+	return replicaset.ID
+}
+
+func (replicaset *GraphReplicaset) labelLines() []string {
+	lines := []string{replicaset.title()}
+
+	if len(replicaset.Roles) > 0 {
+		lines = append(lines, fmt.Sprintf("roles: %s", strings.Join(replicaset.Roles, ", ")))
+	}
+
+	// vshard group, weight, all rw
+	// only specified parameters are shown
+	additionalInfo := []string{}
+	if replicaset.VshardGroup != "" {
+		additionalInfo = append(additionalInfo, fmt.Sprintf("vshard group: %s", replicaset.VshardGroup))
+	}
+	if replicaset.Weight != nil {
+		additionalInfo = append(additionalInfo, fmt.Sprintf("weight: %s", strconv.FormatFloat(*replicaset.Weight, 'f', -1, 64)))
+	}
+	if replicaset.AllRW {
+		additionalInfo = append(additionalInfo, "ALL RW")
+	}
+
+	if len(additionalInfo) > 0 {
+		lines = append(lines, strings.Join(additionalInfo, " | "))
+	}
+
+	return lines
+}
+
+func (replicaset *GraphReplicaset) instanceLabelLines(instance *GraphInstance) []string {
+	title := instance.Alias
+	if title == "" {
+		title = instance.ID
+	}
+
+	if instance.ID == replicaset.LeaderID {
+		title = fmt.Sprintf("%s %s", leaderMarker, title)
+	}
+
+	lines := []string{title}
+
+	if instance.URI != "" {
+		lines = append(lines, instance.URI)
+	}
+
+	if instance.Zone != "" {
+		lines = append(lines, fmt.Sprintf("zone: %s", instance.Zone))
+	}
+
+	if instance.Disabled {
+		lines = append(lines, "disabled")
+	}
+
+	return lines
+}
+
+// leadershipLinks returns pairs of instances indexes
+// that are connected by leadership (leader -> other instance)
+func (replicaset *GraphReplicaset) leadershipLinks() [][2]int {
+	leaderIndex := -1
+	for i, instance := range replicaset.Instances {
+		if instance.ID == replicaset.LeaderID {
+			leaderIndex = i
+			break
+		}
+	}
+
+	if leaderIndex == -1 {
+		return nil
+	}
+
+	var links [][2]int
+	for i := range replicaset.Instances {
+		if i != leaderIndex {
+			links = append(links, [2]int{leaderIndex, i})
+		}
+	}
+
+	return links
+}
+
+// graphLink describes a link between instances
+// that are identified by replicaset and instance indexes
+type graphLink struct {
+	from  [2]int
+	to    [2]int
+	label string
+	// active is false for leadership links and not following upstreams
+	active bool
+}
+
+// links returns replication or leadership links of the graph
+func (graph *TopologyGraph) links() []graphLink {
+	var links []graphLink
+
+	if !graph.ShowReplication {
+		for i, replicaset := range graph.Replicasets {
+			for _, link := range replicaset.leadershipLinks() {
+				links = append(links, graphLink{
+					from:  [2]int{i, link[0]},
+					to:    [2]int{i, link[1]},
+					label: "leader",
+				})
+			}
+		}
+
+		return links
+	}
+
+	instancesIndexes := make(map[string][2]int)
+	for i, replicaset := range graph.Replicasets {
+		for j, instance := range replicaset.Instances {
+			instancesIndexes[instance.ID] = [2]int{i, j}
+		}
+	}
+
+	for i, replicaset := range graph.Replicasets {
+		for j, instance := range replicaset.Instances {
+			for _, upstream := range instance.Upstreams {
+				// upstreams that aren't shown on the graph are skipped
+				upstreamIndexes, found := instancesIndexes[upstream.ID]
+				if !found {
+					continue
+				}
+
+				links = append(links, graphLink{
+					from:   upstreamIndexes,
+					to:     [2]int{i, j},
+					label:  upstream.Status,
+					active: upstream.Status == followUpstreamStatus,
+				})
+			}
+		}
+	}
+
+	return links
+}
+
+// DOT
+
+func dotEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
+
+func dotQuote(s string) string {
+	return fmt.Sprintf(`"%s"`, dotEscape(s))
+}
+
+func dotLabel(lines []string) string {
+	escaped := make([]string, len(lines))
+	for i, line := range lines {
+		escaped[i] = dotEscape(line)
+	}
+
+	return fmt.Sprintf(`"%s"`, strings.Join(escaped, `\n`))
+}
+
+// Dot returns topology graph in Graphviz DOT format
+func (graph *TopologyGraph) Dot() string {
+	lines := []string{
+		"digraph topology {",
+		"  rankdir=LR;",
+		"  node [shape=box];",
+	}
+
+	var links []string
+
+	for _, replicaset := range graph.Replicasets {
+		lines = append(lines,
+			"",
+			fmt.Sprintf("  subgraph %s {", dotQuote("cluster_"+replicaset.ID)),
+			fmt.Sprintf("    label=%s;", dotLabel(replicaset.labelLines())),
+		)
+
+		for _, instance := range replicaset.Instances {
+			attrs := []string{fmt.Sprintf("label=%s", dotLabel(replicaset.instanceLabelLines(instance)))}
+
+			if instance.ID == replicaset.LeaderID {
+				attrs = append(attrs, "style=bold")
+			} else if instance.Disabled {
+				attrs = append(attrs, "style=dashed")
+			}
+
+			lines = append(lines, fmt.Sprintf("    %s [%s];", dotQuote(instance.ID), strings.Join(attrs, ", ")))
+		}
+
+		lines = append(lines, "  }")
+	}
+
+	for _, link := range graph.links() {
+		attrs := []string{fmt.Sprintf("label=%s", dotQuote(link.label))}
+
+		if !graph.ShowReplication {
+			attrs = append(attrs, "style=dotted")
+		} else if !link.active {
+			attrs = append(attrs, "style=dashed", "color=red")
+		}
+
+		links = append(links, fmt.Sprintf(
+			"  %s -> %s [%s];",
+			dotQuote(graph.Replicasets[link.from[0]].Instances[link.from[1]].ID),
+			dotQuote(graph.Replicasets[link.to[0]].Instances[link.to[1]].ID),
+			strings.Join(attrs, ", "),
+		))
+	}
+
+	if len(links) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, links...)
+	}
+
+	lines = append(lines, "}")
+
+	return strings.Join(lines, "\n")
+}
+
+// MERMAID
+
+func mermaidLabel(lines []string) string {
+	escaped := make([]string, len(lines))
+	for i, line := range lines {
+		escaped[i] = strings.ReplaceAll(line, `"`, "#quot;")
+	}
+
+	return fmt.Sprintf(`"%s"`, strings.Join(escaped, "<br/>"))
+}
+
+// Mermaid returns topology graph in Mermaid flowchart format.
+// Since Mermaid node IDs can't contain arbitrary symbols,
+// replicasets and instances are identified by their indexes
+func (graph *TopologyGraph) Mermaid() string {
+	lines := []string{
+		"graph LR",
+		"  classDef leader stroke-width:3px",
+		"  classDef disabled stroke-dasharray:5 5",
+	}
+
+	var links []string
+	var leaders []string
+	var disabled []string
+
+	instanceID := func(i, j int) string {
+		return fmt.Sprintf("rs%d_i%d", i+1, j+1)
+	}
+
+	for i, replicaset := range graph.Replicasets {
+		replicasetID := fmt.Sprintf("rs%d", i+1)
+
+		lines = append(lines,
+			"",
+			fmt.Sprintf("  subgraph %s[%s]", replicasetID, mermaidLabel(replicaset.labelLines())),
+		)
+
+		for j, instance := range replicaset.Instances {
+			lines = append(lines, fmt.Sprintf(
+				"    %s[%s]", instanceID(i, j), mermaidLabel(replicaset.instanceLabelLines(instance)),
+			))
+
+			if instance.ID == replicaset.LeaderID {
+				leaders = append(leaders, instanceID(i, j))
+			} else if instance.Disabled {
+				disabled = append(disabled, instanceID(i, j))
+			}
+		}
+
+		lines = append(lines, "  end")
+	}
+
+	for _, link := range graph.links() {
+		arrow := "-.->"
+		if link.active {
+			arrow = "-->"
+		}
+
+		links = append(links, fmt.Sprintf(
+			"  %s %s|%s| %s",
+			instanceID(link.from[0], link.from[1]), arrow, link.label, instanceID(link.to[0], link.to[1]),
+		))
+	}
+
+	if len(links) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, links...)
+	}
+
+	if len(leaders) > 0 || len(disabled) > 0 {
+		lines = append(lines, "")
+	}
+
+	if len(leaders) > 0 {
+		lines = append(lines, fmt.Sprintf("  class %s leader", strings.Join(leaders, ",")))
+	}
+
+	if len(disabled) > 0 {
+		lines = append(lines, fmt.Sprintf("  class %s disabled", strings.Join(disabled, ",")))
+	}
+
+	return strings.Join(lines, "\n")
+}
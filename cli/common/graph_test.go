@@ -0,0 +1,166 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestTopologyGraph() *TopologyGraph {
+	weight := 1.5
+
+	return &TopologyGraph{
+		Replicasets: []*GraphReplicaset{
+			{
+				ID:       "rpl-1",
+				Alias:    "router",
+				Roles:    []string{"vshard-router", "app.roles.custom"},
+				LeaderID: "srv-1",
+				Instances: []*GraphInstance{
+					{ID: "srv-1", Alias: "router-1", URI: "localhost:3301", Zone: "msk"},
+				},
+			},
+			{
+				ID:          "rpl-2",
+				Roles:       []string{"vshard-storage"},
+				VshardGroup: "hot",
+				Weight:      &weight,
+				AllRW:       true,
+				LeaderID:    "srv-2",
+				Instances: []*GraphInstance{
+					{ID: "srv-2", Alias: "storage-1", URI: "localhost:3302"},
+					{ID: "srv-3", Alias: `storage "2"`, URI: "localhost:3303", Disabled: true},
+				},
+			},
+		},
+	}
+}
+
+func TestTopologyGraphDot(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(`digraph topology {
+  rankdir=LR;
+  node [shape=box];
+
+  subgraph "cluster_rpl-1" {
+    label="router\nroles: vshard-router, app.roles.custom";
+    "srv-1" [label="★ router-1\nlocalhost:3301\nzone: msk", style=bold];
+  }
+
+  subgraph "cluster_rpl-2" {
+    label="rpl-2\nroles: vshard-storage\nvshard group: hot | weight: 1.5 | ALL RW";
+    "srv-2" [label="★ storage-1\nlocalhost:3302", style=bold];
+    "srv-3" [label="storage \"2\"\nlocalhost:3303\ndisabled", style=dashed];
+  }
+
+  "srv-2" -> "srv-3" [label="leader", style=dotted];
+}`, getTestTopologyGraph().Dot())
+}
+
+func TestTopologyGraphMermaid(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(`graph LR
+  classDef leader stroke-width:3px
+  classDef disabled stroke-dasharray:5 5
+
+  subgraph rs1["router<br/>roles: vshard-router, app.roles.custom"]
+    rs1_i1["★ router-1<br/>localhost:3301<br/>zone: msk"]
+  end
+
+  subgraph rs2["rpl-2<br/>roles: vshard-storage<br/>vshard group: hot | weight: 1.5 | ALL RW"]
+    rs2_i1["★ storage-1<br/>localhost:3302"]
+    rs2_i2["storage #quot;2#quot;<br/>localhost:3303<br/>disabled"]
+  end
+
+  rs2_i1 -.->|leader| rs2_i2
+
+  class rs1_i1,rs2_i1 leader
+  class rs2_i2 disabled`, getTestTopologyGraph().Mermaid())
+}
+
+func getTestReplicationTopologyGraph() *TopologyGraph {
+	graph := getTestTopologyGraph()
+	graph.ShowReplication = true
+
+	storages := graph.Replicasets[1].Instances
+	storages[0].Upstreams = []*GraphUpstream{
+		{ID: "srv-3", Status: "disconnected"},
+		// expelled instance isn't shown
+		{ID: "srv-4", Status: "follow"},
+	}
+	storages[1].Upstreams = []*GraphUpstream{
+		{ID: "srv-2", Status: "follow"},
+	}
+
+	return graph
+}
+
+func TestTopologyGraphReplication(t *testing.T) {
+	assert := assert.New(t)
+
+	graph := getTestReplicationTopologyGraph()
+
+	assert.Equal(`digraph topology {
+  rankdir=LR;
+  node [shape=box];
+
+  subgraph "cluster_rpl-1" {
+    label="router\nroles: vshard-router, app.roles.custom";
+    "srv-1" [label="★ router-1\nlocalhost:3301\nzone: msk", style=bold];
+  }
+
+  subgraph "cluster_rpl-2" {
+    label="rpl-2\nroles: vshard-storage\nvshard group: hot | weight: 1.5 | ALL RW";
+    "srv-2" [label="★ storage-1\nlocalhost:3302", style=bold];
+    "srv-3" [label="storage \"2\"\nlocalhost:3303\ndisabled", style=dashed];
+  }
+
+  "srv-3" -> "srv-2" [label="disconnected", style=dashed, color=red];
+  "srv-2" -> "srv-3" [label="follow"];
+}`, graph.Dot())
+
+	assert.Equal(`graph LR
+  classDef leader stroke-width:3px
+  classDef disabled stroke-dasharray:5 5
+
+  subgraph rs1["router<br/>roles: vshard-router, app.roles.custom"]
+    rs1_i1["★ router-1<br/>localhost:3301<br/>zone: msk"]
+  end
+
+  subgraph rs2["rpl-2<br/>roles: vshard-storage<br/>vshard group: hot | weight: 1.5 | ALL RW"]
+    rs2_i1["★ storage-1<br/>localhost:3302"]
+    rs2_i2["storage #quot;2#quot;<br/>localhost:3303<br/>disabled"]
+  end
+
+  rs2_i2 -.->|disconnected| rs2_i1
+  rs2_i1 -->|follow| rs2_i2
+
+  class rs1_i1,rs2_i1 leader
+  class rs2_i2 disabled`, graph.Mermaid())
+
+	// leadership links aren't shown on the replication graph
+	graph = getTestTopologyGraph()
+	graph.ShowReplication = true
+	assert.NotContains(graph.Dot(), "->")
+	assert.NotContains(graph.Mermaid(), "->")
+}
+
+func TestRenderTopologyGraph(t *testing.T) {
+	assert := assert.New(t)
+
+	var err error
+
+	_, err = RenderTopologyGraph(getTestTopologyGraph(), GraphFormatDot)
+	assert.Nil(err)
+
+	_, err = RenderTopologyGraph(getTestTopologyGraph(), GraphFormatMermaid)
+	assert.Nil(err)
+
+	_, err = RenderTopologyGraph(getTestTopologyGraph(), "svg")
+	assert.EqualError(err, `Unknown graph format "svg". Supported formats: dot, mermaid`)
+
+	assert.Nil(CheckGraphFormat("dot"))
+	assert.EqualError(CheckGraphFormat("text"), `Unknown graph format "text". Supported formats: dot, mermaid`)
+}
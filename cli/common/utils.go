@@ -99,6 +99,20 @@ func GetHomeDir() (string, error) {
 	return usr.HomeDir, nil
 }
 
+// ExpandHomeDir replaces leading ~ in the path with the current home directory
+func ExpandHomeDir(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	homeDir, err := GetHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("Failed to get home directory: %s", err)
+	}
+
+	return filepath.Join(homeDir, strings.TrimPrefix(path, "~")), nil
+}
+
 // RandomString generates random string length n
 func RandomString(n int) string {
 	var letters = []rune("abcdefghijklmnopqrstuvwxyz0123456789")
@@ -592,3 +606,32 @@ const (
 		"You may have made a typo. " +
 		"Please, try to specify instance name(s)"
 )
+
+// NormalizeValue converts maps with non-string keys
+// and byte slices to be encoded to JSON or YAML
+func NormalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized[i] = NormalizeValue(item)
+		}
+		return normalized
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[key] = NormalizeValue(item)
+		}
+		return normalized
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[fmt.Sprintf("%v", key)] = NormalizeValue(item)
+		}
+		return normalized
+	default:
+		return v
+	}
+}
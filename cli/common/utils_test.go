@@ -280,3 +280,53 @@ func TestInvalidDependencyParsing(t *testing.T) {
 	assert.Contains(err.Error(), errMsg)
 	assert.Equal(PackDependencies(nil), deps)
 }
+
+func TestExpandHomeDir(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	homeDir, err := GetHomeDir()
+	assert.Nil(err)
+
+	path, err := ExpandHomeDir("~")
+	assert.Nil(err)
+	assert.Equal(homeDir, path)
+
+	path, err = ExpandHomeDir("~/.ssh/id_rsa")
+	assert.Nil(err)
+	assert.Equal(homeDir+"/.ssh/id_rsa", path)
+
+	path, err = ExpandHomeDir("~user/file")
+	assert.Nil(err)
+	assert.Equal("~user/file", path)
+
+	path, err = ExpandHomeDir("/etc/hosts")
+	assert.Nil(err)
+	assert.Equal("/etc/hosts", path)
+}
+
+func TestNormalizeValue(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	assert.Equal(nil, NormalizeValue(nil))
+	assert.Equal(uint64(1), NormalizeValue(uint64(1)))
+	assert.Equal("bytes", NormalizeValue([]byte("bytes")))
+
+	assert.Equal(
+		map[string]interface{}{
+			"1": "one",
+			"list": []interface{}{
+				"bytes",
+				map[string]interface{}{"true": int64(2)},
+			},
+		},
+		NormalizeValue(map[interface{}]interface{}{
+			uint64(1): "one",
+			"list": []interface{}{
+				[]byte("bytes"),
+				map[interface{}]interface{}{true: int64(2)},
+			},
+		}),
+	)
+}
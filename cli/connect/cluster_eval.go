@@ -0,0 +1,236 @@
+package connect
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tarantool/cartridge-cli/cli/cluster"
+	"github.com/tarantool/cartridge-cli/cli/common"
+	"github.com/tarantool/cartridge-cli/cli/context"
+	"github.com/tarantool/cartridge-cli/cli/project"
+	"github.com/tarantool/cartridge-cli/cli/replicasets"
+	"github.com/tarantool/cartridge-cli/cli/running"
+)
+
+const (
+	clusterEvalStatusOk    = "ok"
+	clusterEvalStatusError = "error"
+)
+
+// clusterEvalResult describes the result of evaluation on one instance
+type clusterEvalResult struct {
+	InstanceName string
+	Values       []interface{}
+	Err          error
+}
+
+// EvalCluster evaluates the code on all local instances of the application
+// (or on the instances selected by replica set, role or leadership)
+// and prints the aggregated results
+func EvalCluster(ctx *context.Ctx, args []string) error {
+	if err := project.FillCtx(ctx); err != nil {
+		return err
+	}
+
+	if len(args) > 1 {
+		return fmt.Errorf("Should be specified one expression")
+	}
+
+	if len(args) == 1 {
+		if ctx.Connect.EvalFile != "" {
+			return fmt.Errorf("Expression and --file flag can't be specified together")
+		}
+		ctx.Connect.Eval = args[0]
+	}
+
+	if !evalIsSpecified(ctx) {
+		return fmt.Errorf("Expression or --file flag should be specified")
+	}
+
+	output := ctx.Connect.Output
+	if output == "" {
+		output = TableOutput
+	}
+
+	if output != TableOutput && output != JSONOutput {
+		return fmt.Errorf("Unknown output format %q. Supported formats: table, json", output)
+	}
+
+	code, err := getEvalCode(ctx)
+	if err != nil {
+		return err
+	}
+
+	instancesNames, err := getClusterEvalInstancesNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	results := make([]*clusterEvalResult, len(instancesNames))
+
+	var wg sync.WaitGroup
+	for i, instanceName := range instancesNames {
+		wg.Add(1)
+		go func(i int, instanceName string) {
+			defer wg.Done()
+			results[i] = evalOnInstance(ctx, instanceName, code)
+		}(i, instanceName)
+	}
+	wg.Wait()
+
+	if output == JSONOutput {
+		fmt.Print(renderClusterEvalJSON(results))
+	} else {
+		fmt.Print(renderClusterEvalTable(results))
+	}
+
+	var failedInstancesNames []string
+	for _, result := range results {
+		if result.Err != nil {
+			failedInstancesNames = append(failedInstancesNames, result.InstanceName)
+		}
+	}
+
+	if len(failedInstancesNames) > 0 {
+		return fmt.Errorf(
+			"Failed to evaluate code on %d instance(s): %s",
+			len(failedInstancesNames), strings.Join(failedInstancesNames, ", "),
+		)
+	}
+
+	return nil
+}
+
+func evalOnInstance(ctx *context.Ctx, instanceName string, code *evalCode) *clusterEvalResult {
+	result := &clusterEvalResult{
+		InstanceName: instanceName,
+	}
+
+	process := running.NewInstanceProcess(ctx, instanceName)
+	if !process.IsRunning() {
+		result.Err = fmt.Errorf("Instance is not running")
+		return result
+	}
+
+	target := &evalTarget{
+		Name: instanceName,
+		ConnOpts: &ConnOpts{
+			Network: "unix",
+			Address: project.GetInstanceConsoleSock(ctx, instanceName),
+		},
+	}
+
+	result.Values, result.Err = evalOnTarget(target, code, ctx.Eval.Timeout)
+	return result
+}
+
+// getClusterEvalInstancesNames returns sorted names of the instances
+// described in the instances configuration file.
+// If some topology filter is specified, only matching instances are returned
+func getClusterEvalInstancesNames(ctx *context.Ctx) ([]string, error) {
+	instancesConf, err := cluster.GetInstancesConf(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get instances configuration: %s", err)
+	}
+
+	var instancesNames []string
+	for instanceName := range *instancesConf {
+		instancesNames = append(instancesNames, instanceName)
+	}
+	sort.Strings(instancesNames)
+
+	if ctx.Replicasets.ReplicasetName != "" || ctx.Eval.Role != "" || ctx.Eval.LeadersOnly {
+		conn, err := cluster.ConnectToSomeJoinedInstance(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+
+		topologyReplicasets, err := replicasets.GetTopologyReplicasets(conn)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get current topology replica sets: %s", err)
+		}
+
+		instancesNames, err = filterInstancesByTopology(instancesNames, topologyReplicasets, ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(instancesNames) == 0 {
+		return nil, fmt.Errorf("No instances match the specified filters")
+	}
+
+	return instancesNames, nil
+}
+
+func filterInstancesByTopology(instancesNames []string,
+	topologyReplicasets *replicasets.TopologyReplicasets, ctx *context.Ctx) ([]string, error) {
+
+	matchingInstances, err := replicasets.FilterInstances(
+		topologyReplicasets, ctx.Replicasets.ReplicasetName, ctx.Eval.Role, ctx.Eval.LeadersOnly,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var filteredInstancesNames []string
+	for _, instanceName := range instancesNames {
+		if matchingInstances[instanceName] {
+			filteredInstancesNames = append(filteredInstancesNames, instanceName)
+		}
+	}
+
+	return filteredInstancesNames, nil
+}
+
+func renderClusterEvalTable(results []*clusterEvalResult) string {
+	rows := make([][]string, len(results))
+	for i, result := range results {
+		if result.Err != nil {
+			rows[i] = []string{result.InstanceName, clusterEvalStatusError, result.Err.Error()}
+			continue
+		}
+
+		values := make([]string, len(result.Values))
+		for j, value := range result.Values {
+			values[j] = formatTableCell(value)
+		}
+
+		rows[i] = []string{result.InstanceName, clusterEvalStatusOk, strings.Join(values, ", ")}
+	}
+
+	lines := drawTable([]string{"instance", "status", "result"}, rows)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func renderClusterEvalJSON(results []*clusterEvalResult) string {
+	resultsByInstance := make(map[string]interface{}, len(results))
+	for _, result := range results {
+		if result.Err != nil {
+			resultsByInstance[result.InstanceName] = map[string]interface{}{
+				"error": result.Err.Error(),
+			}
+			continue
+		}
+
+		values := make([]interface{}, len(result.Values))
+		for i, value := range result.Values {
+			values[i] = common.NormalizeValue(value)
+		}
+
+		resultsByInstance[result.InstanceName] = map[string]interface{}{
+			"results": values,
+		}
+	}
+
+	encoded, err := json.MarshalIndent(resultsByInstance, "", "  ")
+	if err != nil {
+		return renderError(YAMLOutput, fmt.Errorf("Failed to encode results to JSON: %s", err))
+	}
+
+	return string(encoded) + "\n"
+}
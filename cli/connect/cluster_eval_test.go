@@ -0,0 +1,125 @@
+package connect
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tarantool/cartridge-cli/cli/context"
+	"github.com/tarantool/cartridge-cli/cli/replicasets"
+)
+
+func TestFilterInstancesByTopology(t *testing.T) {
+	assert := assert.New(t)
+
+	var err error
+	var instancesNames []string
+	var ctx context.Ctx
+
+	localInstancesNames := []string{"router", "s1-master", "s1-replica", "s2-master"}
+
+	topologyReplicasets := &replicasets.TopologyReplicasets{
+		"router-uuid": &replicasets.TopologyReplicaset{
+			Alias:      "router",
+			Roles:      []string{"vshard-router"},
+			LeaderUUID: "router-instance-uuid",
+			Instances: replicasets.TopologyInstances{
+				{Alias: "router", UUID: "router-instance-uuid"},
+			},
+		},
+		"s1-uuid": &replicasets.TopologyReplicaset{
+			Alias:      "s-1",
+			Roles:      []string{"vshard-storage"},
+			LeaderUUID: "s1-master-uuid",
+			Instances: replicasets.TopologyInstances{
+				{Alias: "s1-master", UUID: "s1-master-uuid"},
+				{Alias: "s1-replica", UUID: "s1-replica-uuid"},
+			},
+		},
+		"s2-uuid": &replicasets.TopologyReplicaset{
+			Alias:      "s-2",
+			Roles:      []string{"vshard-storage"},
+			LeaderUUID: "s2-master-uuid",
+			Instances: replicasets.TopologyInstances{
+				{Alias: "s2-master", UUID: "s2-master-uuid"},
+				{Alias: "remote-replica", UUID: "remote-replica-uuid"},
+			},
+		},
+	}
+
+	// replicaset
+	ctx.Replicasets.ReplicasetName = "s-1"
+	instancesNames, err = filterInstancesByTopology(localInstancesNames, topologyReplicasets, &ctx)
+	assert.Nil(err)
+	assert.Equal([]string{"s1-master", "s1-replica"}, instancesNames)
+
+	// unknown replicaset
+	ctx.Replicasets.ReplicasetName = "unknown"
+	_, err = filterInstancesByTopology(localInstancesNames, topologyReplicasets, &ctx)
+	assert.EqualError(err, "Replica set unknown isn't found in current topology")
+
+	// role (remote instances are skipped)
+	ctx.Replicasets.ReplicasetName = ""
+	ctx.Eval.Role = "vshard-storage"
+	instancesNames, err = filterInstancesByTopology(localInstancesNames, topologyReplicasets, &ctx)
+	assert.Nil(err)
+	assert.Equal([]string{"s1-master", "s1-replica", "s2-master"}, instancesNames)
+
+	// role and leaders
+	ctx.Eval.LeadersOnly = true
+	instancesNames, err = filterInstancesByTopology(localInstancesNames, topologyReplicasets, &ctx)
+	assert.Nil(err)
+	assert.Equal([]string{"s1-master", "s2-master"}, instancesNames)
+
+	// leaders
+	ctx.Eval.Role = ""
+	instancesNames, err = filterInstancesByTopology(localInstancesNames, topologyReplicasets, &ctx)
+	assert.Nil(err)
+	assert.Equal([]string{"router", "s1-master", "s2-master"}, instancesNames)
+
+	// no matching instances
+	ctx.Eval.Role = "unknown-role"
+	instancesNames, err = filterInstancesByTopology(localInstancesNames, topologyReplicasets, &ctx)
+	assert.Nil(err)
+	assert.Len(instancesNames, 0)
+}
+
+func TestRenderClusterEvalResults(t *testing.T) {
+	assert := assert.New(t)
+
+	results := []*clusterEvalResult{
+		{InstanceName: "router", Values: []interface{}{int8(1), "str"}},
+		{InstanceName: "s1-master", Values: []interface{}{map[string]interface{}{"a": uint8(1)}}},
+		{InstanceName: "s1-replica", Err: fmt.Errorf("Instance is not running")},
+	}
+
+	assert.Equal(`+------------+--------+-------------------------+
+| instance   | status | result                  |
++------------+--------+-------------------------+
+| router     | ok     | 1, str                  |
+| s1-master  | ok     | {"a":1}                 |
+| s1-replica | error  | Instance is not running |
++------------+--------+-------------------------+
+`, renderClusterEvalTable(results))
+
+	assert.Equal(`{
+  "router": {
+    "results": [
+      1,
+      "str"
+    ]
+  },
+  "s1-master": {
+    "results": [
+      {
+        "a": 1
+      }
+    ]
+  },
+  "s1-replica": {
+    "error": "Instance is not running"
+  }
+}
+`, renderClusterEvalJSON(results))
+}
@@ -1,16 +1,14 @@
 package connect
 
 import (
-	"strings"
+	"fmt"
 
+	"github.com/tarantool/cartridge-cli/cli/connector"
 	"github.com/tarantool/cartridge-cli/cli/context"
 )
 
 const (
 	MaxHistoryLines = 10000
-
-	TCPNetwork  = "tcp"
-	UnixNetwork = "unix"
 )
 
 type ConnOpts struct {
@@ -18,55 +16,49 @@ type ConnOpts struct {
 	Address  string
 	Username string
 	Password string
+
+	Transport string
+	SSL       connector.SSLOpts
+}
+
+type ConsoleOpts struct {
+	Title    string
+	Language string
+	Output   string
+
+	HistoryFile string
+	HistorySize int
 }
 
 type GetRawSuggestionsFunc func(console *Console, lastWord string) interface{}
 
 func getConnOpts(connString string, ctx *context.Ctx) (*ConnOpts, error) {
-	connOpts := ConnOpts{
-		Username: ctx.Connect.Username,
-		Password: ctx.Connect.Password,
+	connectorConnOpts, err := connector.GetConnOpts(connString, connector.Opts{
+		Username:  ctx.Connect.Username,
+		Password:  ctx.Connect.Password,
+		Transport: ctx.Connect.Transport,
+		SSL:       connector.SSLOpts(ctx.Connect.SSL),
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	connStringParts := strings.SplitN(connString, "@", 2)
-	address := connStringParts[len(connStringParts)-1]
+	connOpts := ConnOpts(*connectorConnOpts)
+	return &connOpts, nil
+}
 
-	if len(connStringParts) > 1 {
-		authString := connStringParts[0]
-		authStringParts := strings.SplitN(authString, ":", 2)
+func connect(connOpts *ConnOpts) (*connector.Conn, error) {
+	connString := fmt.Sprintf("%s://%s", connOpts.Network, connOpts.Address)
 
-		if connOpts.Username == "" {
-			connOpts.Username = authStringParts[0]
-		}
-		if len(authStringParts) > 1 && connOpts.Password == "" {
-			connOpts.Password = authStringParts[1]
-		}
+	conn, err := connector.Connect(connString, connector.Opts{
+		Username:  connOpts.Username,
+		Password:  connOpts.Password,
+		Transport: connOpts.Transport,
+		SSL:       connOpts.SSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to connect: %s", err)
 	}
 
-	addrLen := len(address)
-	switch {
-	case addrLen > 0 && (address[0] == '.' || address[0] == '/'):
-		connOpts.Network = UnixNetwork
-		connOpts.Address = address
-	case addrLen >= 7 && address[0:7] == "unix://":
-		connOpts.Network = UnixNetwork
-		connOpts.Address = address[7:]
-	case addrLen >= 5 && address[0:5] == "unix:":
-		connOpts.Network = UnixNetwork
-		connOpts.Address = address[5:]
-	case addrLen >= 6 && address[0:6] == "unix/:":
-		connOpts.Network = UnixNetwork
-		connOpts.Address = address[6:]
-	case addrLen >= 6 && address[0:6] == "tcp://":
-		connOpts.Network = TCPNetwork
-		connOpts.Address = address[6:]
-	case addrLen >= 4 && address[0:4] == "tcp:":
-		connOpts.Network = TCPNetwork
-		connOpts.Address = address[4:]
-	default:
-		connOpts.Network = TCPNetwork
-		connOpts.Address = address
-	}
-
-	return &connOpts, nil
+	return conn, nil
 }
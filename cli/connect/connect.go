@@ -25,27 +25,43 @@ func Enter(ctx *context.Ctx, args []string) error {
 		return err
 	}
 
-	if len(ctx.Running.Instances) != 1 {
+	if evalIsSpecified(ctx) {
+		if len(ctx.Running.Instances) == 0 {
+			return fmt.Errorf("Should be specified at least one instance name")
+		}
+	} else if len(ctx.Running.Instances) != 1 {
 		return fmt.Errorf("Should be specified one instance name")
 	}
 
-	instanceName := ctx.Running.Instances[0]
+	targets := make([]*evalTarget, len(ctx.Running.Instances))
+	for i, instanceName := range ctx.Running.Instances {
+		process := running.NewInstanceProcess(ctx, instanceName)
+		if !process.IsRunning() {
+			return common.ErrWrapCheckInstanceNameCommonMisprint([]string{instanceName}, ctx.Project.Name,
+				fmt.Errorf("Instance %s is not running", instanceName))
+		}
+
+		targets[i] = &evalTarget{
+			Name: instanceName,
+			ConnOpts: &ConnOpts{
+				Network: "unix",
+				Address: project.GetInstanceConsoleSock(ctx, instanceName),
+			},
+		}
+	}
 
-	process := running.NewInstanceProcess(ctx, instanceName)
-	if !process.IsRunning() {
-		return common.ErrWrapCheckInstanceNameCommonMisprint([]string{instanceName}, ctx.Project.Name,
-			fmt.Errorf("Instance %s is not running", instanceName))
+	if evalIsSpecified(ctx) {
+		return runEval(targets, ctx)
 	}
 
-	socketPath := project.GetInstanceConsoleSock(ctx, instanceName)
-	title := project.GetInstanceID(ctx, instanceName)
+	title := project.GetInstanceID(ctx, ctx.Running.Instances[0])
 
-	connOpts := ConnOpts{
-		Network: "unix",
-		Address: socketPath,
+	historyFilePath, err := getHistoryFilePath(ctx, ctx.Running.Instances[0])
+	if err != nil {
+		return err
 	}
 
-	if err := runConsole(&connOpts, title); err != nil {
+	if err := runConsole(targets[0].ConnOpts, title, historyFilePath, ctx); err != nil {
 		return fmt.Errorf("Failed to run interactive console: %s", err)
 	}
 
@@ -53,27 +69,46 @@ func Enter(ctx *context.Ctx, args []string) error {
 }
 
 func Connect(ctx *context.Ctx, args []string) error {
-	if len(args) != 1 {
+	if evalIsSpecified(ctx) {
+		if len(args) == 0 {
+			return fmt.Errorf("Should be specified at least one connection string")
+		}
+	} else if len(args) != 1 {
 		return fmt.Errorf("Should be specified one connection string")
 	}
 
-	connString := args[0]
+	targets := make([]*evalTarget, len(args))
+	for i, connString := range args {
+		connOpts, err := getConnOpts(connString, ctx)
+		if err != nil {
+			return fmt.Errorf("Failed to get connection opts: %s", err)
+		}
+
+		targets[i] = &evalTarget{
+			Name:     connOpts.Address,
+			ConnOpts: connOpts,
+		}
+	}
 
-	connOpts, err := getConnOpts(connString, ctx)
-	if err != nil {
-		return fmt.Errorf("Failed to get connection opts: %s", err)
+	if evalIsSpecified(ctx) {
+		return runEval(targets, ctx)
 	}
 
-	if err := runConsole(connOpts, ""); err != nil {
+	if err := runConsole(targets[0].ConnOpts, "", "", ctx); err != nil {
 		return fmt.Errorf("Failed to run interactive console: %s", err)
 	}
 
 	return nil
-
 }
 
-func runConsole(connOpts *ConnOpts, title string) error {
-	console, err := NewConsole(connOpts, title)
+func runConsole(connOpts *ConnOpts, title string, historyFilePath string, ctx *context.Ctx) error {
+	console, err := NewConsole(connOpts, ConsoleOpts{
+		Title:       title,
+		Language:    ctx.Connect.Language,
+		Output:      ctx.Connect.Output,
+		HistoryFile: historyFilePath,
+		HistorySize: ctx.Connect.HistorySize,
+	})
 	if err != nil {
 		return fmt.Errorf("Failed to create new console: %s", err)
 	}
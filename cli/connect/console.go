@@ -2,11 +2,10 @@ package connect
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
-	"io"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"sort"
 	"strings"
 	"time"
@@ -27,6 +26,10 @@ const (
 	HistoryFileName = ".tarantool_history"
 
 	MaxLivePrefixIndent = 15
+
+	reconnectAttempts     = 10
+	reconnectInitialDelay = 500 * time.Millisecond
+	reconnectMaxDelay     = 5 * time.Second
 )
 
 var (
@@ -42,19 +45,32 @@ func init() {
 type Console struct {
 	input string
 
-	title string
+	// pendingInput is the input that wasn't executed
+	// because the connection was lost
+	pendingInput string
+
+	title          string
+	specifiedTitle string
 
 	historyFile     *os.File
 	historyFilePath string
+	historySize     int
 	historyLines    []string
+	historySearch   historySearch
 
 	prefix            string
 	livePrefixEnabled bool
 	livePrefix        string
 	livePrefixFunc    func() (string, bool)
 
-	connOpts *ConnOpts
-	conn     *connector.Conn
+	disconnectedPrefix string
+
+	connOpts     *ConnOpts
+	conn         *connector.Conn
+	disconnected bool
+
+	language string
+	output   string
 
 	executor  func(in string)
 	completer func(in prompt.Document) []prompt.Suggest
@@ -64,27 +80,45 @@ type Console struct {
 	prompt *prompt.Prompt
 }
 
-func NewConsole(connOpts *ConnOpts, title string) (*Console, error) {
+func NewConsole(connOpts *ConnOpts, opts ConsoleOpts) (*Console, error) {
 	console := &Console{
-		title:    title,
-		connOpts: connOpts,
-		luaState: lua.NewState(),
+		title:           opts.Title,
+		specifiedTitle:  opts.Title,
+		connOpts:        connOpts,
+		language:        opts.Language,
+		output:          opts.Output,
+		historyFilePath: opts.HistoryFile,
+		historySize:     opts.HistorySize,
+		luaState:        lua.NewState(),
 	}
 
 	var err error
 
+	if console.language == "" {
+		console.language = LuaLanguage
+	}
+
+	if err := CheckLanguage(console.language); err != nil {
+		return nil, err
+	}
+
+	if console.output == "" {
+		console.output = YAMLOutput
+	}
+
+	if err := CheckOutput(console.output); err != nil {
+		return nil, err
+	}
+
 	// load Tarantool console history from file
 	if err := loadHistory(console); err != nil {
 		log.Debugf("Failed to load Tarantool console history: %s", err)
 	}
 
 	// connect to specified address
-	console.conn, err = connector.Connect(connOpts.Address, connector.Opts{
-		Username: connOpts.Username,
-		Password: connOpts.Password,
-	})
+	console.conn, err = connect(connOpts)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to connect: %s", err)
+		return nil, err
 	}
 
 	// initialize user commands executor
@@ -150,82 +184,237 @@ func (console *Console) Close() {
 	}
 }
 
-func loadHistory(console *Console) error {
-	var err error
+func getExecutor(console *Console) prompt.Executor {
+	executor := func(in string) {
+		if console.input == "" && strings.TrimSpace(in) == "" && console.pendingInput != "" {
+			// empty input after the connection loss retries the pending input
+			console.input = console.pendingInput
+		} else {
+			console.input += in + " "
+		}
 
-	homeDir, err := common.GetHomeDir()
-	if err != nil {
-		return fmt.Errorf("Failed to get home directory: %s", err)
+		console.pendingInput = ""
+
+		if !console.inputIsCompleted() {
+			console.livePrefixEnabled = true
+			return
+		}
+
+		input := strings.TrimSpace(console.input)
+
+		if err := appendToHistoryFile(console, input); err != nil {
+			log.Debugf("Failed to append command to history file: %s", err)
+		}
+
+		data, err := console.execute(input)
+		if connector.IsConnectionLost(err) {
+			if console.prompt == nil {
+				// piped input can't be retried
+				log.Fatalf("Connection was closed. Probably instance process isn't running anymore")
+			}
+
+			// the input could be already executed by the instance,
+			// so it's retried only if the user confirms it
+			if reconnectErr := console.reconnect(); reconnectErr != nil {
+				err = reconnectErr
+			} else {
+				err = fmt.Errorf("Connection was lost, the last input may not have been executed")
+			}
+		}
+
+		if err != nil {
+			log.Errorf("%s", err)
+			log.Infof("Press Enter to retry the last input")
+			console.pendingInput = console.input
+		} else if data != "" {
+			fmt.Printf("%s\n", data)
+		}
+
+		console.input = ""
+		console.livePrefixEnabled = false
 	}
 
-	console.historyFilePath = filepath.Join(homeDir, HistoryFileName)
+	return executor
+}
 
-	console.historyLines, err = common.GetLastNLines(console.historyFilePath, MaxHistoryLines)
-	if err != nil {
-		return fmt.Errorf("Failed to read history from file: %s", err)
+func (console *Console) execute(input string) (string, error) {
+	if language, ok := parseSetLanguageCommand(input); ok {
+		return setLanguage(console, language), nil
 	}
 
-	// open history file for appending
-	// see https://unix.stackexchange.com/questions/346062/concurrent-writing-to-a-log-file-from-many-processes
-	console.historyFile, err = os.OpenFile(
-		console.historyFilePath,
-		os.O_APPEND|os.O_CREATE|os.O_WRONLY,
-		0644,
-	)
+	if output, ok := parseSetOutputCommand(input); ok {
+		return setOutput(console, output), nil
+	}
 
-	if err != nil {
-		log.Debugf("Failed to open history file for append: %s", err)
+	if console.language == SQLLanguage && !strings.HasPrefix(input, `\`) {
+		if input == "" {
+			return "", nil
+		}
+		return executeSQL(console, input)
 	}
 
-	return nil
+	if console.output == YAMLOutput {
+		return evalLua(console, console.input)
+	}
+
+	return evalLuaAndRender(console, console.input)
 }
 
-func getExecutor(console *Console) prompt.Executor {
-	executor := func(in string) {
-		console.input += in + " "
+// reconnect tries to connect to the instance again with exponential backoff.
+// The input that was being executed isn't retried here.
+// While the console is disconnected, the prompt prefix shows the connection status.
+// After reconnect, title and prefix are updated since the instance
+// could be changed (e.g. the instance is restarted with another alias)
+func (console *Console) reconnect() error {
+	console.disconnected = true
+	if console.conn != nil {
+		console.conn.Close()
+	}
 
-		if !inputIsCompleted(console.input, console.luaState) {
-			console.livePrefixEnabled = true
-			return
-		}
+	log.Warnf("Connection to %s was lost, reconnecting to %s", console.title, console.connOpts.Address)
 
-		if err := appendToHistoryFile(console, strings.TrimSpace(console.input)); err != nil {
-			log.Debugf("Failed to append command to history file: %s", err)
+	var err error
+	delay := reconnectInitialDelay
+
+	for attempt := 1; attempt <= reconnectAttempts; attempt++ {
+		log.Debugf("Reconnecting to %s (attempt %d of %d)", console.connOpts.Address, attempt, reconnectAttempts)
+
+		var conn *connector.Conn
+		if conn, err = connect(console.connOpts); err == nil {
+			console.conn = conn
+			console.disconnected = false
+
+			console.title = console.specifiedTitle
+			setTitle(console)
+			setPrefix(console)
+
+			log.Infof("Reconnected to %s", console.title)
+			return nil
 		}
 
-		req := connector.EvalReq(evalFuncBody, console.input)
-		req.SetPushCallback(func(pushedData interface{}) {
-			encodedData, err := yaml.Marshal(pushedData)
-			if err != nil {
-				log.Warnf("Failed to encode pushed data: %s", err)
-				return
-			}
+		log.Debugf("Failed to reconnect: %s", err)
 
-			common.ColorYellow.Printf("%s\n", encodedData)
-		})
+		if attempt < reconnectAttempts {
+			time.Sleep(delay)
 
-		var data string
-		var results []string
-		if err := console.conn.ExecTyped(req, &results); err != nil {
-			if err == io.EOF {
-				log.Fatalf("Connection was closed. Probably instance process isn't running anymore")
-			} else {
-				log.Fatalf("Failed to execute command: %s", err)
+			if delay *= 2; delay > reconnectMaxDelay {
+				delay = reconnectMaxDelay
 			}
-		} else if len(results) == 0 {
-			log.Infof("Connection closed")
-			os.Exit(0)
-		} else {
-			data = results[0]
 		}
+	}
 
-		fmt.Printf("%s\n", data)
+	return fmt.Errorf("Failed to reconnect to %s after %d attempts: %s", console.connOpts.Address, reconnectAttempts, err)
+}
 
-		console.input = ""
-		console.livePrefixEnabled = false
+func (console *Console) inputIsCompleted() bool {
+	if console.language == SQLLanguage {
+		return sqlInputIsCompleted(console.input)
 	}
 
-	return executor
+	return inputIsCompleted(console.input, console.luaState)
+}
+
+func setLanguage(console *Console, language string) string {
+	if err := CheckLanguage(language); err != nil {
+		return renderError(console.output, err)
+	}
+
+	console.language = language
+
+	return renderOutput(console.output, []interface{}{true})
+}
+
+func setOutput(console *Console, output string) string {
+	if err := CheckOutput(output); err != nil {
+		return renderError(console.output, err)
+	}
+
+	console.output = output
+
+	return renderOutput(console.output, []interface{}{true})
+}
+
+func evalLua(console *Console, in string) (string, error) {
+	req := connector.EvalReq(evalFuncBody, in)
+	req.SetPushCallback(func(pushedData interface{}) {
+		encodedData, err := yaml.Marshal(pushedData)
+		if err != nil {
+			log.Warnf("Failed to encode pushed data: %s", err)
+			return
+		}
+
+		common.ColorYellow.Printf("%s\n", encodedData)
+	})
+
+	var results []string
+	if err := execRequest(console, req, &results); err != nil {
+		return "", err
+	}
+
+	if len(results) == 0 {
+		log.Infof("Connection closed")
+		os.Exit(0)
+	}
+
+	return results[0], nil
+}
+
+func executeSQL(console *Console, statement string) (string, error) {
+	req := connector.EvalReq(executeSQLFuncBody, statement)
+
+	var results []*SQLResult
+	if err := execRequest(console, req, &results); err != nil {
+		return "", err
+	}
+
+	if len(results) == 0 {
+		log.Infof("Connection closed")
+		os.Exit(0)
+	}
+
+	return renderSQLResult(results[0], console.output), nil
+}
+
+// evalLuaAndRender evaluates Lua code and renders returned values
+// according to the console output format.
+// In contrast to evalLua, values are returned as they are
+// instead of being formatted by the Tarantool console
+func evalLuaAndRender(console *Console, in string) (string, error) {
+	req := connector.EvalReq(evalRawFuncBody, in)
+	req.SetPushCallback(func(pushedData interface{}) {
+		common.ColorYellow.Printf("%s\n", renderOutput(console.output, []interface{}{pushedData}))
+	})
+
+	var results []*EvalResult
+	if err := execRequest(console, req, &results); err != nil {
+		return "", err
+	}
+
+	if len(results) == 0 {
+		log.Infof("Connection closed")
+		os.Exit(0)
+	}
+
+	if results[0].Error != "" {
+		return renderError(console.output, errors.New(results[0].Error)), nil
+	}
+
+	return renderOutput(console.output, results[0].GetValues()), nil
+}
+
+// execRequest executes the request on the console connection.
+// Connection loss error is returned to be handled by the caller,
+// other errors are fatal
+func execRequest(console *Console, req *connector.Request, resData interface{}) error {
+	if err := console.conn.ExecTyped(req, resData); err != nil {
+		if connector.IsConnectionLost(err) {
+			return err
+		}
+
+		log.Fatalf("Failed to execute command: %s", err)
+	}
+
+	return nil
 }
 
 func inputIsCompleted(input string, luaState *lua.LState) bool {
@@ -247,7 +436,7 @@ func inputIsCompleted(input string, luaState *lua.LState) bool {
 
 func getCompleter(console *Console) prompt.Completer {
 	completer := func(in prompt.Document) []prompt.Suggest {
-		if len(in.Text) == 0 {
+		if len(in.Text) == 0 || console.language == SQLLanguage {
 			return nil
 		}
 
@@ -314,14 +503,34 @@ func setPrefix(console *Console) {
 	}
 
 	console.livePrefix = fmt.Sprintf("%s> ", strings.Repeat(" ", livePrefixIndent))
+	console.disconnectedPrefix = fmt.Sprintf("%s (disconnected)> ", console.title)
 
 	console.livePrefixFunc = func() (string, bool) {
-		return console.livePrefix, console.livePrefixEnabled
+		if console.disconnected {
+			return console.disconnectedPrefix, true
+		}
+
+		if searchPrefix, ok := getHistorySearchPrefix(console); ok {
+			return searchPrefix, true
+		}
+
+		if console.livePrefixEnabled {
+			return console.livePrefix, true
+		}
+
+		// prefix is updated on reconnect,
+		// so it's used instead of the prompt prefix set on creation
+		return console.prefix, true
 	}
 }
 
 func getPromptOptions(console *Console) []prompt.Option {
 	options := []prompt.Option{
+		prompt.OptionParser(&historySearchParser{
+			ConsoleParser: prompt.NewStandardInputParser(),
+			console:       console,
+		}),
+
 		prompt.OptionTitle(console.title),
 		prompt.OptionPrefix(console.prefix),
 		prompt.OptionLivePrefix(console.livePrefixFunc),
@@ -350,6 +559,12 @@ func getPromptOptions(console *Console) []prompt.Option {
 					buf.CursorRight(wordLen)
 				},
 			},
+			prompt.ASCIICodeBind{ // show the command found by the history search
+				ASCIICode: historySearchRefreshBytes,
+				Fn: func(buf *prompt.Buffer) {
+					refreshHistorySearchBuffer(console, buf)
+				},
+			},
 		),
 
 		prompt.OptionAddKeyBind(
@@ -366,19 +581,3 @@ func getPromptOptions(console *Console) []prompt.Option {
 
 	return options
 }
-
-func appendToHistoryFile(console *Console, in string) error {
-	if console.historyFile == nil {
-		return fmt.Errorf("No hostory file found")
-	}
-
-	if _, err := console.historyFile.WriteString(in + "\n"); err != nil {
-		return fmt.Errorf("Failed to append to history file: %s", err)
-	}
-
-	if err := console.historyFile.Sync(); err != nil {
-		return fmt.Errorf("Failed to sync history file: %s", err)
-	}
-
-	return nil
-}
@@ -0,0 +1,37 @@
+package connect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLivePrefixFunc(t *testing.T) {
+	assert := assert.New(t)
+
+	console := &Console{title: "localhost:3301"}
+	setPrefix(console)
+
+	prefix, enabled := console.livePrefixFunc()
+	assert.True(enabled)
+	assert.Equal("localhost:3301> ", prefix)
+
+	// multiline input
+	console.livePrefixEnabled = true
+	prefix, _ = console.livePrefixFunc()
+	assert.Equal("              > ", prefix)
+	console.livePrefixEnabled = false
+
+	// disconnected
+	console.disconnected = true
+	prefix, _ = console.livePrefixFunc()
+	assert.Equal("localhost:3301 (disconnected)> ", prefix)
+
+	// reconnected to the instance with another title
+	console.disconnected = false
+	console.title = "router"
+	setPrefix(console)
+
+	prefix, _ = console.livePrefixFunc()
+	assert.Equal("router> ", prefix)
+}
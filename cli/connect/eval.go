@@ -0,0 +1,160 @@
+package connect
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tarantool/cartridge-cli/cli/connector"
+	"github.com/tarantool/cartridge-cli/cli/context"
+)
+
+// evalTarget describes an instance where the code is evaluated.
+// Name is used as a prefix of the instance output lines
+// if the code is evaluated on several instances
+type evalTarget struct {
+	Name     string
+	ConnOpts *ConnOpts
+}
+
+type evalCode struct {
+	Body      string
+	ChunkName string
+}
+
+func evalIsSpecified(ctx *context.Ctx) bool {
+	return ctx.Connect.Eval != "" || ctx.Connect.EvalFile != ""
+}
+
+// runEval evaluates the whole expression or script as one chunk on each target.
+// Results are rendered in the specified output format,
+// an error is returned if evaluation failed on some target
+func runEval(targets []*evalTarget, ctx *context.Ctx) error {
+	output := ctx.Connect.Output
+	if output == "" {
+		output = YAMLOutput
+	}
+
+	if err := CheckOutput(output); err != nil {
+		return err
+	}
+
+	code, err := getEvalCode(ctx)
+	if err != nil {
+		return err
+	}
+
+	outputs := make([]string, len(targets))
+	errs := make([]error, len(targets))
+
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target *evalTarget) {
+			defer wg.Done()
+
+			var values []interface{}
+			if values, errs[i] = evalOnTarget(target, code, 0); errs[i] != nil {
+				outputs[i] = renderError(output, errs[i])
+			} else {
+				outputs[i] = renderOutput(output, values)
+			}
+		}(i, target)
+	}
+	wg.Wait()
+
+	var failedTargetsErrs []string
+	for i, target := range targets {
+		if len(targets) > 1 {
+			fmt.Print(prefixLines(outputs[i], fmt.Sprintf("%s | ", target.Name)))
+		} else {
+			fmt.Println(strings.TrimSuffix(outputs[i], "\n"))
+		}
+
+		if errs[i] != nil {
+			failedTargetsErrs = append(failedTargetsErrs, fmt.Sprintf("%s: %s", target.Name, errs[i]))
+		}
+	}
+
+	if len(failedTargetsErrs) > 0 {
+		return fmt.Errorf("Failed to evaluate code on %s", strings.Join(failedTargetsErrs, ", "))
+	}
+
+	return nil
+}
+
+func getEvalCode(ctx *context.Ctx) (*evalCode, error) {
+	if ctx.Connect.Eval != "" && ctx.Connect.EvalFile != "" {
+		return nil, fmt.Errorf("Only one of --eval and --file flags can be specified")
+	}
+
+	if ctx.Connect.Eval != "" {
+		return &evalCode{
+			Body:      ctx.Connect.Eval,
+			ChunkName: "=eval",
+		}, nil
+	}
+
+	scriptContent, err := ioutil.ReadFile(ctx.Connect.EvalFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read script file: %s", err)
+	}
+
+	return &evalCode{
+		Body:      removeShebang(string(scriptContent)),
+		ChunkName: "@" + ctx.Connect.EvalFile,
+	}, nil
+}
+
+// removeShebang replaces the shebang line with an empty one
+// to keep lines numbers in error messages
+func removeShebang(script string) string {
+	if !strings.HasPrefix(script, "#!") {
+		return script
+	}
+
+	if newLineIndex := strings.Index(script, "\n"); newLineIndex != -1 {
+		return script[newLineIndex:]
+	}
+
+	return ""
+}
+
+// evalOnTarget evaluates the code on the target and returns the values it returned.
+// Zero timeout means no timeout
+func evalOnTarget(target *evalTarget, code *evalCode, timeout time.Duration) ([]interface{}, error) {
+	conn, err := connect(target.ConnOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	req := connector.EvalReq(evalRawFuncBody, code.Body, code.ChunkName).SetReadTimeout(timeout)
+
+	var results []*EvalResult
+	if err := conn.ExecTyped(req, &results); err != nil {
+		return nil, fmt.Errorf("Failed to evaluate code: %s", err)
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("Connection was closed")
+	}
+
+	if results[0].Error != "" {
+		return nil, errors.New(results[0].Error)
+	}
+
+	return results[0].GetValues(), nil
+}
+
+func prefixLines(s string, prefix string) string {
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
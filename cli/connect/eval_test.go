@@ -0,0 +1,57 @@
+package connect
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tarantool/cartridge-cli/cli/context"
+)
+
+func TestGetEvalCode(t *testing.T) {
+	assert := assert.New(t)
+
+	var err error
+	var code *evalCode
+	var ctx context.Ctx
+
+	// expression
+	ctx.Connect.Eval = "box.info.version"
+	code, err = getEvalCode(&ctx)
+	assert.Nil(err)
+	assert.Equal(&evalCode{Body: "box.info.version", ChunkName: "=eval"}, code)
+
+	// both flags
+	ctx.Connect.EvalFile = "script.lua"
+	_, err = getEvalCode(&ctx)
+	assert.EqualError(err, "Only one of --eval and --file flags can be specified")
+
+	// script
+	dir, err := ioutil.TempDir("", "eval")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	scriptPath := filepath.Join(dir, "script.lua")
+	assert.Nil(ioutil.WriteFile(scriptPath, []byte("#!/usr/bin/env tarantool\nlocal a = 1\nreturn a\n"), 0644))
+
+	ctx.Connect.Eval = ""
+	ctx.Connect.EvalFile = scriptPath
+	code, err = getEvalCode(&ctx)
+	assert.Nil(err)
+	assert.Equal(&evalCode{Body: "\nlocal a = 1\nreturn a\n", ChunkName: "@" + scriptPath}, code)
+
+	// non-existent script
+	ctx.Connect.EvalFile = filepath.Join(dir, "unknown.lua")
+	_, err = getEvalCode(&ctx)
+	assert.Contains(err.Error(), "Failed to read script file")
+}
+
+func TestPrefixLines(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("router | ---\nrouter | - 1\nrouter | ...\n", prefixLines("---\n- 1\n...\n", "router | "))
+	assert.Equal("router | 1;\n", prefixLines("1;", "router | "))
+}
@@ -0,0 +1,215 @@
+package connect
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/tarantool/cartridge-cli/cli/common"
+	"github.com/tarantool/cartridge-cli/cli/context"
+	"github.com/tarantool/cartridge-cli/cli/project"
+)
+
+const (
+	GlobalHistoryScope   = "global"
+	AppHistoryScope      = "app"
+	InstanceHistoryScope = "instance"
+
+	historyDir = ".cartridge/history"
+)
+
+var (
+	HistoryScopes = []string{GlobalHistoryScope, AppHistoryScope, InstanceHistoryScope}
+)
+
+// getHistoryFilePath returns console history file path for the application instance.
+// The history file specified in .cartridge.yml is used if it's set,
+// otherwise the path depends on history scope:
+//
+// * global - ~/.tarantool_history (shared with the Tarantool console)
+// * app - ~/.cartridge/history/<app-name>
+// * instance - ~/.cartridge/history/<app-name>.<instance-name>
+func getHistoryFilePath(ctx *context.Ctx, instanceName string) (string, error) {
+	if ctx.Connect.HistoryFile != "" {
+		return common.ExpandHomeDir(ctx.Connect.HistoryFile)
+	}
+
+	homeDir, err := common.GetHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("Failed to get home directory: %s", err)
+	}
+
+	switch ctx.Connect.HistoryScope {
+	case GlobalHistoryScope:
+		return filepath.Join(homeDir, HistoryFileName), nil
+	case AppHistoryScope, "":
+		return filepath.Join(homeDir, historyDir, ctx.Project.Name), nil
+	case InstanceHistoryScope:
+		return filepath.Join(homeDir, historyDir, project.GetInstanceID(ctx, instanceName)), nil
+	default:
+		return "", fmt.Errorf(
+			"Unknown history scope %q. Supported scopes: %s",
+			ctx.Connect.HistoryScope, strings.Join(HistoryScopes, ", "),
+		)
+	}
+}
+
+// loadHistory reads the last unique commands from the history file
+// and opens it for appending.
+// The file is compacted if it contains much more lines than the history size
+func loadHistory(console *Console) error {
+	var err error
+
+	if console.historyFilePath == "" {
+		homeDir, err := common.GetHomeDir()
+		if err != nil {
+			return fmt.Errorf("Failed to get home directory: %s", err)
+		}
+
+		console.historyFilePath = filepath.Join(homeDir, HistoryFileName)
+	}
+
+	if console.historySize <= 0 {
+		console.historySize = MaxHistoryLines
+	}
+
+	if err := os.MkdirAll(filepath.Dir(console.historyFilePath), 0755); err != nil {
+		return fmt.Errorf("Failed to create history file directory: %s", err)
+	}
+
+	fileLines, err := readHistoryFile(console.historyFilePath)
+	if err != nil {
+		return fmt.Errorf("Failed to read history from file: %s", err)
+	}
+
+	console.historyLines = getUniqueHistoryLines(fileLines, console.historySize)
+
+	if len(fileLines) > 2*console.historySize {
+		if err := writeHistoryFile(console.historyFilePath, console.historyLines); err != nil {
+			log.Debugf("Failed to compact history file: %s", err)
+		}
+	}
+
+	// open history file for appending
+	// see https://unix.stackexchange.com/questions/346062/concurrent-writing-to-a-log-file-from-many-processes
+	console.historyFile, err = os.OpenFile(
+		console.historyFilePath,
+		os.O_APPEND|os.O_CREATE|os.O_WRONLY,
+		0644,
+	)
+
+	if err != nil {
+		log.Debugf("Failed to open history file for append: %s", err)
+	}
+
+	return nil
+}
+
+func appendToHistoryFile(console *Console, in string) error {
+	if in == "" {
+		return nil
+	}
+
+	// skip consecutive duplicates
+	if len(console.historyLines) > 0 && console.historyLines[len(console.historyLines)-1] == in {
+		return nil
+	}
+
+	console.historyLines = append(console.historyLines, in)
+
+	if console.historyFile == nil {
+		return fmt.Errorf("No history file found")
+	}
+
+	if _, err := console.historyFile.WriteString(in + "\n"); err != nil {
+		return fmt.Errorf("Failed to append to history file: %s", err)
+	}
+
+	if err := console.historyFile.Sync(); err != nil {
+		return fmt.Errorf("Failed to sync history file: %s", err)
+	}
+
+	return nil
+}
+
+func readHistoryFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var lines []string
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// writeHistoryFile replaces the history file content atomically
+func writeHistoryFile(path string, lines []string) error {
+	tmpFile, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	content := strings.Join(lines, "\n")
+	if len(lines) > 0 {
+		content += "\n"
+	}
+
+	if _, err := tmpFile.WriteString(content); err != nil {
+		tmpFile.Close()
+		return err
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tmpFile.Name(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), path)
+}
+
+// getUniqueHistoryLines returns the last `size` unique lines
+// keeping the order of their last occurrences
+func getUniqueHistoryLines(lines []string, size int) []string {
+	addedLines := make(map[string]bool)
+
+	var uniqueLines []string
+	for i := len(lines) - 1; i >= 0 && len(uniqueLines) < size; i-- {
+		if addedLines[lines[i]] {
+			continue
+		}
+
+		addedLines[lines[i]] = true
+		uniqueLines = append(uniqueLines, lines[i])
+	}
+
+	// reverse
+	for i, j := 0, len(uniqueLines)-1; i < j; i, j = i+1, j-1 {
+		uniqueLines[i], uniqueLines[j] = uniqueLines[j], uniqueLines[i]
+	}
+
+	return uniqueLines
+}
@@ -0,0 +1,219 @@
+package connect
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/c-bata/go-prompt"
+)
+
+var (
+	// ControlRBytes starts the reverse history search
+	ControlRBytes = []byte{0x12}
+
+	// historySearchRefreshBytes is sent to the prompt instead of the keys
+	// handled by the history search, so the prompt only updates the buffer.
+	// It doesn't match any terminal key sequence
+	historySearchRefreshBytes = []byte{0x1b, 0x5b, 0x3f, 0x72, 0x73}
+)
+
+// historySearch is the state of the reverse incremental history search.
+// The search is started by Ctrl-R, typed characters are added to the query
+// and repeated Ctrl-R jumps to the older match.
+// The found command is shown in the prompt buffer, so Enter executes it
+type historySearch struct {
+	mutex sync.Mutex
+
+	active bool
+	query  string
+	// matchIndex is the index of the found line in the history,
+	// it's equal to the history length if nothing is found
+	matchIndex int
+	failed     bool
+}
+
+// historySearchParser handles the history search keys before they are
+// passed to the prompt, since go-prompt handles Ctrl-R by itself
+type historySearchParser struct {
+	prompt.ConsoleParser
+
+	console *Console
+}
+
+// findHistoryMatch returns the index of the latest history line
+// that is older than the line with `before` index and contains the query.
+// -1 is returned if there is no such line
+func findHistoryMatch(lines []string, query string, before int) int {
+	if before > len(lines) {
+		before = len(lines)
+	}
+
+	for i := before - 1; i >= 0; i-- {
+		if strings.Contains(lines[i], query) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// findOlderHistoryMatch is the same as findHistoryMatch,
+// but lines equal to the line with `before` index are skipped
+func findOlderHistoryMatch(lines []string, query string, before int) int {
+	for i := findHistoryMatch(lines, query, before); i >= 0; i = findHistoryMatch(lines, query, i) {
+		if before >= len(lines) || lines[i] != lines[before] {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (search *historySearch) start(lines []string) {
+	search.active = true
+	search.query = ""
+	search.matchIndex = len(lines)
+	search.failed = false
+}
+
+func (search *historySearch) stop() {
+	search.active = false
+}
+
+// setQuery finds the latest line that contains the new query
+func (search *historySearch) setQuery(lines []string, query string) {
+	search.query = query
+
+	if query == "" {
+		search.matchIndex = len(lines)
+		search.failed = false
+		return
+	}
+
+	if i := findHistoryMatch(lines, query, len(lines)); i >= 0 {
+		search.matchIndex = i
+		search.failed = false
+	} else {
+		search.failed = true
+	}
+}
+
+// older jumps to the previous line that contains the query
+func (search *historySearch) older(lines []string) {
+	if search.query == "" {
+		return
+	}
+
+	if i := findOlderHistoryMatch(lines, search.query, search.matchIndex); i >= 0 {
+		search.matchIndex = i
+		search.failed = false
+	} else {
+		search.failed = true
+	}
+}
+
+// getMatch returns the found line
+func (search *historySearch) getMatch(lines []string) (string, bool) {
+	if search.matchIndex < 0 || search.matchIndex >= len(lines) {
+		return "", false
+	}
+
+	return lines[search.matchIndex], true
+}
+
+func (search *historySearch) getPrefix() string {
+	if search.failed {
+		return fmt.Sprintf("(failed reverse-i-search)`%s': ", search.query)
+	}
+
+	return fmt.Sprintf("(reverse-i-search)`%s': ", search.query)
+}
+
+// Read handles Ctrl-R, typed characters and Backspace while the search is active.
+// Up and Down keys stop the search and leave the found command for editing,
+// other keys stop the search and are handled by the prompt
+func (parser *historySearchParser) Read() ([]byte, error) {
+	b, err := parser.ConsoleParser.Read()
+	if err != nil || len(b) == 0 {
+		return b, err
+	}
+
+	console := parser.console
+	search := &console.historySearch
+
+	search.mutex.Lock()
+	defer search.mutex.Unlock()
+
+	key := prompt.GetKey(b)
+
+	if !search.active {
+		if key == prompt.ControlR {
+			search.start(console.historyLines)
+			return historySearchRefreshBytes, nil
+		}
+
+		return b, nil
+	}
+
+	switch {
+	case key == prompt.ControlR:
+		search.older(console.historyLines)
+	case key == prompt.Backspace || key == prompt.ControlH:
+		query := []rune(search.query)
+		if len(query) > 0 {
+			search.setQuery(console.historyLines, string(query[:len(query)-1]))
+		}
+	case key == prompt.Up || key == prompt.Down:
+		search.stop()
+	case key == prompt.NotDefined && isPrintable(string(b)):
+		search.setQuery(console.historyLines, search.query+string(b))
+	default:
+		search.stop()
+		return b, nil
+	}
+
+	return historySearchRefreshBytes, nil
+}
+
+// refreshHistorySearchBuffer replaces the buffer text with the found command
+func refreshHistorySearchBuffer(console *Console, buf *prompt.Buffer) {
+	search := &console.historySearch
+
+	search.mutex.Lock()
+	match, found := search.getMatch(console.historyLines)
+	search.mutex.Unlock()
+
+	if !found {
+		return
+	}
+
+	buf.Delete(len(buf.Document().TextAfterCursor()))
+	buf.DeleteBeforeCursor(len([]rune(buf.Document().TextBeforeCursor())))
+	buf.InsertText(match, false, true)
+}
+
+// getHistorySearchPrefix returns the prompt prefix if the search is active
+func getHistorySearchPrefix(console *Console) (string, bool) {
+	search := &console.historySearch
+
+	search.mutex.Lock()
+	defer search.mutex.Unlock()
+
+	if !search.active {
+		return "", false
+	}
+
+	return search.getPrefix(), true
+}
+
+func isPrintable(s string) bool {
+	for _, r := range s {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+
+	return s != ""
+}
@@ -0,0 +1,88 @@
+package connect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindHistoryMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	lines := []string{"box.info()", "box.cfg{}", "return 1", "box.info.status"}
+
+	assert.Equal(3, findHistoryMatch(lines, "box", len(lines)))
+	assert.Equal(1, findHistoryMatch(lines, "box", 3))
+	assert.Equal(0, findHistoryMatch(lines, "info", 3))
+	assert.Equal(-1, findHistoryMatch(lines, "info", 0))
+	assert.Equal(-1, findHistoryMatch(lines, "unknown", len(lines)))
+	assert.Equal(3, findHistoryMatch(lines, "status", 100))
+	assert.Equal(-1, findHistoryMatch(nil, "box", 0))
+
+	// lines equal to the current match are skipped
+	lines = []string{"box.info()", "box.cfg{}", "box.info()"}
+	assert.Equal(1, findOlderHistoryMatch(lines, "box", 2))
+	assert.Equal(0, findOlderHistoryMatch(lines, "box", 1))
+	assert.Equal(-1, findOlderHistoryMatch(lines, "info", 2))
+	assert.Equal(2, findOlderHistoryMatch(lines, "info", len(lines)))
+}
+
+func TestHistorySearch(t *testing.T) {
+	assert := assert.New(t)
+
+	lines := []string{"box.info()", "box.cfg{}", "return 1", "box.info.status"}
+
+	var search historySearch
+	search.start(lines)
+	assert.True(search.active)
+	assert.Equal("(reverse-i-search)`': ", search.getPrefix())
+
+	// nothing is found by the empty query
+	_, found := search.getMatch(lines)
+	assert.False(found)
+	search.older(lines)
+	_, found = search.getMatch(lines)
+	assert.False(found)
+
+	// the latest match is found on typing
+	search.setQuery(lines, "i")
+	match, found := search.getMatch(lines)
+	assert.True(found)
+	assert.Equal("box.info.status", match)
+
+	search.setQuery(lines, "inf")
+	match, _ = search.getMatch(lines)
+	assert.Equal("box.info.status", match)
+	assert.Equal("(reverse-i-search)`inf': ", search.getPrefix())
+
+	// repeated Ctrl-R jumps to the older match
+	search.older(lines)
+	match, _ = search.getMatch(lines)
+	assert.Equal("box.info()", match)
+
+	// there are no older matches, the last one is kept
+	search.older(lines)
+	match, _ = search.getMatch(lines)
+	assert.Equal("box.info()", match)
+	assert.Equal("(failed reverse-i-search)`inf': ", search.getPrefix())
+
+	// the query isn't found
+	search.setQuery(lines, "infox")
+	match, _ = search.getMatch(lines)
+	assert.Equal("box.info()", match)
+	assert.True(search.failed)
+
+	search.stop()
+	assert.False(search.active)
+}
+
+func TestIsPrintable(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(isPrintable("a"))
+	assert.True(isPrintable("box.info()"))
+	assert.True(isPrintable("тест"))
+	assert.False(isPrintable(""))
+	assert.False(isPrintable("\x1b[A"))
+	assert.False(isPrintable("\t"))
+}
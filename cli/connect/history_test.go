@@ -0,0 +1,118 @@
+package connect
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tarantool/cartridge-cli/cli/common"
+	"github.com/tarantool/cartridge-cli/cli/context"
+)
+
+func TestGetUniqueHistoryLines(t *testing.T) {
+	assert := assert.New(t)
+
+	lines := []string{"a", "b", "a", "c", "b", "d"}
+
+	assert.Equal([]string{"a", "c", "b", "d"}, getUniqueHistoryLines(lines, 10))
+	assert.Equal([]string{"b", "d"}, getUniqueHistoryLines(lines, 2))
+	assert.Len(getUniqueHistoryLines(nil, 10), 0)
+}
+
+func TestGetHistoryFilePath(t *testing.T) {
+	assert := assert.New(t)
+
+	var err error
+	var path string
+	var ctx context.Ctx
+
+	homeDir, err := common.GetHomeDir()
+	assert.Nil(err)
+
+	ctx.Project.Name = "myapp"
+
+	// app scope is used by default
+	path, err = getHistoryFilePath(&ctx, "router")
+	assert.Nil(err)
+	assert.Equal(filepath.Join(homeDir, ".cartridge", "history", "myapp"), path)
+
+	ctx.Connect.HistoryScope = InstanceHistoryScope
+	path, err = getHistoryFilePath(&ctx, "router")
+	assert.Nil(err)
+	assert.Equal(filepath.Join(homeDir, ".cartridge", "history", "myapp.router"), path)
+
+	ctx.Connect.HistoryScope = GlobalHistoryScope
+	path, err = getHistoryFilePath(&ctx, "router")
+	assert.Nil(err)
+	assert.Equal(filepath.Join(homeDir, ".tarantool_history"), path)
+
+	ctx.Connect.HistoryScope = "unknown"
+	_, err = getHistoryFilePath(&ctx, "router")
+	assert.EqualError(err, `Unknown history scope "unknown". Supported scopes: global, app, instance`)
+
+	// history file is specified
+	ctx.Connect.HistoryFile = "~/myapp_history"
+	path, err = getHistoryFilePath(&ctx, "router")
+	assert.Nil(err)
+	assert.Equal(filepath.Join(homeDir, "myapp_history"), path)
+}
+
+func TestLoadHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "history")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	historyFilePath := filepath.Join(dir, "history", "myapp")
+
+	// history file doesn't exist
+	console := &Console{
+		historyFilePath: historyFilePath,
+		historySize:     3,
+	}
+	assert.Nil(loadHistory(console))
+	assert.Len(console.historyLines, 0)
+
+	// consecutive duplicates aren't appended
+	for _, in := range []string{"a", "b", "b", "", "c", "a"} {
+		assert.Nil(appendToHistoryFile(console, in))
+	}
+	console.Close()
+
+	content, err := ioutil.ReadFile(historyFilePath)
+	assert.Nil(err)
+	assert.Equal("a\nb\nc\na\n", string(content))
+
+	// duplicates are removed on load
+	console = &Console{
+		historyFilePath: historyFilePath,
+		historySize:     3,
+	}
+	assert.Nil(loadHistory(console))
+	assert.Equal([]string{"b", "c", "a"}, console.historyLines)
+	console.Close()
+
+	// file is compacted if it's too big
+	var lines string
+	for i := 0; i < 10; i++ {
+		lines += fmt.Sprintf("line-%d\n", i)
+	}
+	assert.Nil(ioutil.WriteFile(historyFilePath, []byte(lines), 0644))
+
+	console = &Console{
+		historyFilePath: historyFilePath,
+		historySize:     3,
+	}
+	assert.Nil(loadHistory(console))
+	assert.Equal([]string{"line-7", "line-8", "line-9"}, console.historyLines)
+	console.Close()
+
+	content, err = ioutil.ReadFile(historyFilePath)
+	assert.Nil(err)
+	assert.Equal("line-7\nline-8\nline-9\n", string(content))
+}
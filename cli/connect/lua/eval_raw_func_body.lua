@@ -0,0 +1,35 @@
+local line, chunkname = ...
+
+local serializer = require('msgpack').new()
+serializer.cfg({
+    encode_use_tostring = true,
+    encode_invalid_as_nil = true,
+})
+
+local func, err = loadstring('return ' .. line, chunkname)
+if func == nil then
+    func, err = loadstring(line, chunkname)
+end
+
+if func == nil then
+    return { error = tostring(err) }
+end
+
+local function pack(ok, ...)
+    return ok, select('#', ...), { ... }
+end
+
+local ok, results_count, results = pack(pcall(func))
+if not ok then
+    return { error = tostring(results[1]) }
+end
+
+local encoded_results = setmetatable({}, { __serialize = 'seq' })
+for i = 1, results_count do
+    encoded_results[i] = serializer.decode(serializer.encode(results[i]))
+end
+
+return {
+    results = encoded_results,
+    results_count = results_count,
+}
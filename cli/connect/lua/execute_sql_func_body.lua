@@ -0,0 +1,16 @@
+local statement = ...
+
+if box.execute == nil then
+    return { error = 'SQL is not supported by this Tarantool version' }
+end
+
+local res, err = box.execute(statement)
+if err ~= nil then
+    return { error = tostring(err) }
+end
+
+if res.rows ~= nil then
+    setmetatable(res.rows, { __serialize = 'seq' })
+end
+
+return res
@@ -0,0 +1,449 @@
+package connect
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v2"
+
+	"github.com/tarantool/cartridge-cli/cli/common"
+)
+
+const (
+	YAMLOutput  = "yaml"
+	LuaOutput   = "lua"
+	JSONOutput  = "json"
+	TableOutput = "table"
+)
+
+var (
+	Outputs = []string{YAMLOutput, LuaOutput, JSONOutput, TableOutput}
+
+	luaIdentifierRgx = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// EvalResult describes the result of evaluating Lua code
+// that is rendered on the client side
+type EvalResult struct {
+	Results      []interface{} `mapstructure:"results"`
+	ResultsCount int           `mapstructure:"results_count"`
+
+	Error string `mapstructure:"error"`
+}
+
+func (evalResult *EvalResult) DecodeMsgpack(d *msgpack.Decoder) error {
+	return common.DecodeMsgpackStruct(d, evalResult)
+}
+
+// GetValues returns all values returned by the evaluated code,
+// trailing nil values are restored
+func (evalResult *EvalResult) GetValues() []interface{} {
+	values := make([]interface{}, evalResult.ResultsCount)
+	copy(values, evalResult.Results)
+
+	return values
+}
+
+// CheckOutput checks that specified console output format is supported
+func CheckOutput(output string) error {
+	if !common.StringSliceContains(Outputs, output) {
+		return fmt.Errorf("Unknown output format %q. Supported formats: %s", output, strings.Join(Outputs, ", "))
+	}
+
+	return nil
+}
+
+// parseSetOutputCommand parses `\set output <yaml|lua|json|table>` console command.
+// The second returned value is false if the input isn't a set output command
+func parseSetOutputCommand(input string) (string, bool) {
+	fields := strings.Fields(strings.TrimSuffix(strings.TrimSpace(input), ";"))
+
+	if len(fields) < 2 || fields[0] != `\set` || fields[1] != "output" {
+		return "", false
+	}
+
+	if len(fields) != 3 {
+		return "", true
+	}
+
+	return strings.ToLower(fields[2]), true
+}
+
+// renderOutput returns values formatted in the specified output format
+func renderOutput(output string, values []interface{}) string {
+	switch output {
+	case LuaOutput:
+		return renderLua(values)
+	case JSONOutput:
+		return renderJSON(values)
+	case TableOutput:
+		return renderTableOutput(values)
+	default:
+		return renderYAML(values)
+	}
+}
+
+// renderError returns an error formatted in the specified output format
+// the same way as Tarantool console does
+func renderError(output string, err error) string {
+	if output == TableOutput {
+		return fmt.Sprintf("error: %s\n", err)
+	}
+
+	return renderOutput(output, []interface{}{
+		map[string]interface{}{"error": err.Error()},
+	})
+}
+
+// YAML
+
+func renderYAML(values []interface{}) string {
+	if len(values) == 0 {
+		return "---\n...\n"
+	}
+
+	encoded, err := yaml.Marshal(common.NormalizeValue(values))
+	if err != nil {
+		encoded = []byte(fmt.Sprintf("- error: %q\n", err))
+	}
+
+	return fmt.Sprintf("---\n%s...\n", encoded)
+}
+
+// LUA
+
+func renderLua(values []interface{}) string {
+	encoded := make([]string, len(values))
+	for i, value := range values {
+		encoded[i] = formatLuaValue(value)
+	}
+
+	return strings.Join(encoded, ", ") + ";"
+}
+
+func formatLuaValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return strconv.Quote(v)
+	case []byte:
+		return strconv.Quote(string(v))
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = formatLuaValue(item)
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	case map[string]interface{}, map[interface{}]interface{}:
+		keys, items := getSortedMapItems(v)
+
+		fields := make([]string, len(keys))
+		for i, key := range keys {
+			fields[i] = fmt.Sprintf("%s = %s", formatLuaKey(key), formatLuaValue(items[i]))
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func formatLuaKey(key interface{}) string {
+	if s, ok := key.(string); ok {
+		if luaIdentifierRgx.MatchString(s) {
+			return s
+		}
+		return fmt.Sprintf("[%s]", strconv.Quote(s))
+	}
+
+	return fmt.Sprintf("[%s]", formatLuaValue(key))
+}
+
+// JSON
+
+func renderJSON(values []interface{}) string {
+	encoded, err := json.MarshalIndent(common.NormalizeValue(values), "", "  ")
+	if err != nil {
+		return renderError(YAMLOutput, fmt.Errorf("Failed to encode result to JSON: %s", err))
+	}
+
+	return string(encoded) + "\n"
+}
+
+// TABLE
+
+// renderTableOutput renders each returned value as a table.
+// Arrays of tuples are shown with columns named by field numbers,
+// arrays of maps are shown with columns named by map keys
+//
+// +---+-------+-----+
+// | 1 | 2     | 3   |
+// +---+-------+-----+
+// | 1 | Alice | 100 |
+// | 2 | Bob   | 200 |
+// +---+-------+-----+
+// (2 rows)
+func renderTableOutput(values []interface{}) string {
+	rendered := make([]string, len(values))
+	for i, value := range values {
+		rendered[i] = renderTableValue(value)
+	}
+
+	return strings.Join(rendered, "\n")
+}
+
+func renderTableValue(value interface{}) string {
+	switch v := value.(type) {
+	case []interface{}:
+		if len(v) == 0 {
+			return "(0 rows)\n"
+		}
+
+		var header []string
+		var rows [][]string
+
+		if allItemsAreMaps(v) {
+			header, rows = getMapsTable(v)
+		} else {
+			header, rows = getTuplesTable(v)
+		}
+
+		lines := drawTable(header, rows)
+		lines = append(lines, getRowsCountFooter(len(rows)))
+
+		return strings.Join(lines, "\n") + "\n"
+	case map[string]interface{}, map[interface{}]interface{}:
+		keys, items := getSortedMapItems(v)
+
+		rows := make([][]string, len(keys))
+		for i, key := range keys {
+			rows[i] = []string{formatTableCell(key), formatTableCell(items[i])}
+		}
+
+		return strings.Join(drawTable([]string{"key", "value"}, rows), "\n") + "\n"
+	default:
+		return formatTableCell(value) + "\n"
+	}
+}
+
+func allItemsAreMaps(items []interface{}) bool {
+	for _, item := range items {
+		switch item.(type) {
+		case map[string]interface{}, map[interface{}]interface{}:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func getTuplesTable(tuples []interface{}) ([]string, [][]string) {
+	columnsCount := 1
+	for _, tuple := range tuples {
+		if fields, ok := tuple.([]interface{}); ok && len(fields) > columnsCount {
+			columnsCount = len(fields)
+		}
+	}
+
+	header := make([]string, columnsCount)
+	for i := range header {
+		header[i] = strconv.Itoa(i + 1)
+	}
+
+	rows := make([][]string, len(tuples))
+	for i, tuple := range tuples {
+		rows[i] = make([]string, columnsCount)
+
+		fields, ok := tuple.([]interface{})
+		if !ok {
+			fields = []interface{}{tuple}
+		}
+
+		for j, field := range fields {
+			rows[i][j] = formatTableCell(field)
+		}
+	}
+
+	return header, rows
+}
+
+func getMapsTable(maps []interface{}) ([]string, [][]string) {
+	var header []string
+	columnsIndexes := make(map[string]int)
+
+	itemsByColumn := make([]map[string]interface{}, len(maps))
+	for i, m := range maps {
+		keys, items := getSortedMapItems(m)
+
+		itemsByColumn[i] = make(map[string]interface{})
+		for j, key := range keys {
+			column := formatTableCell(key)
+			itemsByColumn[i][column] = items[j]
+
+			if _, found := columnsIndexes[column]; !found {
+				columnsIndexes[column] = len(header)
+				header = append(header, column)
+			}
+		}
+	}
+
+	rows := make([][]string, len(maps))
+	for i := range maps {
+		rows[i] = make([]string, len(header))
+		for column, item := range itemsByColumn[i] {
+			rows[i][columnsIndexes[column]] = formatTableCell(item)
+		}
+	}
+
+	return header, rows
+}
+
+func formatTableCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case []interface{}, map[string]interface{}, map[interface{}]interface{}:
+		encoded, err := json.Marshal(common.NormalizeValue(v))
+		if err != nil {
+			return formatLuaValue(v)
+		}
+		return string(encoded)
+	default:
+		return formatLuaValue(v)
+	}
+}
+
+// drawTable returns table lines:
+// the header between separators, rows and the closing separator
+// (if there are some rows)
+func drawTable(header []string, rows [][]string) []string {
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for j, value := range row {
+			if width := utf8.RuneCountInString(value); width > widths[j] {
+				widths[j] = width
+			}
+		}
+	}
+
+	separatorParts := make([]string, len(widths))
+	for j, width := range widths {
+		separatorParts[j] = strings.Repeat("-", width+2)
+	}
+	separator := "+" + strings.Join(separatorParts, "+") + "+"
+
+	formatRow := func(row []string) string {
+		cells := make([]string, len(row))
+		for j, value := range row {
+			cells[j] = " " + value + strings.Repeat(" ", widths[j]-utf8.RuneCountInString(value)) + " "
+		}
+		return "|" + strings.Join(cells, "|") + "|"
+	}
+
+	lines := []string{separator, formatRow(header), separator}
+	for _, row := range rows {
+		lines = append(lines, formatRow(row))
+	}
+
+	if len(rows) > 0 {
+		lines = append(lines, separator)
+	}
+
+	return lines
+}
+
+func getRowsCountFooter(rowsCount int) string {
+	if rowsCount == 1 {
+		return "(1 row)"
+	}
+
+	return fmt.Sprintf("(%d rows)", rowsCount)
+}
+
+// getSortedMapItems returns map keys and corresponding values.
+// Numeric keys go first in ascending order, then other keys sorted as strings
+func getSortedMapItems(m interface{}) ([]interface{}, []interface{}) {
+	var keys []interface{}
+	itemsByKey := make(map[interface{}]interface{})
+
+	switch v := m.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			keys = append(keys, key)
+			itemsByKey[key] = item
+		}
+	case map[interface{}]interface{}:
+		for key, item := range v {
+			keys = append(keys, key)
+			itemsByKey[key] = item
+		}
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		iNum, iIsNum := getNumber(keys[i])
+		jNum, jIsNum := getNumber(keys[j])
+
+		if iIsNum && jIsNum {
+			return iNum < jNum
+		}
+
+		if iIsNum != jIsNum {
+			return iIsNum
+		}
+
+		return fmt.Sprintf("%v", keys[i]) < fmt.Sprintf("%v", keys[j])
+	})
+
+	items := make([]interface{}, len(keys))
+	for i, key := range keys {
+		items[i] = itemsByKey[key]
+	}
+
+	return keys, items
+}
+
+func getNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
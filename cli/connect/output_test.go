@@ -0,0 +1,142 @@
+package connect
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestParseSetOutputCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	var output string
+	var ok bool
+
+	output, ok = parseSetOutputCommand(`\set output table`)
+	assert.True(ok)
+	assert.Equal("table", output)
+
+	output, ok = parseSetOutputCommand(` \set  output  JSON; `)
+	assert.True(ok)
+	assert.Equal("json", output)
+
+	output, ok = parseSetOutputCommand(`\set output`)
+	assert.True(ok)
+	assert.Equal("", output)
+
+	_, ok = parseSetOutputCommand(`\set language sql`)
+	assert.False(ok)
+}
+
+func TestRenderLua(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(";", renderLua(nil))
+	assert.Equal("666;", renderLua([]interface{}{int64(666)}))
+	assert.Equal(`1.5, "str", nil, true;`, renderLua([]interface{}{1.5, "str", nil, true}))
+
+	assert.Equal(
+		`{1, "Alice", {a = 1, ["b c"] = "d"}};`,
+		renderLua([]interface{}{
+			[]interface{}{uint8(1), "Alice", map[string]interface{}{"a": int8(1), "b c": "d"}},
+		}),
+	)
+
+	assert.Equal(
+		`{[1] = "x", [3] = "z", key = "value"};`,
+		renderLua([]interface{}{
+			map[interface{}]interface{}{uint8(3): "z", "key": "value", uint8(1): "x"},
+		}),
+	)
+}
+
+func TestRenderJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("[\n  666\n]\n", renderJSON([]interface{}{int64(666)}))
+
+	assert.Equal(
+		"[\n  {\n    \"1\": \"x\",\n    \"key\": [\n      1,\n      null\n    ]\n  }\n]\n",
+		renderJSON([]interface{}{
+			map[interface{}]interface{}{uint8(1): "x", "key": []interface{}{uint8(1), nil}},
+		}),
+	)
+}
+
+func TestRenderYAML(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("---\n...\n", renderYAML(nil))
+	assert.Equal("---\n- true\n...\n", renderYAML([]interface{}{true}))
+	assert.Equal("---\n- error: some error\n...\n", renderError(YAMLOutput, fmt.Errorf("some error")))
+	assert.Equal(`{error = "some error"};`, renderError(LuaOutput, fmt.Errorf("some error")))
+	assert.Equal("error: some error\n", renderError(TableOutput, fmt.Errorf("some error")))
+}
+
+func TestRenderTableOutput(t *testing.T) {
+	assert := assert.New(t)
+
+	// tuples
+	assert.Equal(`+---+-------+-----+
+| 1 | 2     | 3   |
++---+-------+-----+
+| 1 | Alice | 100 |
+| 2 | Bob   |     |
++---+-------+-----+
+(2 rows)
+`, renderTableOutput([]interface{}{
+		[]interface{}{
+			[]interface{}{uint8(1), "Alice", uint8(100)},
+			[]interface{}{uint8(2), "Bob"},
+		},
+	}))
+
+	// maps
+	assert.Equal(`+----+-------+-----------+
+| id | name  | tags      |
++----+-------+-----------+
+| 1  | Alice | ["a","b"] |
+| 2  |       | null      |
++----+-------+-----------+
+(2 rows)
+`, renderTableOutput([]interface{}{
+		[]interface{}{
+			map[string]interface{}{"id": uint8(1), "name": "Alice", "tags": []interface{}{"a", "b"}},
+			map[string]interface{}{"id": uint8(2), "tags": nil},
+		},
+	}))
+
+	// single map
+	assert.Equal(`+--------+-------+
+| key    | value |
++--------+-------+
+| status | ok    |
++--------+-------+
+`, renderTableOutput([]interface{}{
+		map[string]interface{}{"status": "ok"},
+	}))
+
+	// scalars and empty array
+	assert.Equal("666\n\n(0 rows)\n", renderTableOutput([]interface{}{int64(666), []interface{}{}}))
+}
+
+func TestDecodeEvalResult(t *testing.T) {
+	assert := assert.New(t)
+
+	encoded, err := msgpack.Marshal([]interface{}{
+		map[string]interface{}{
+			"results":       []interface{}{1, "str"},
+			"results_count": 3,
+		},
+	})
+	assert.Nil(err)
+
+	var results []*EvalResult
+	assert.Nil(msgpack.Unmarshal(encoded, &results))
+
+	assert.Len(results, 1)
+	assert.Equal("", results[0].Error)
+	assert.Equal([]interface{}{int8(1), "str", nil}, results[0].GetValues())
+}
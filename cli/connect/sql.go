@@ -0,0 +1,154 @@
+package connect
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/tarantool/cartridge-cli/cli/common"
+)
+
+const (
+	LuaLanguage = "lua"
+	SQLLanguage = "sql"
+
+	sqlStatementDelimiter = ";"
+	sqlNullValue          = "NULL"
+)
+
+var (
+	Languages = []string{LuaLanguage, SQLLanguage}
+)
+
+type SQLColumn struct {
+	Name string `mapstructure:"name"`
+	Type string `mapstructure:"type"`
+}
+
+// SQLResult describes the result of box.execute call.
+// SELECT statements return metadata and rows,
+// other statements return the number of affected rows
+type SQLResult struct {
+	Metadata []SQLColumn     `mapstructure:"metadata"`
+	Rows     [][]interface{} `mapstructure:"rows"`
+
+	RowCount         *int          `mapstructure:"row_count"`
+	AutoincrementIDs []interface{} `mapstructure:"autoincrement_ids"`
+
+	Error string `mapstructure:"error"`
+}
+
+func (sqlResult *SQLResult) DecodeMsgpack(d *msgpack.Decoder) error {
+	return common.DecodeMsgpackStruct(d, sqlResult)
+}
+
+// CheckLanguage checks that specified console language is supported
+func CheckLanguage(language string) error {
+	if !common.StringSliceContains(Languages, language) {
+		return fmt.Errorf("Unknown language %q. Supported languages: %s", language, strings.Join(Languages, ", "))
+	}
+
+	return nil
+}
+
+// parseSetLanguageCommand parses `\set language <lua|sql>` console command.
+// The second returned value is false if the input isn't a set language command
+func parseSetLanguageCommand(input string) (string, bool) {
+	fields := strings.Fields(strings.TrimSuffix(strings.TrimSpace(input), sqlStatementDelimiter))
+
+	if len(fields) < 2 || fields[0] != `\set` || fields[1] != "language" {
+		return "", false
+	}
+
+	if len(fields) != 3 {
+		return "", true
+	}
+
+	return strings.ToLower(fields[2]), true
+}
+
+// sqlInputIsCompleted checks if the SQL statement is completed.
+// Statement should end with a delimiter,
+// console commands (e.g. `\set language lua`) are completed at the end of line
+func sqlInputIsCompleted(input string) bool {
+	input = strings.TrimSpace(input)
+
+	return input == "" || strings.HasPrefix(input, `\`) || strings.HasSuffix(input, sqlStatementDelimiter)
+}
+
+// renderSQLResult returns the result of SQL statement as a table.
+// Errors are formatted according to the console output format
+//
+// +----+-------+
+// | ID | NAME  |
+// +----+-------+
+// | 1  | Alice |
+// +----+-------+
+// (1 row)
+func renderSQLResult(sqlResult *SQLResult, output string) string {
+	if sqlResult.Error != "" {
+		return renderError(output, errors.New(sqlResult.Error))
+	}
+
+	if sqlResult.Metadata == nil {
+		rowCount := 0
+		if sqlResult.RowCount != nil {
+			rowCount = *sqlResult.RowCount
+		}
+
+		lines := []string{fmt.Sprintf("Rows affected: %d", rowCount)}
+
+		if len(sqlResult.AutoincrementIDs) > 0 {
+			ids := make([]string, len(sqlResult.AutoincrementIDs))
+			for i, id := range sqlResult.AutoincrementIDs {
+				ids[i] = formatSQLValue(id)
+			}
+
+			lines = append(lines, fmt.Sprintf("Autoincrement IDs: %s", strings.Join(ids, ", ")))
+		}
+
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	header := make([]string, len(sqlResult.Metadata))
+	for i, column := range sqlResult.Metadata {
+		header[i] = column.Name
+	}
+
+	rows := make([][]string, len(sqlResult.Rows))
+	for i, row := range sqlResult.Rows {
+		rows[i] = make([]string, len(header))
+		for j := range header {
+			if j < len(row) {
+				rows[i][j] = formatSQLValue(row[j])
+			} else {
+				rows[i][j] = sqlNullValue
+			}
+		}
+	}
+
+	lines := drawTable(header, rows)
+	lines = append(lines, getRowsCountFooter(len(rows)))
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func formatSQLValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return sqlNullValue
+	case string:
+		return v
+	case []byte:
+		return fmt.Sprintf("X'%X'", v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,124 @@
+package connect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestParseSetLanguageCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	var language string
+	var ok bool
+
+	language, ok = parseSetLanguageCommand(`\set language sql`)
+	assert.True(ok)
+	assert.Equal("sql", language)
+
+	language, ok = parseSetLanguageCommand(`  \set   language   LUA;  `)
+	assert.True(ok)
+	assert.Equal("lua", language)
+
+	language, ok = parseSetLanguageCommand(`\set language`)
+	assert.True(ok)
+	assert.Equal("", language)
+
+	_, ok = parseSetLanguageCommand(`\set output lua`)
+	assert.False(ok)
+
+	_, ok = parseSetLanguageCommand(`return 'set language sql'`)
+	assert.False(ok)
+}
+
+func TestSQLInputIsCompleted(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(sqlInputIsCompleted(""))
+	assert.True(sqlInputIsCompleted("SELECT 1; "))
+	assert.True(sqlInputIsCompleted(`\set language lua `))
+	assert.True(sqlInputIsCompleted("SELECT * FROM t WHERE id = 1 AND name = 'x'; "))
+
+	assert.False(sqlInputIsCompleted("SELECT 1 "))
+	assert.False(sqlInputIsCompleted("SELECT * FROM t WHERE "))
+}
+
+func TestRenderSQLResult(t *testing.T) {
+	assert := assert.New(t)
+
+	var sqlResult *SQLResult
+
+	// select
+	sqlResult = &SQLResult{
+		Metadata: []SQLColumn{
+			{Name: "ID", Type: "integer"},
+			{Name: "NAME", Type: "string"},
+			{Name: "SCORE", Type: "number"},
+		},
+		Rows: [][]interface{}{
+			{int8(1), "Alice", 1.5},
+			{int8(22), "Bob", nil},
+		},
+	}
+
+	assert.Equal(`+----+-------+-------+
+| ID | NAME  | SCORE |
++----+-------+-------+
+| 1  | Alice | 1.5   |
+| 22 | Bob   | NULL  |
++----+-------+-------+
+(2 rows)
+`, renderSQLResult(sqlResult, YAMLOutput))
+
+	// single row
+	sqlResult.Rows = sqlResult.Rows[:1]
+	assert.Contains(renderSQLResult(sqlResult, YAMLOutput), "(1 row)")
+
+	// no rows
+	sqlResult.Rows = [][]interface{}{}
+	assert.Equal(`+----+------+-------+
+| ID | NAME | SCORE |
++----+------+-------+
+(0 rows)
+`, renderSQLResult(sqlResult, YAMLOutput))
+
+	// insert
+	rowCount := 2
+	sqlResult = &SQLResult{
+		RowCount:         &rowCount,
+		AutoincrementIDs: []interface{}{uint8(1), uint8(2)},
+	}
+	assert.Equal("Rows affected: 2\nAutoincrement IDs: 1, 2\n", renderSQLResult(sqlResult, YAMLOutput))
+
+	// error
+	sqlResult = &SQLResult{
+		Error: "Space 'T' does not exist",
+	}
+	assert.Equal("---\n- error: Space 'T' does not exist\n...\n", renderSQLResult(sqlResult, YAMLOutput))
+}
+
+func TestDecodeSQLResult(t *testing.T) {
+	assert := assert.New(t)
+
+	encoded, err := msgpack.Marshal([]interface{}{
+		map[string]interface{}{
+			"metadata": []interface{}{
+				map[string]interface{}{"name": "ID", "type": "integer"},
+			},
+			"rows": []interface{}{
+				[]interface{}{1},
+				[]interface{}{2},
+			},
+		},
+	})
+	assert.Nil(err)
+
+	var results []*SQLResult
+	assert.Nil(msgpack.Unmarshal(encoded, &results))
+
+	assert.Len(results, 1)
+	assert.Equal([]SQLColumn{{Name: "ID", Type: "integer"}}, results[0].Metadata)
+	assert.Len(results[0].Rows, 2)
+	assert.Nil(results[0].RowCount)
+}
@@ -3,30 +3,60 @@ package connector
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/FZambia/tarantool"
 )
 
-func connectBinary(connOpts *ConnOpts) (*tarantool.Connection, error) {
+func connectBinary(ctx context.Context, connOpts *ConnOpts) (*tarantool.Connection, error) {
 	connectStr := fmt.Sprintf("%s://%s", connOpts.Network, connOpts.Address)
 
-	binaryConn, err := tarantool.Connect(connectStr, tarantool.Opts{
+	tarantoolOpts := tarantool.Opts{
 		User:           connOpts.Username,
 		Password:       connOpts.Password,
 		SkipSchema:     true, // see https://github.com/FZambia/tarantool/issues/3
 		RequestTimeout: 0,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("Failed to connect: %s", err)
 	}
 
-	return binaryConn, nil
+	if deadline, ok := ctx.Deadline(); ok {
+		if tarantoolOpts.ConnectTimeout = time.Until(deadline); tarantoolOpts.ConnectTimeout <= 0 {
+			return nil, ctx.Err()
+		}
+	}
+
+	type connectResult struct {
+		binaryConn *tarantool.Connection
+		err        error
+	}
+
+	// the driver doesn't accept the context, so connecting
+	// is performed in background to be interrupted
+	resCh := make(chan connectResult, 1)
+	go func() {
+		binaryConn, err := tarantool.Connect(connectStr, tarantoolOpts)
+		resCh <- connectResult{binaryConn, err}
+	}()
+
+	select {
+	case res := <-resCh:
+		if res.err != nil {
+			return nil, fmt.Errorf("Failed to connect: %s", res.err)
+		}
+		return res.binaryConn, nil
+	case <-ctx.Done():
+		go func() {
+			if res := <-resCh; res.err == nil {
+				res.binaryConn.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
 }
 
-func initBinaryConn(conn *Conn, connOpts *ConnOpts) error {
+func initBinaryConn(ctx context.Context, conn *Conn, connOpts *ConnOpts) error {
 	var err error
 
-	if conn.binary, err = connectBinary(connOpts); err != nil {
+	if conn.binary, err = connectBinary(ctx, connOpts); err != nil {
 		return err
 	}
 
@@ -53,7 +83,11 @@ func processTarantoolReqBinary(conn *Conn, req *tarantool.Request, execOpts Exec
 		})
 	}
 
-	ctx := context.Background()
+	ctx := execOpts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	var cancel context.CancelFunc
 
 	if execOpts.ReadTimeout != 0 {
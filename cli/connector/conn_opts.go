@@ -1,12 +1,17 @@
 package connector
 
 import (
+	"fmt"
+	"net/url"
 	"strings"
 )
 
 const (
 	TCPNetwork  = "tcp"
 	UnixNetwork = "unix"
+
+	PlainTransport = "plain"
+	SSLTransport   = "ssl"
 )
 
 type ConnOpts struct {
@@ -14,12 +19,19 @@ type ConnOpts struct {
 	Address  string
 	Username string
 	Password string
+
+	Transport string
+	SSL       SSLOpts
 }
 
-func getConnOpts(connString, username, password string) *ConnOpts {
+// GetConnOpts parses connection string.
+// Transport and SSL options can be specified as URI parameters,
+// e.g. tcp://localhost:3301?transport=ssl&ssl_ca_file=ca.crt.
+// Values specified in opts have priority over the URI parameters
+func GetConnOpts(connString string, opts Opts) (*ConnOpts, error) {
 	connOpts := ConnOpts{
-		Username: username,
-		Password: password,
+		Username: opts.Username,
+		Password: opts.Password,
 	}
 
 	connStringParts := strings.SplitN(connString, "@", 2)
@@ -37,6 +49,18 @@ func getConnOpts(connString, username, password string) *ConnOpts {
 		}
 	}
 
+	addressParts := strings.SplitN(address, "?", 2)
+	address = addressParts[0]
+
+	var params string
+	if len(addressParts) > 1 {
+		params = addressParts[1]
+	}
+
+	if err := setTransportOpts(&connOpts, params, opts); err != nil {
+		return nil, err
+	}
+
 	addrLen := len(address)
 	switch {
 	case addrLen > 0 && (address[0] == '.' || address[0] == '/'):
@@ -62,5 +86,70 @@ func getConnOpts(connString, username, password string) *ConnOpts {
 		connOpts.Address = address
 	}
 
-	return &connOpts
+	return &connOpts, nil
+}
+
+// setTransportOpts sets transport and SSL options from opts and URI parameters.
+// Unknown parameters are ignored, except the ones that start with `ssl_`
+func setTransportOpts(connOpts *ConnOpts, params string, opts Opts) error {
+	connOpts.Transport = opts.Transport
+	connOpts.SSL = opts.SSL
+
+	parsedParams, err := url.ParseQuery(params)
+	if err != nil {
+		return fmt.Errorf("Failed to parse connection string parameters: %s", err)
+	}
+
+	sslParams := map[string]*string{
+		"ssl_key_file":    &connOpts.SSL.KeyFile,
+		"ssl_cert_file":   &connOpts.SSL.CertFile,
+		"ssl_ca_file":     &connOpts.SSL.CaFile,
+		"ssl_ciphers":     &connOpts.SSL.Ciphers,
+		"ssl_server_name": &connOpts.SSL.ServerName,
+	}
+
+	for paramName, values := range parsedParams {
+		value := values[len(values)-1]
+
+		if paramName == "transport" {
+			if connOpts.Transport == "" {
+				connOpts.Transport = value
+			}
+			continue
+		}
+
+		if sslParam, found := sslParams[paramName]; found {
+			if *sslParam == "" {
+				*sslParam = value
+			}
+			continue
+		}
+
+		if strings.HasPrefix(paramName, "ssl_") {
+			return fmt.Errorf("Unknown SSL parameter: %s", paramName)
+		}
+	}
+
+	sslOptsAreSpecified := connOpts.SSL != SSLOpts{}
+
+	if connOpts.Transport == "" {
+		if sslOptsAreSpecified {
+			connOpts.Transport = SSLTransport
+		} else {
+			connOpts.Transport = PlainTransport
+		}
+	}
+
+	switch connOpts.Transport {
+	case PlainTransport:
+		if sslOptsAreSpecified {
+			return fmt.Errorf("SSL options can't be used with %s transport", PlainTransport)
+		}
+	case SSLTransport:
+	default:
+		return fmt.Errorf("Unknown transport %q. Supported transports: %s, %s",
+			connOpts.Transport, PlainTransport, SSLTransport)
+	}
+
+	return nil
 }
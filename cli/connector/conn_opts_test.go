@@ -0,0 +1,82 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetConnOpts(t *testing.T) {
+	assert := assert.New(t)
+
+	var err error
+	var connOpts *ConnOpts
+
+	// plain
+	connOpts, err = GetConnOpts("admin:secret@localhost:3301", Opts{})
+	assert.Nil(err)
+	assert.Equal(&ConnOpts{
+		Network:   TCPNetwork,
+		Address:   "localhost:3301",
+		Username:  "admin",
+		Password:  "secret",
+		Transport: PlainTransport,
+	}, connOpts)
+
+	connOpts, err = GetConnOpts("unix:///var/run/app.sock", Opts{})
+	assert.Nil(err)
+	assert.Equal(UnixNetwork, connOpts.Network)
+	assert.Equal("/var/run/app.sock", connOpts.Address)
+	assert.Equal(PlainTransport, connOpts.Transport)
+
+	// SSL parameters
+	connOpts, err = GetConnOpts(
+		"tcp://localhost:3301?transport=ssl&ssl_ca_file=ca.crt&ssl_cert_file=client.crt&ssl_key_file=client.key"+
+			"&ssl_ciphers=TLS_AES_128_GCM_SHA256&ssl_server_name=tarantool&connect_timeout=5s",
+		Opts{},
+	)
+	assert.Nil(err)
+	assert.Equal(&ConnOpts{
+		Network:   TCPNetwork,
+		Address:   "localhost:3301",
+		Transport: SSLTransport,
+		SSL: SSLOpts{
+			CaFile:     "ca.crt",
+			CertFile:   "client.crt",
+			KeyFile:    "client.key",
+			Ciphers:    "TLS_AES_128_GCM_SHA256",
+			ServerName: "tarantool",
+		},
+	}, connOpts)
+
+	// SSL transport is used if some SSL option is specified
+	connOpts, err = GetConnOpts("localhost:3301", Opts{SSL: SSLOpts{CaFile: "ca.crt"}})
+	assert.Nil(err)
+	assert.Equal(SSLTransport, connOpts.Transport)
+
+	// options have priority over the URI parameters
+	connOpts, err = GetConnOpts("localhost:3301?ssl_ca_file=uri-ca.crt", Opts{SSL: SSLOpts{CaFile: "ca.crt"}})
+	assert.Nil(err)
+	assert.Equal("ca.crt", connOpts.SSL.CaFile)
+
+	// errors
+	_, err = GetConnOpts("localhost:3301?transport=tls", Opts{})
+	assert.EqualError(err, `Unknown transport "tls". Supported transports: plain, ssl`)
+
+	_, err = GetConnOpts("localhost:3301?transport=plain&ssl_ca_file=ca.crt", Opts{})
+	assert.EqualError(err, "SSL options can't be used with plain transport")
+
+	_, err = GetConnOpts("localhost:3301?transport=ssl&ssl_ca_fle=ca.crt", Opts{})
+	assert.EqualError(err, "Unknown SSL parameter: ssl_ca_fle")
+}
+
+func TestGetCipherSuites(t *testing.T) {
+	assert := assert.New(t)
+
+	cipherSuites, err := getCipherSuites("TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256:TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384")
+	assert.Nil(err)
+	assert.Len(cipherSuites, 2)
+
+	_, err = getCipherSuites("ECDHE-RSA-AES128-GCM-SHA256")
+	assert.EqualError(err, "Unknown SSL cipher: ECDHE-RSA-AES128-GCM-SHA256")
+}
@@ -1,6 +1,7 @@
 package connector
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
@@ -19,6 +20,8 @@ type Conn struct {
 	plainText net.Conn
 	binary    *tarantool.Connection
 
+	tlsProxy *TLSProxy
+
 	evalFunc func(conn *Conn, funcBody string, args []interface{}, execOpts ExecOpts) ([]interface{}, error)
 	callFunc func(conn *Conn, funcName string, args []interface{}, execOpts ExecOpts) ([]interface{}, error)
 }
@@ -26,9 +29,13 @@ type Conn struct {
 type Opts struct {
 	Username string
 	Password string
+
+	Transport string
+	SSL       SSLOpts
 }
 
 type ExecOpts struct {
+	Context      context.Context
 	PushCallback func(interface{})
 	ReadTimeout  time.Duration
 	ResData      interface{}
@@ -42,37 +49,49 @@ const (
 	BinaryProtocol    Protocol = "binary"
 
 	SimpleOperationTimeout = 3 * time.Second
+
+	// size of sockaddr_un.sun_path
+	maxUnixSocketAddressLen = 108
 )
 
 func Connect(connString string, opts Opts) (*Conn, error) {
+	return ConnectContext(context.Background(), connString, opts)
+}
+
+// ConnectContext is the same as Connect, but connecting (dial, reading greeting
+// and authentication) is interrupted when the context is done.
+// The context doesn't affect the connection after it's established,
+// use Request.SetContext to cancel requests
+func ConnectContext(ctx context.Context, connString string, opts Opts) (*Conn, error) {
 	var err error
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	conn := &Conn{}
 
-	connOpts := getConnOpts(connString, opts.Username, opts.Password)
+	connOpts, err := GetConnOpts(connString, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse connection string: %s", err)
+	}
 
 	if _, err := os.Stat(connOpts.Address); err == nil {
-		workDir, err := os.Getwd()
-		if err != nil {
+		if connOpts.Address, err = getUnixSocketAddress(connOpts.Address); err != nil {
 			return nil, err
 		}
-		os.Chdir(filepath.Dir(connOpts.Address))
-		connOpts.Address = "./" + filepath.Base(connOpts.Address)
-		defer os.Chdir(workDir)
-		if len(connOpts.Address) > 108 {
-			return nil, fmt.Errorf("Address is exceeding sun_path limit.(108 bytes)")
-		}
 	}
 
 	// connect to specified address
-	plainTextConn, err := net.Dial(connOpts.Network, connOpts.Address)
+	plainTextConn, err := dial(ctx, connOpts)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to dial: %s", err)
+		return nil, err
 	}
 
 	// detect protocol
-	conn.protocol, err = getProtocol(plainTextConn)
+	conn.protocol, err = getProtocol(ctx, plainTextConn)
 	if err != nil {
+		plainTextConn.Close()
 		return nil, fmt.Errorf("Failed to get protocol: %s", err)
 	}
 
@@ -83,7 +102,25 @@ func Connect(connString string, opts Opts) (*Conn, error) {
 			return nil, err
 		}
 	case BinaryProtocol:
-		if err := initBinaryConn(conn, connOpts); err != nil {
+		if connOpts.Transport == SSLTransport {
+			// binary protocol driver dials the connection itself,
+			// so SSL connection is established via the local proxy
+			plainTextConn.Close()
+
+			if conn.tlsProxy, err = NewTLSProxy(connOpts); err != nil {
+				return nil, err
+			}
+
+			proxyConnOpts := *connOpts
+			proxyConnOpts.Network = UnixNetwork
+			proxyConnOpts.Address = conn.tlsProxy.listener.Addr().String()
+			connOpts = &proxyConnOpts
+		}
+
+		if err := initBinaryConn(ctx, conn, connOpts); err != nil {
+			if conn.tlsProxy != nil {
+				conn.tlsProxy.Close()
+			}
 			return nil, err
 		}
 	default:
@@ -106,14 +143,66 @@ func (conn *Conn) Close() error {
 	case PlainTextProtocol:
 		return conn.plainText.Close()
 	case BinaryProtocol:
+		if conn.tlsProxy != nil {
+			defer conn.tlsProxy.Close()
+		}
 		return conn.binary.Close()
 	default:
 		return fmt.Errorf("Unsupported protocol: %s", conn.protocol)
 	}
 }
 
-func getProtocol(conn net.Conn) (Protocol, error) {
-	greeting, err := readGreeting(conn)
+// getUnixSocketAddress returns the unix socket address that fits sun_path.
+// The path relative to the working directory is used if the specified one
+// is too long. The working directory isn't changed, so it's safe to connect
+// to several sockets concurrently
+func getUnixSocketAddress(address string) (string, error) {
+	if len(address) <= maxUnixSocketAddressLen {
+		return address, nil
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	absAddress, err := filepath.Abs(address)
+	if err != nil {
+		return "", err
+	}
+
+	relAddress, err := filepath.Rel(workDir, absAddress)
+	if err != nil || len(relAddress) > maxUnixSocketAddressLen {
+		return "", fmt.Errorf("Address is exceeding sun_path limit.(%d bytes)", maxUnixSocketAddressLen)
+	}
+
+	return relAddress, nil
+}
+
+func dial(ctx context.Context, connOpts *ConnOpts) (net.Conn, error) {
+	if connOpts.Transport == SSLTransport {
+		tlsConfig, err := getTLSConfig(connOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		return dialTLS(ctx, connOpts, tlsConfig)
+	}
+
+	var dialer net.Dialer
+	plainTextConn, err := dialer.DialContext(ctx, connOpts.Network, connOpts.Address)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("Failed to dial: %s", err)
+	}
+
+	return plainTextConn, nil
+}
+
+func getProtocol(ctx context.Context, conn net.Conn) (Protocol, error) {
+	greeting, err := readGreeting(ctx, conn)
 	if err != nil {
 		return "", fmt.Errorf("Failed to read Tarantool greeting: %s", err)
 	}
@@ -128,13 +217,46 @@ func getProtocol(conn net.Conn) (Protocol, error) {
 	}
 }
 
-func readGreeting(conn net.Conn) (string, error) {
+func readGreeting(ctx context.Context, conn net.Conn) (string, error) {
 	conn.SetReadDeadline(time.Now().Add(SimpleOperationTimeout))
 
+	stopInterrupt := interruptOnDone(ctx, conn)
+	defer stopInterrupt()
+
 	greeting := make([]byte, greetingSize)
 	if _, err := conn.Read(greeting); err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
 		return "", fmt.Errorf("Failed to read Tarantool greeting: %s", err)
 	}
 
 	return string(greeting), nil
 }
+
+// interruptOnDone interrupts blocked reads and writes on the connection
+// when the context is done. Returned function should be called
+// to stop watching the context
+func interruptOnDone(ctx context.Context, conn net.Conn) func() {
+	if ctx.Done() == nil {
+		return func() {}
+	}
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		select {
+		case <-ctx.Done():
+			conn.SetDeadline(time.Now())
+		case <-stop:
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-stopped
+	}
+}
@@ -0,0 +1,132 @@
+package connector
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectContext(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "connector")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	// server accepts connections, but doesn't send greeting
+	sockPath := filepath.Join(dir, "instance.control")
+	listener, err := net.Listen("unix", sockPath)
+	assert.Nil(err)
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	// deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	startTime := time.Now()
+	_, err = ConnectContext(ctx, sockPath, Opts{})
+	assert.True(time.Since(startTime) < SimpleOperationTimeout)
+	assert.Contains(err.Error(), context.DeadlineExceeded.Error())
+
+	// cancel
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	startTime = time.Now()
+	_, err = ConnectContext(ctx, sockPath, Opts{})
+	assert.True(time.Since(startTime) < SimpleOperationTimeout)
+	assert.Contains(err.Error(), context.Canceled.Error())
+
+	// already canceled
+	_, err = ConnectContext(ctx, sockPath, Opts{})
+	assert.Contains(err.Error(), context.Canceled.Error())
+}
+
+func TestRequestContext(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "connector")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	sockPath := filepath.Join(dir, "instance.control")
+	server, err := startMockConsoleServer(sockPath, 300*time.Millisecond)
+	assert.Nil(err)
+	defer server.listener.Close()
+
+	conn, err := Connect(sockPath, Opts{})
+	assert.Nil(err)
+	defer conn.Close()
+
+	// request without context
+	data, err := conn.Exec(EvalReq("return true"))
+	assert.Nil(err)
+	assert.Equal([]interface{}{true}, data)
+
+	// context is longer than request
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var res []interface{}
+	assert.Nil(conn.ExecTyped(EvalReq("return true").SetContext(ctx), &res))
+	assert.Equal([]interface{}{true}, res)
+
+	// deadline
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = conn.Exec(EvalReq("return true").SetContext(ctx))
+	assert.Equal(context.DeadlineExceeded, err)
+
+	// cancel
+	conn, err = Connect(sockPath, Opts{})
+	assert.Nil(err)
+	defer conn.Close()
+
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err = conn.Exec(CallReq("box.info").SetContext(ctx))
+	assert.Equal(context.Canceled, err)
+}
+
+func TestGetUnixSocketAddress(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	workDir, err := os.Getwd()
+	assert.Nil(err)
+
+	// short address is used as is
+	address, err := getUnixSocketAddress("/tmp/instance.control")
+	assert.Nil(err)
+	assert.Equal("/tmp/instance.control", address)
+
+	// long address is replaced with the relative one
+	relAddress := filepath.Join("tmp", "run", "instance.control")
+	longAddress := workDir + strings.Repeat("/.", 60) + "/" + relAddress
+	address, err = getUnixSocketAddress(longAddress)
+	assert.Nil(err)
+	assert.Equal(relAddress, address)
+
+	// relative address is too long too
+	_, err = getUnixSocketAddress(filepath.Join(workDir, strings.Repeat("x", 110), "instance.control"))
+	assert.EqualError(err, "Address is exceeding sun_path limit.(108 bytes)")
+}
@@ -0,0 +1,31 @@
+package connector
+
+import (
+	"errors"
+	"io"
+	"net"
+	"syscall"
+
+	"github.com/FZambia/tarantool"
+)
+
+// IsConnectionLost returns true if the request failed
+// because the connection to the instance was closed or reset.
+// It happens, for example, when the instance is restarted
+func IsConnectionLost(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var clientErr tarantool.ClientError
+	if errors.As(err, &clientErr) {
+		return clientErr.Code == tarantool.ErrConnectionClosed || clientErr.Code == tarantool.ErrConnectionNotReady
+	}
+
+	return false
+}
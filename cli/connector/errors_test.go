@@ -0,0 +1,51 @@
+package connector
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/FZambia/tarantool"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsConnectionLost(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(IsConnectionLost(nil))
+	assert.False(IsConnectionLost(fmt.Errorf("Some error")))
+
+	assert.True(IsConnectionLost(io.EOF))
+	assert.True(IsConnectionLost(fmt.Errorf("Failed to read: %w", io.EOF)))
+	assert.False(IsConnectionLost(fmt.Errorf("Failed to read: %s", io.EOF)))
+
+	assert.True(IsConnectionLost(tarantool.ClientError{Code: tarantool.ErrConnectionClosed}))
+	assert.True(IsConnectionLost(tarantool.ClientError{Code: tarantool.ErrConnectionNotReady}))
+	assert.False(IsConnectionLost(tarantool.ClientError{Code: tarantool.ErrTimedOut}))
+}
+
+func TestIsConnectionLostPlainText(t *testing.T) {
+	assert := assert.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(err)
+	defer listener.Close()
+
+	clientConn, err := net.Dial("tcp", listener.Addr().String())
+	assert.Nil(err)
+
+	serverConn, err := listener.Accept()
+	assert.Nil(err)
+
+	// connection is closed by the server
+	serverConn.Close()
+	_, err = readFromPlainTextConn(clientConn, EvalPlainTextOpts{ReadTimeout: time.Second})
+	assert.True(IsConnectionLost(err))
+
+	// connection is closed by the client
+	clientConn.Close()
+	err = writeToPlainTextConn(clientConn, "return 1\n")
+	assert.True(IsConnectionLost(err))
+}
@@ -67,7 +67,7 @@ func evalPlainTextConn(conn net.Conn, funcBody string, args []interface{}, opts
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("Failed to check returned data: %s", err)
+		return nil, fmt.Errorf("Failed to check returned data: %w", err)
 	}
 
 	data, err := processEvalTarantoolRes(resBytes, opts.ResData)
@@ -104,7 +104,7 @@ func formatAndSendEvalFunc(conn net.Conn, funcBody string, args []interface{}, e
 
 	// write to socket
 	if err := writeToPlainTextConn(conn, evalFuncFormatted); err != nil {
-		return fmt.Errorf("Failed to send eval function to socket: %s", err)
+		return fmt.Errorf("Failed to send eval function to socket: %w", err)
 	}
 
 	return nil
@@ -113,11 +113,11 @@ func formatAndSendEvalFunc(conn net.Conn, funcBody string, args []interface{}, e
 func writeToPlainTextConn(conn net.Conn, data string) error {
 	writer := bufio.NewWriter(conn)
 	if _, err := writer.WriteString(data); err != nil {
-		return fmt.Errorf("Failed to send to socket: %s", err)
+		return fmt.Errorf("Failed to send to socket: %w", err)
 	}
 
 	if err := writer.Flush(); err != nil {
-		return fmt.Errorf("Failed to flush: %s", err)
+		return fmt.Errorf("Failed to flush: %w", err)
 	}
 
 	return nil
@@ -176,7 +176,7 @@ func readFromPlainTextConn(conn net.Conn, opts EvalPlainTextOpts) ([]byte, error
 		}
 
 		if err != nil {
-			return nil, fmt.Errorf("Failed to read from instance socket: %s", err)
+			return nil, fmt.Errorf("Failed to read from instance socket: %w", err)
 		}
 
 		dataPortion := string(dataPortionBytes)
@@ -228,7 +228,7 @@ func readDataPortionFromPlainTextConn(conn net.Conn, buffer *bytes.Buffer, readT
 
 		if buffer.Len() == 0 {
 			if n, err := conn.Read(tmp); err != nil && err != io.EOF {
-				return nil, fmt.Errorf("Failed to read: %s", err)
+				return nil, fmt.Errorf("Failed to read: %w", err)
 			} else if n == 0 || err == io.EOF {
 				return nil, io.EOF
 			} else {
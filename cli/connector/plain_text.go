@@ -14,13 +14,42 @@ func initPlainTextConn(conn *Conn, plainTextConn net.Conn) error {
 }
 
 func evalPlainText(conn *Conn, funcBody string, args []interface{}, execOpts ExecOpts) ([]interface{}, error) {
-	evalPlainTextOpts := getEvalPlainTextOpts(execOpts)
-	return evalPlainTextConn(conn.plainText, funcBody, args, evalPlainTextOpts)
+	return execPlainTextWithContext(conn, execOpts, func(evalPlainTextOpts EvalPlainTextOpts) ([]interface{}, error) {
+		return evalPlainTextConn(conn.plainText, funcBody, args, evalPlainTextOpts)
+	})
 }
 
 func callPlainText(conn *Conn, funcName string, args []interface{}, execOpts ExecOpts) ([]interface{}, error) {
+	return execPlainTextWithContext(conn, execOpts, func(evalPlainTextOpts EvalPlainTextOpts) ([]interface{}, error) {
+		return callPlainTextConn(conn.plainText, funcName, args, evalPlainTextOpts)
+	})
+}
+
+// execPlainTextWithContext applies the request context:
+// blocked reads and writes are interrupted when the context is done
+func execPlainTextWithContext(conn *Conn, execOpts ExecOpts,
+	execFunc func(EvalPlainTextOpts) ([]interface{}, error)) ([]interface{}, error) {
+
 	evalPlainTextOpts := getEvalPlainTextOpts(execOpts)
-	return callPlainTextConn(conn.plainText, funcName, args, evalPlainTextOpts)
+
+	ctx := execOpts.Context
+	if ctx == nil {
+		return execFunc(evalPlainTextOpts)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	stopInterrupt := interruptOnDone(ctx, conn.plainText)
+	defer stopInterrupt()
+
+	data, err := execFunc(evalPlainTextOpts)
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	return data, err
 }
 
 func getEvalPlainTextOpts(execOpts ExecOpts) EvalPlainTextOpts {
@@ -0,0 +1,256 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	DefaultMaxConnsPerAddress = 8
+)
+
+type PoolOpts struct {
+	Opts
+
+	// MaxConnsPerAddress is the maximum number of connections
+	// opened to one address. Requests to the same address
+	// are executed concurrently via different connections
+	MaxConnsPerAddress int
+}
+
+// Pool keeps connections per address and allows to execute
+// requests concurrently.
+// Each connection executes one request at a time, so the connections
+// that use plain text protocol are never shared between requests.
+type Pool struct {
+	opts PoolOpts
+
+	mutex     sync.Mutex
+	addresses map[string]*addressPool
+	closed    bool
+}
+
+type addressPool struct {
+	connString string
+
+	// idle contains opened connections that aren't used now
+	idle chan *Conn
+	// tokens limits the number of opened connections
+	tokens chan struct{}
+}
+
+// Future is the result of the request executed asynchronously
+type Future struct {
+	done chan struct{}
+
+	data []interface{}
+	err  error
+}
+
+func NewPool(opts PoolOpts) *Pool {
+	if opts.MaxConnsPerAddress <= 0 {
+		opts.MaxConnsPerAddress = DefaultMaxConnsPerAddress
+	}
+
+	return &Pool{
+		opts:      opts,
+		addresses: make(map[string]*addressPool),
+	}
+}
+
+// Exec executes the request on some connection to the specified address.
+// If the context is done before the response is received,
+// the connection is closed and the context error is returned
+func (pool *Pool) Exec(ctx context.Context, connString string, req *Request) ([]interface{}, error) {
+	return pool.exec(ctx, connString, func(conn *Conn) ([]interface{}, error) {
+		return conn.Exec(req)
+	})
+}
+
+// ExecTyped is the same as Exec, but the response is decoded to resData
+func (pool *Pool) ExecTyped(ctx context.Context, connString string, req *Request, resData interface{}) error {
+	_, err := pool.exec(ctx, connString, func(conn *Conn) ([]interface{}, error) {
+		return nil, conn.ExecTyped(req, resData)
+	})
+
+	return err
+}
+
+// ExecAsync executes the request in background.
+// Use Future.Get to wait for the response
+func (pool *Pool) ExecAsync(ctx context.Context, connString string, req *Request) *Future {
+	return newFuture(func() ([]interface{}, error) {
+		return pool.Exec(ctx, connString, req)
+	})
+}
+
+// ExecTypedAsync executes the request in background.
+// The response is decoded to resData, so Future.Get returns only an error
+func (pool *Pool) ExecTypedAsync(ctx context.Context, connString string, req *Request, resData interface{}) *Future {
+	return newFuture(func() ([]interface{}, error) {
+		return nil, pool.ExecTyped(ctx, connString, req, resData)
+	})
+}
+
+// Close closes all idle connections.
+// Connections that are used now are closed after the request is executed
+func (pool *Pool) Close() error {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	pool.closed = true
+
+	var closeErr error
+	for _, addressPool := range pool.addresses {
+		if err := addressPool.closeIdle(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+
+	return closeErr
+}
+
+// Get waits for the request to be executed and returns the response
+func (future *Future) Get() ([]interface{}, error) {
+	<-future.done
+	return future.data, future.err
+}
+
+// Done returns the channel that is closed when the request is executed
+func (future *Future) Done() <-chan struct{} {
+	return future.done
+}
+
+func newFuture(execFunc func() ([]interface{}, error)) *Future {
+	future := &Future{
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(future.done)
+		future.data, future.err = execFunc()
+	}()
+
+	return future
+}
+
+func (pool *Pool) exec(ctx context.Context, connString string,
+	execFunc func(conn *Conn) ([]interface{}, error)) ([]interface{}, error) {
+
+	addressPool, err := pool.getAddressPool(connString)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := pool.acquire(ctx, addressPool)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []interface{}
+	var execErr error
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		data, execErr = execFunc(conn)
+	}()
+
+	select {
+	case <-done:
+		pool.release(addressPool, conn, IsConnectionLost(execErr))
+		return data, execErr
+	case <-ctx.Done():
+		// the response can't be read from this connection anymore,
+		// so it's closed to interrupt the request
+		conn.Close()
+
+		go func() {
+			<-done
+			pool.release(addressPool, nil, true)
+		}()
+
+		return nil, ctx.Err()
+	}
+}
+
+func (pool *Pool) getAddressPool(connString string) (*addressPool, error) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	if pool.closed {
+		return nil, fmt.Errorf("Connection pool is closed")
+	}
+
+	addrPool, found := pool.addresses[connString]
+	if !found {
+		addrPool = &addressPool{
+			connString: connString,
+			idle:       make(chan *Conn, pool.opts.MaxConnsPerAddress),
+			tokens:     make(chan struct{}, pool.opts.MaxConnsPerAddress),
+		}
+		pool.addresses[connString] = addrPool
+	}
+
+	return addrPool, nil
+}
+
+// acquire returns an idle connection to the address
+// or opens a new one if the connections limit isn't reached
+func (pool *Pool) acquire(ctx context.Context, addressPool *addressPool) (*Conn, error) {
+	select {
+	case conn := <-addressPool.idle:
+		return conn, nil
+	default:
+	}
+
+	select {
+	case conn := <-addressPool.idle:
+		return conn, nil
+	case addressPool.tokens <- struct{}{}:
+		conn, err := ConnectContext(ctx, addressPool.connString, pool.opts.Opts)
+		if err != nil {
+			<-addressPool.tokens
+			return nil, fmt.Errorf("Failed to connect to %s: %s", addressPool.connString, err)
+		}
+
+		return conn, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// release returns the connection to the pool.
+// Broken connection is closed to free the place for a new one
+func (pool *Pool) release(addressPool *addressPool, conn *Conn, broken bool) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	if broken || pool.closed {
+		if conn != nil {
+			conn.Close()
+		}
+
+		<-addressPool.tokens
+		return
+	}
+
+	addressPool.idle <- conn
+}
+
+func (addressPool *addressPool) closeIdle() error {
+	var closeErr error
+
+	for {
+		select {
+		case conn := <-addressPool.idle:
+			if err := conn.Close(); err != nil && closeErr == nil {
+				closeErr = err
+			}
+			<-addressPool.tokens
+		default:
+			return closeErr
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package connector
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// mockConsoleServer imitates Tarantool console socket:
+// it responds to each received line with the encoded `true` value
+// after the specified delay
+type mockConsoleServer struct {
+	listener net.Listener
+	delay    time.Duration
+
+	mutex         sync.Mutex
+	accepted      int
+	active        int
+	maxActive     int
+	totalRequests int
+}
+
+func startMockConsoleServer(sockPath string, delay time.Duration) (*mockConsoleServer, error) {
+	listener, err := net.Listen("unix", sockPath)
+	if err != nil {
+		return nil, err
+	}
+
+	server := &mockConsoleServer{
+		listener: listener,
+		delay:    delay,
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			server.mutex.Lock()
+			server.accepted++
+			server.mutex.Unlock()
+
+			go server.serve(conn)
+		}
+	}()
+
+	return server, nil
+}
+
+func (server *mockConsoleServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	greeting := fmt.Sprintf("%-63s\n%-63s\n", "Tarantool 2.10.0 (Lua console)", "type 'help' for interactive help")
+	if _, err := conn.Write([]byte(greeting)); err != nil {
+		return
+	}
+
+	dataEnc, _ := msgpack.Marshal([]interface{}{true})
+	response := fmt.Sprintf("---\n- data_enc: %s\n...\n", base64.StdEncoding.EncodeToString(dataEnc))
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		server.mutex.Lock()
+		server.totalRequests++
+		server.active++
+		if server.active > server.maxActive {
+			server.maxActive = server.active
+		}
+		server.mutex.Unlock()
+
+		time.Sleep(server.delay)
+
+		server.mutex.Lock()
+		server.active--
+		server.mutex.Unlock()
+
+		if _, err := conn.Write([]byte(response)); err != nil {
+			return
+		}
+	}
+}
+
+func TestPoolExecConcurrently(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "pool")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	sockPath := filepath.Join(dir, "instance.control")
+	server, err := startMockConsoleServer(sockPath, 100*time.Millisecond)
+	assert.Nil(err)
+	defer server.listener.Close()
+
+	pool := NewPool(PoolOpts{MaxConnsPerAddress: 4})
+	defer pool.Close()
+
+	futures := make([]*Future, 8)
+	for i := range futures {
+		futures[i] = pool.ExecAsync(context.Background(), sockPath, EvalReq("return true"))
+	}
+
+	for _, future := range futures {
+		data, err := future.Get()
+		assert.Nil(err)
+		assert.Equal([]interface{}{true}, data)
+	}
+
+	server.mutex.Lock()
+	assert.Equal(8, server.totalRequests)
+	assert.Equal(4, server.accepted)
+	assert.Equal(4, server.maxActive)
+	server.mutex.Unlock()
+
+	// idle connections are reused
+	var res []interface{}
+	assert.Nil(pool.ExecTyped(context.Background(), sockPath, EvalReq("return true"), &res))
+	assert.Equal([]interface{}{true}, res)
+
+	server.mutex.Lock()
+	assert.Equal(4, server.accepted)
+	server.mutex.Unlock()
+}
+
+func TestPoolExecDeadline(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "pool")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	sockPath := filepath.Join(dir, "instance.control")
+	server, err := startMockConsoleServer(sockPath, 500*time.Millisecond)
+	assert.Nil(err)
+	defer server.listener.Close()
+
+	pool := NewPool(PoolOpts{MaxConnsPerAddress: 1})
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = pool.Exec(ctx, sockPath, EvalReq("return true"))
+	assert.Equal(context.DeadlineExceeded, err)
+
+	// interrupted connection is closed and the new one is opened
+	data, err := pool.Exec(context.Background(), sockPath, EvalReq("return true"))
+	assert.Nil(err)
+	assert.Equal([]interface{}{true}, data)
+
+	server.mutex.Lock()
+	assert.Equal(2, server.accepted)
+	server.mutex.Unlock()
+
+	// closed pool
+	assert.Nil(pool.Close())
+	_, err = pool.Exec(context.Background(), sockPath, EvalReq("return true"))
+	assert.EqualError(err, "Connection pool is closed")
+
+	// unknown address
+	pool = NewPool(PoolOpts{})
+	defer pool.Close()
+
+	_, err = pool.Exec(context.Background(), filepath.Join(dir, "unknown.control"), EvalReq("return true"))
+	assert.NotNil(err)
+}
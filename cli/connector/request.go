@@ -1,6 +1,7 @@
 package connector
 
 import (
+	"context"
 	"time"
 )
 
@@ -8,10 +9,21 @@ type Request struct {
 	execFunc      func(conn *Conn) ([]interface{}, error)
 	execTypedFunc func(conn *Conn, resData interface{}) error
 
+	ctx          context.Context
 	pushCallback func(interface{})
 	readTimeout  time.Duration
 }
 
+// SetContext sets the context of the request.
+// If the context is done before the response is received,
+// the context error is returned.
+// Note that the plain text connection can't be used after that,
+// since the response can't be read anymore
+func (req *Request) SetContext(ctx context.Context) *Request {
+	req.ctx = ctx
+	return req
+}
+
 func (req *Request) SetPushCallback(pushCallback func(interface{})) *Request {
 	req.pushCallback = pushCallback
 	return req
@@ -31,6 +43,7 @@ func EvalReq(funcBody string, args ...interface{}) *Request {
 
 	req.execFunc = func(conn *Conn) ([]interface{}, error) {
 		return conn.evalFunc(conn, funcBody, args, ExecOpts{
+			Context:      req.ctx,
 			PushCallback: req.pushCallback,
 			ReadTimeout:  req.readTimeout,
 		})
@@ -38,6 +51,7 @@ func EvalReq(funcBody string, args ...interface{}) *Request {
 
 	req.execTypedFunc = func(conn *Conn, resData interface{}) error {
 		_, err := conn.evalFunc(conn, funcBody, args, ExecOpts{
+			Context:      req.ctx,
 			PushCallback: req.pushCallback,
 			ReadTimeout:  req.readTimeout,
 			ResData:      resData,
@@ -54,6 +68,7 @@ func CallReq(funcName string, args ...interface{}) *Request {
 
 	req.execFunc = func(conn *Conn) ([]interface{}, error) {
 		return conn.callFunc(conn, funcName, args, ExecOpts{
+			Context:      req.ctx,
 			PushCallback: req.pushCallback,
 			ReadTimeout:  req.readTimeout,
 		})
@@ -61,6 +76,7 @@ func CallReq(funcName string, args ...interface{}) *Request {
 
 	req.execTypedFunc = func(conn *Conn, resData interface{}) error {
 		_, err := conn.callFunc(conn, funcName, args, ExecOpts{
+			Context:      req.ctx,
 			PushCallback: req.pushCallback,
 			ReadTimeout:  req.readTimeout,
 			ResData:      resData,
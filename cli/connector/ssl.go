@@ -0,0 +1,205 @@
+package connector
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SSLOpts describes SSL transport options
+type SSLOpts struct {
+	KeyFile    string
+	CertFile   string
+	CaFile     string
+	Ciphers    string
+	ServerName string
+}
+
+// TLSProxy accepts plain connections on the private unix socket
+// and forwards each of them to the remote address over TLS.
+// It's used to connect to SSL instances via the binary protocol driver
+// that can dial plain connections only
+type TLSProxy struct {
+	connOpts  *ConnOpts
+	tlsConfig *tls.Config
+
+	dir      string
+	listener net.Listener
+
+	closeOnce sync.Once
+}
+
+// NewTLSProxy starts the proxy for the specified connection options
+func NewTLSProxy(connOpts *ConnOpts) (*TLSProxy, error) {
+	tlsConfig, err := getTLSConfig(connOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := ioutil.TempDir("", "cartridge-tls-")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create directory for TLS proxy socket: %s", err)
+	}
+
+	listener, err := net.Listen(UnixNetwork, filepath.Join(dir, "proxy.sock"))
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("Failed to start TLS proxy: %s", err)
+	}
+
+	proxy := &TLSProxy{
+		connOpts:  connOpts,
+		tlsConfig: tlsConfig,
+		dir:       dir,
+		listener:  listener,
+	}
+
+	go proxy.serve()
+
+	return proxy, nil
+}
+
+// ConnString returns the proxy socket connection string
+func (proxy *TLSProxy) ConnString() string {
+	return fmt.Sprintf("%s://%s", UnixNetwork, proxy.listener.Addr().String())
+}
+
+// Close stops accepting new connections and removes the proxy socket
+func (proxy *TLSProxy) Close() error {
+	var err error
+
+	proxy.closeOnce.Do(func() {
+		err = proxy.listener.Close()
+		os.RemoveAll(proxy.dir)
+	})
+
+	return err
+}
+
+func (proxy *TLSProxy) serve() {
+	for {
+		clientConn, err := proxy.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go proxy.forward(clientConn)
+	}
+}
+
+func (proxy *TLSProxy) forward(clientConn net.Conn) {
+	defer clientConn.Close()
+
+	remoteConn, err := dialTLS(context.Background(), proxy.connOpts, proxy.tlsConfig)
+	if err != nil {
+		return
+	}
+	defer remoteConn.Close()
+
+	done := make(chan struct{}, 2)
+
+	go func() {
+		io.Copy(remoteConn, clientConn)
+		done <- struct{}{}
+	}()
+
+	go func() {
+		io.Copy(clientConn, remoteConn)
+		done <- struct{}{}
+	}()
+
+	<-done
+}
+
+func dialTLS(ctx context.Context, connOpts *ConnOpts, tlsConfig *tls.Config) (net.Conn, error) {
+	dialer := tls.Dialer{Config: tlsConfig}
+	tlsConn, err := dialer.DialContext(ctx, connOpts.Network, connOpts.Address)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to establish SSL connection: %s", err)
+	}
+
+	return tlsConn, nil
+}
+
+func getTLSConfig(connOpts *ConnOpts) (*tls.Config, error) {
+	sslOpts := connOpts.SSL
+
+	tlsConfig := &tls.Config{
+		ServerName: sslOpts.ServerName,
+	}
+
+	if tlsConfig.ServerName == "" && connOpts.Network == TCPNetwork {
+		if host, _, err := net.SplitHostPort(connOpts.Address); err == nil {
+			tlsConfig.ServerName = host
+		}
+	}
+
+	if sslOpts.CaFile != "" {
+		caCert, err := ioutil.ReadFile(sslOpts.CaFile)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read SSL CA file: %s", err)
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("Failed to parse SSL CA file %s: no PEM certificates found", sslOpts.CaFile)
+		}
+	}
+
+	if sslOpts.CertFile != "" || sslOpts.KeyFile != "" {
+		if sslOpts.CertFile == "" || sslOpts.KeyFile == "" {
+			return nil, fmt.Errorf("Both SSL certificate and key files should be specified")
+		}
+
+		cert, err := tls.LoadX509KeyPair(sslOpts.CertFile, sslOpts.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to load SSL certificate: %s", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if sslOpts.Ciphers != "" {
+		cipherSuites, err := getCipherSuites(sslOpts.Ciphers)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.CipherSuites = cipherSuites
+	}
+
+	return tlsConfig, nil
+}
+
+// getCipherSuites parses colon-separated list of cipher suites names
+func getCipherSuites(ciphers string) ([]uint16, error) {
+	knownCipherSuites := make(map[string]uint16)
+	for _, cipherSuite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		knownCipherSuites[cipherSuite.Name] = cipherSuite.ID
+	}
+
+	var cipherSuites []uint16
+	for _, cipherName := range strings.Split(ciphers, ":") {
+		cipherName = strings.TrimSpace(cipherName)
+		if cipherName == "" {
+			continue
+		}
+
+		cipherSuiteID, found := knownCipherSuites[cipherName]
+		if !found {
+			return nil, fmt.Errorf("Unknown SSL cipher: %s", cipherName)
+		}
+
+		cipherSuites = append(cipherSuites, cipherSuiteID)
+	}
+
+	return cipherSuites, nil
+}
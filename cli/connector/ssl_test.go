@@ -0,0 +1,149 @@
+package connector
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeSelfSignedCert generates self-signed certificate for localhost
+// and writes certificate and key files to the specified directory
+func writeSelfSignedCert(dir string) (string, string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return "", "", err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", "", err
+	}
+
+	certPath := filepath.Join(dir, "server.crt")
+	keyPath := filepath.Join(dir, "server.key")
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	if err := ioutil.WriteFile(certPath, certPEM, 0644); err != nil {
+		return "", "", err
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err := ioutil.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		return "", "", err
+	}
+
+	return certPath, keyPath, nil
+}
+
+// startMockBinaryServer starts TLS server that sends Tarantool binary protocol
+// greeting to each accepted connection
+func startMockBinaryServer(certPath, keyPath string) (net.Listener, error) {
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	if err != nil {
+		return nil, err
+	}
+
+	greeting := fmt.Sprintf("%-63s\n%-63s\n", "Tarantool 2.10.0 (Binary) 00000000-0000-0000-0000-000000000000", "salt")
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func(conn net.Conn) {
+				defer conn.Close()
+				conn.Write([]byte(greeting))
+				ioutil.ReadAll(conn)
+			}(conn)
+		}
+	}()
+
+	return listener, nil
+}
+
+func TestConnectSSL(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "ssl")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	certPath, keyPath, err := writeSelfSignedCert(dir)
+	assert.Nil(err)
+
+	listener, err := startMockBinaryServer(certPath, keyPath)
+	assert.Nil(err)
+	defer listener.Close()
+
+	address := listener.Addr().String()
+
+	// trusted CA
+	conn, err := Connect(fmt.Sprintf("tcp://%s?transport=ssl&ssl_ca_file=%s", address, certPath), Opts{})
+	assert.Nil(err)
+	assert.Equal(BinaryProtocol, conn.protocol)
+
+	proxySocketPath := conn.tlsProxy.listener.Addr().String()
+	assert.FileExists(proxySocketPath)
+
+	assert.Nil(conn.Close())
+	assert.NoFileExists(proxySocketPath)
+
+	// server name is specified via options
+	conn, err = Connect(address, Opts{SSL: SSLOpts{CaFile: certPath, ServerName: "localhost"}})
+	assert.Nil(err)
+	assert.Nil(conn.Close())
+
+	// unknown authority
+	_, err = Connect(fmt.Sprintf("%s?transport=ssl", address), Opts{})
+	assert.NotNil(err)
+	assert.True(strings.Contains(err.Error(), "Failed to establish SSL connection"), err.Error())
+
+	// server name mismatch
+	_, err = Connect(address, Opts{SSL: SSLOpts{CaFile: certPath, ServerName: "tarantool.example.com"}})
+	assert.NotNil(err)
+	assert.True(strings.Contains(err.Error(), "Failed to establish SSL connection"), err.Error())
+
+	// client certificate without key
+	_, err = Connect(address, Opts{SSL: SSLOpts{CaFile: certPath, CertFile: certPath}})
+	assert.EqualError(err, "Both SSL certificate and key files should be specified")
+}
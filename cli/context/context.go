@@ -1,6 +1,7 @@
 package context
 
 import (
+	gocontext "context"
 	"net/http"
 	"time"
 
@@ -19,9 +20,12 @@ type Ctx struct {
 	Repair      RepairCtx
 	Admin       AdminCtx
 	Replicasets ReplicasetsCtx
+	Vshard      VshardCtx
 	Connect     ConnectCtx
+	Eval        EvalCtx
 	Failover    FailoverCtx
 	Bench       BenchCtx
+	Etcd        EtcdCtx
 }
 
 type ProjectCtx struct {
@@ -42,6 +46,8 @@ type RepairCtx struct {
 	Force  bool
 	Reload bool
 
+	Inventory string
+
 	SetURIInstanceUUID string
 	NewURI             string
 
@@ -49,6 +55,10 @@ type RepairCtx struct {
 
 	SetLeaderReplicasetUUID string
 	SetLeaderInstanceUUID   string
+
+	SyncSource string
+
+	ListFormat string
 }
 
 type BuildCtx struct {
@@ -70,6 +80,12 @@ type RunningCtx struct {
 	StateboardFlagIsSet bool
 	StateboardOnly      bool
 
+	WithEtcd      bool
+	EtcdFlagIsSet bool
+	EtcdListen    string
+	EtcdBinary    string
+	EtcdEmbedded  bool
+
 	Daemonize    bool
 	StartTimeout time.Duration
 
@@ -139,6 +155,11 @@ type CliCtx struct {
 	CartridgeTmpDir string
 	TmpDir          string
 	CacheDir        string
+
+	// Context is used to cancel connector requests.
+	// Commands that support cancellation replace it with the context
+	// that is done on SIGINT or when the command timeout is exceeded
+	Context gocontext.Context
 }
 
 type DockerCtx struct {
@@ -151,6 +172,22 @@ type AdminCtx struct {
 
 	InstanceName string
 	ConnString   string
+
+	Username  string
+	Password  string
+	Transport string
+	SSL       SSLCtx
+
+	CallTimeout time.Duration
+
+	ArgsJSON string
+	ArgsFile string
+	Output   string
+
+	All            bool
+	ReplicasetName string
+	Role           string
+	Parallel       int
 }
 
 type ReplicasetsCtx struct {
@@ -163,11 +200,40 @@ type ReplicasetsCtx struct {
 	RolesList             []string
 	VshardGroup           string
 	FailoverPriorityNames []string
+
+	ListFormat string
+
+	DecommissionTimeout time.Duration
+	SetupTimeout        time.Duration
+}
+
+type VshardCtx struct {
+	WaitTimeout time.Duration
 }
 
 type ConnectCtx struct {
 	Username string
 	Password string
+
+	Transport string
+	SSL       SSLCtx
+
+	Language string
+	Output   string
+
+	Eval     string
+	EvalFile string
+
+	HistoryFile  string
+	HistorySize  int
+	HistoryScope string
+}
+
+type EvalCtx struct {
+	Role        string
+	LeadersOnly bool
+
+	Timeout time.Duration
 }
 
 type FailoverCtx struct {
@@ -177,6 +243,18 @@ type FailoverCtx struct {
 
 	ParamsJSON         string
 	ProviderParamsJSON string
+
+	ForceInconsistency bool
+	SwitchoverTimeout  time.Duration
+
+	Watch         bool
+	WatchInterval time.Duration
+}
+
+type EtcdCtx struct {
+	Name    string
+	Listen  string
+	DataDir string
 }
 
 type BenchCtx struct {
@@ -192,4 +270,16 @@ type BenchCtx struct {
 	SelectCount          int    // SelectCount describes the number of select operations as a percentage.
 	UpdateCount          int    // UpdateCount describes the number of update operations as a percentage.
 	PreFillingCount      int    // PreFillingCount describes the number of records to pre-fill the space.
+	WorkloadFile         string // WorkloadFile describes the path to the YAML file with benchmark workload.
+
+	Transport string // Transport describes the connection transport: plain or ssl.
+	SSL       SSLCtx // SSL describes SSL transport options.
+}
+
+type SSLCtx struct {
+	KeyFile    string
+	CertFile   string
+	CaFile     string
+	Ciphers    string
+	ServerName string
 }
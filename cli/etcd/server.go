@@ -0,0 +1,286 @@
+package etcd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	keysPrefix = "/v2/keys"
+
+	// version reported by the /version endpoint
+	serverVersion  = "3.4.0"
+	clusterVersion = "3.4.0"
+
+	memberID  = "8e9e05c52164694d"
+	clusterID = "cdf818194e3a8c32"
+)
+
+// Server serves the subset of the etcd v2 API used by the Cartridge
+// etcd2 state provider: keys API, members list, version and health
+type Server struct {
+	store *Store
+
+	name       string
+	clientURLs []string
+
+	// onChange is called after each successful modification
+	onChange func()
+}
+
+func NewServer(store *Store, name string, clientURLs []string) *Server {
+	return &Server{
+		store:      store,
+		name:       name,
+		clientURLs: clientURLs,
+		onChange:   func() {},
+	}
+}
+
+func (server *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(keysPrefix, server.handleKeys)
+	mux.HandleFunc(keysPrefix+"/", server.handleKeys)
+	mux.HandleFunc("/v2/members", server.handleMembers)
+	mux.HandleFunc("/version", server.handleVersion)
+	mux.HandleFunc("/health", server.handleHealth)
+
+	return mux
+}
+
+func (server *Server) handleKeys(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		server.writeError(w, newError(ErrCodeInvalidField, err.Error(), server.store.Index()))
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, keysPrefix)
+
+	var event *Event
+	var err error
+
+	status := http.StatusOK
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		if getBoolParam(r, "wait") {
+			server.handleWatch(w, r, key)
+			return
+		}
+
+		event, err = server.store.Get(key, getBoolParam(r, "recursive"))
+	case http.MethodPut:
+		var opts SetOpts
+		if opts, err = getSetOpts(r); err != nil {
+			break
+		}
+
+		event, err = server.store.Set(key, r.FormValue("value"), opts)
+		if err == nil && event.PrevNode == nil {
+			status = http.StatusCreated
+		}
+	case http.MethodPost:
+		var opts SetOpts
+		if opts, err = getSetOpts(r); err != nil {
+			break
+		}
+
+		event, err = server.store.CreateInOrder(key, r.FormValue("value"), opts.TTL)
+		status = http.StatusCreated
+	case http.MethodDelete:
+		var opts DeleteOpts
+		if opts, err = getDeleteOpts(r); err != nil {
+			break
+		}
+
+		event, err = server.store.Delete(key, opts)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT, POST, DELETE")
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err != nil {
+		server.writeError(w, err)
+		return
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		server.onChange()
+	}
+
+	server.writeJSON(w, event, status)
+}
+
+func (server *Server) handleWatch(w http.ResponseWriter, r *http.Request, key string) {
+	var waitIndex uint64
+
+	if waitIndexStr := r.FormValue("waitIndex"); waitIndexStr != "" {
+		var err error
+		if waitIndex, err = strconv.ParseUint(waitIndexStr, 10, 64); err != nil {
+			server.writeError(w, newError(ErrCodeInvalidField, "invalid value for waitIndex", server.store.Index()))
+			return
+		}
+	}
+
+	eventCh, cancel, err := server.store.Watch(key, getBoolParam(r, "recursive"), waitIndex)
+	if err != nil {
+		server.writeError(w, err)
+		return
+	}
+	defer cancel()
+
+	// headers are sent immediately as etcd does,
+	// so the client knows the watch is established
+	server.setHeaders(w)
+	w.WriteHeader(http.StatusOK)
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	select {
+	case event := <-eventCh:
+		json.NewEncoder(w).Encode(event)
+	case <-r.Context().Done():
+	}
+}
+
+func (server *Server) handleMembers(w http.ResponseWriter, r *http.Request) {
+	members := map[string]interface{}{
+		"members": []map[string]interface{}{
+			{
+				"id":         memberID,
+				"name":       server.name,
+				"peerURLs":   []string{},
+				"clientURLs": server.clientURLs,
+			},
+		},
+	}
+
+	server.writeJSON(w, members, http.StatusOK)
+}
+
+func (server *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
+	server.writeJSON(w, map[string]string{
+		"etcdserver":  serverVersion,
+		"etcdcluster": clusterVersion,
+	}, http.StatusOK)
+}
+
+func (server *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	server.writeJSON(w, map[string]string{"health": "true"}, http.StatusOK)
+}
+
+func (server *Server) setHeaders(w http.ResponseWriter) {
+	index := strconv.FormatUint(server.store.Index(), 10)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Etcd-Cluster-Id", clusterID)
+	w.Header().Set("X-Etcd-Index", index)
+	w.Header().Set("X-Raft-Index", index)
+	w.Header().Set("X-Raft-Term", "1")
+}
+
+func (server *Server) writeError(w http.ResponseWriter, err error) {
+	etcdErr, ok := err.(*Error)
+	if !ok {
+		etcdErr = newError(ErrCodeInvalidField, err.Error(), server.store.Index())
+	}
+
+	server.writeJSON(w, etcdErr, getErrorStatus(etcdErr.ErrorCode))
+}
+
+func (server *Server) writeJSON(w http.ResponseWriter, value interface{}, status int) {
+	server.setHeaders(w)
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(value)
+}
+
+func getErrorStatus(errorCode int) int {
+	switch errorCode {
+	case ErrCodeKeyNotFound:
+		return http.StatusNotFound
+	case ErrCodeNotFile, ErrCodeNotDir, ErrCodeRootROnly, ErrCodeDirNotEmpty:
+		return http.StatusForbidden
+	case ErrCodeTestFailed, ErrCodeNodeExist:
+		return http.StatusPreconditionFailed
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func getSetOpts(r *http.Request) (SetOpts, error) {
+	var opts SetOpts
+	var err error
+
+	if ttlStr := r.FormValue("ttl"); ttlStr != "" {
+		ttl, err := strconv.ParseUint(ttlStr, 10, 64)
+		if err != nil {
+			return opts, newError(ErrCodeInvalidField, "invalid value for ttl", 0)
+		}
+		opts.TTL = time.Duration(ttl) * time.Second
+	}
+
+	if _, found := r.Form["prevExist"]; found {
+		prevExist := getBoolParam(r, "prevExist")
+		opts.PrevExist = &prevExist
+	}
+
+	if opts.PrevIndex, err = getIndexParam(r, "prevIndex"); err != nil {
+		return opts, err
+	}
+
+	if _, found := r.Form["prevValue"]; found {
+		prevValue := r.FormValue("prevValue")
+		opts.PrevValue = &prevValue
+	}
+
+	opts.Dir = getBoolParam(r, "dir")
+	opts.Refresh = getBoolParam(r, "refresh")
+
+	return opts, nil
+}
+
+func getDeleteOpts(r *http.Request) (DeleteOpts, error) {
+	var opts DeleteOpts
+	var err error
+
+	if opts.PrevIndex, err = getIndexParam(r, "prevIndex"); err != nil {
+		return opts, err
+	}
+
+	if _, found := r.Form["prevValue"]; found {
+		prevValue := r.FormValue("prevValue")
+		opts.PrevValue = &prevValue
+	}
+
+	opts.Dir = getBoolParam(r, "dir")
+	opts.Recursive = getBoolParam(r, "recursive")
+
+	return opts, nil
+}
+
+func getIndexParam(r *http.Request, name string) (uint64, error) {
+	valueStr := r.FormValue(name)
+	if valueStr == "" {
+		return 0, nil
+	}
+
+	value, err := strconv.ParseUint(valueStr, 10, 64)
+	if err != nil {
+		return 0, newError(ErrCodeInvalidField, fmt.Sprintf("invalid value for %s", name), 0)
+	}
+
+	return value, nil
+}
+
+func getBoolParam(r *http.Request, name string) bool {
+	value, err := strconv.ParseBool(r.FormValue(name))
+	return err == nil && value
+}
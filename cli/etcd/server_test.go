@@ -0,0 +1,159 @@
+package etcd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func doRequest(t *testing.T, method, requestURL string, form url.Values) (*http.Response, map[string]interface{}) {
+	var body *strings.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	} else {
+		body = strings.NewReader("")
+	}
+
+	req, err := http.NewRequest(method, requestURL, body)
+	if err != nil {
+		t.Fatalf("Failed to create request: %s", err)
+	}
+
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to perform request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %s", err)
+	}
+
+	return resp, result
+}
+
+func TestServerKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(NewServer(NewStore(), "myapp-etcd", []string{"http://localhost:2379"}).Handler())
+	defer server.Close()
+
+	keyURL := server.URL + "/v2/keys/cartridge/lock"
+
+	resp, result := doRequest(t, http.MethodGet, keyURL, nil)
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+	assert.Equal(float64(ErrCodeKeyNotFound), result["errorCode"])
+	assert.Equal("0", resp.Header.Get("X-Etcd-Index"))
+	assert.NotEmpty(resp.Header.Get("X-Etcd-Cluster-Id"))
+
+	resp, result = doRequest(t, http.MethodPut, keyURL, url.Values{
+		"value":     {"session"},
+		"ttl":       {"10"},
+		"prevExist": {"false"},
+	})
+	assert.Equal(http.StatusCreated, resp.StatusCode)
+	assert.Equal("create", result["action"])
+	assert.Equal("1", resp.Header.Get("X-Etcd-Index"))
+
+	node := result["node"].(map[string]interface{})
+	assert.Equal("/cartridge/lock", node["key"])
+	assert.Equal("session", node["value"])
+	assert.Equal(float64(10), node["ttl"])
+
+	resp, result = doRequest(t, http.MethodPut, keyURL, url.Values{
+		"value":     {"other-session"},
+		"prevExist": {"false"},
+	})
+	assert.Equal(http.StatusPreconditionFailed, resp.StatusCode)
+	assert.Equal(float64(ErrCodeNodeExist), result["errorCode"])
+
+	resp, result = doRequest(t, http.MethodPut, keyURL+"?prevIndex=1", url.Values{"value": {"new-session"}})
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal("compareAndSwap", result["action"])
+
+	resp, result = doRequest(t, http.MethodGet, server.URL+"/v2/keys/cartridge?recursive=true", nil)
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	node = result["node"].(map[string]interface{})
+	assert.Equal(true, node["dir"])
+	assert.Len(node["nodes"], 1)
+
+	resp, result = doRequest(t, http.MethodDelete, keyURL+"?prevValue=session", nil)
+	assert.Equal(http.StatusPreconditionFailed, resp.StatusCode)
+	assert.Equal(float64(ErrCodeTestFailed), result["errorCode"])
+
+	resp, result = doRequest(t, http.MethodDelete, keyURL, nil)
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal("delete", result["action"])
+
+	resp, result = doRequest(t, http.MethodPut, keyURL, url.Values{"value": {"value"}, "ttl": {"abc"}})
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(float64(ErrCodeInvalidField), result["errorCode"])
+}
+
+func TestServerWatch(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(NewServer(NewStore(), "myapp-etcd", []string{"http://localhost:2379"}).Handler())
+	defer server.Close()
+
+	type watchResult struct {
+		resp   *http.Response
+		result map[string]interface{}
+	}
+
+	resultCh := make(chan watchResult, 1)
+	go func() {
+		resp, result := doRequest(t, http.MethodGet, server.URL+"/v2/keys/cartridge?wait=true&recursive=true", nil)
+		resultCh <- watchResult{resp, result}
+	}()
+
+	// wait for the watch to be established
+	time.Sleep(100 * time.Millisecond)
+
+	doRequest(t, http.MethodPut, server.URL+"/v2/keys/cartridge/leaders", url.Values{"value": {"leaders"}})
+
+	select {
+	case res := <-resultCh:
+		assert.Equal(http.StatusOK, res.resp.StatusCode)
+		assert.Equal("set", res.result["action"])
+		assert.Equal("/cartridge/leaders", res.result["node"].(map[string]interface{})["key"])
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Watch event wasn't received")
+	}
+
+	resp, result := doRequest(t, http.MethodGet, server.URL+"/v2/keys/cartridge/leaders?wait=true&waitIndex=1", nil)
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal("leaders", result["node"].(map[string]interface{})["value"])
+}
+
+func TestServerMembers(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(NewServer(NewStore(), "myapp-etcd", []string{"http://localhost:2379"}).Handler())
+	defer server.Close()
+
+	resp, result := doRequest(t, http.MethodGet, server.URL+"/v2/members", nil)
+	assert.Equal(http.StatusOK, resp.StatusCode)
+
+	members := result["members"].([]interface{})
+	assert.Len(members, 1)
+
+	member := members[0].(map[string]interface{})
+	assert.Equal("myapp-etcd", member["name"])
+	assert.Equal([]interface{}{"http://localhost:2379"}, member["clientURLs"])
+
+	resp, result = doRequest(t, http.MethodGet, server.URL+"/health", nil)
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal("true", result["health"])
+}
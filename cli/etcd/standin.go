@@ -0,0 +1,154 @@
+package etcd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/apex/log"
+
+	"github.com/tarantool/cartridge-cli/cli/context"
+)
+
+const (
+	snapshotFileName = "snapshot.json"
+
+	expireCheckInterval = 100 * time.Millisecond
+)
+
+// RunStandIn runs the etcd v2 compatible key-value storage.
+// It's used to test the etcd2 failover state provider locally
+// when etcd binary isn't available.
+// Data is saved to the snapshot file in the data directory on each modification
+func RunStandIn(ctx *context.Ctx) error {
+	if err := os.MkdirAll(ctx.Etcd.DataDir, 0755); err != nil {
+		return fmt.Errorf("Failed to initialize data dir: %s", err)
+	}
+
+	store := NewStore()
+	snapshotPath := filepath.Join(ctx.Etcd.DataDir, snapshotFileName)
+
+	if data, err := ioutil.ReadFile(snapshotPath); err == nil {
+		if err := store.Recover(data); err != nil {
+			return fmt.Errorf("Failed to recover data from snapshot %s: %s", snapshotPath, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("Failed to read snapshot: %s", err)
+	}
+
+	listener, err := net.Listen("tcp", ctx.Etcd.Listen)
+	if err != nil {
+		return fmt.Errorf("Failed to listen %s: %s", ctx.Etcd.Listen, err)
+	}
+
+	server := NewServer(store, ctx.Etcd.Name, []string{getClientURL(listener.Addr(), ctx.Etcd.Listen)})
+
+	var snapshotMutex sync.Mutex
+	server.onChange = func() {
+		snapshotMutex.Lock()
+		defer snapshotMutex.Unlock()
+
+		if err := saveSnapshot(store, snapshotPath); err != nil {
+			log.Warnf("Failed to save snapshot: %s", err)
+		}
+	}
+
+	httpServer := &http.Server{Handler: server.Handler()}
+
+	serveErrCh := make(chan error, 1)
+	go func() {
+		serveErrCh <- httpServer.Serve(listener)
+	}()
+
+	go expireKeys(store, server.onChange)
+
+	log.Infof("etcd v2 stand-in is listening on %s", ctx.Etcd.Listen)
+
+	if err := notifyReady(); err != nil {
+		log.Warnf("Failed to notify about readiness: %s", err)
+	}
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, syscall.SIGTERM, syscall.SIGINT)
+
+	select {
+	case err := <-serveErrCh:
+		return fmt.Errorf("Failed to serve: %s", err)
+	case sig := <-signalCh:
+		log.Infof("Got %s, shutting down", sig)
+	}
+
+	// watch requests are long-polling,
+	// so connections are closed without waiting for them
+	httpServer.Close()
+
+	snapshotMutex.Lock()
+	defer snapshotMutex.Unlock()
+
+	return saveSnapshot(store, snapshotPath)
+}
+
+// getClientURL returns the URL clients should use to connect:
+// the host is taken from the specified address and the port from the listener
+// (it can be chosen by the system if the specified port is 0)
+func getClientURL(addr net.Addr, listen string) string {
+	host, _, err := net.SplitHostPort(listen)
+	if err != nil || host == "" {
+		host = "localhost"
+	}
+
+	port := strconv.Itoa(addr.(*net.TCPAddr).Port)
+
+	return fmt.Sprintf("http://%s", net.JoinHostPort(host, port))
+}
+
+func expireKeys(store *Store, onChange func()) {
+	for range time.Tick(expireCheckInterval) {
+		index := store.Index()
+		store.ExpireKeys()
+
+		if store.Index() != index {
+			onChange()
+		}
+	}
+}
+
+func saveSnapshot(store *Store, snapshotPath string) error {
+	data, err := store.Save()
+	if err != nil {
+		return err
+	}
+
+	tmpPath := snapshotPath + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, snapshotPath)
+}
+
+// notifyReady sends READY=1 to the socket specified in the NOTIFY_SOCKET
+// environment variable the same way etcd and Tarantool do
+func notifyReady() error {
+	notifySocket := os.Getenv("NOTIFY_SOCKET")
+	if notifySocket == "" {
+		return nil
+	}
+
+	conn, err := net.Dial("unixgram", notifySocket)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("READY=1"))
+	return err
+}
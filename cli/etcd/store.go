@@ -0,0 +1,682 @@
+package etcd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ErrCodeKeyNotFound    = 100
+	ErrCodeTestFailed     = 101
+	ErrCodeNotFile        = 102
+	ErrCodeNotDir         = 104
+	ErrCodeNodeExist      = 105
+	ErrCodeRootROnly      = 107
+	ErrCodeDirNotEmpty    = 108
+	ErrCodeInvalidField   = 209
+	ErrCodeEventIndexLost = 401
+
+	// number of events kept to serve watchers with waitIndex
+	eventsHistorySize = 1000
+)
+
+var errorMessages = map[int]string{
+	ErrCodeKeyNotFound:    "Key not found",
+	ErrCodeTestFailed:     "Compare failed",
+	ErrCodeNotFile:        "Not a file",
+	ErrCodeNotDir:         "Not a directory",
+	ErrCodeNodeExist:      "Key already exists",
+	ErrCodeRootROnly:      "Root is read only",
+	ErrCodeDirNotEmpty:    "Directory not empty",
+	ErrCodeInvalidField:   "Invalid field",
+	ErrCodeEventIndexLost: "The event in requested index is outdated and cleared",
+}
+
+// Error is an etcd v2 API error
+type Error struct {
+	ErrorCode int    `json:"errorCode"`
+	Message   string `json:"message"`
+	Cause     string `json:"cause,omitempty"`
+	Index     uint64 `json:"index"`
+}
+
+func (err *Error) Error() string {
+	return fmt.Sprintf("%d: %s (%s) [%d]", err.ErrorCode, err.Message, err.Cause, err.Index)
+}
+
+func newError(errorCode int, cause string, index uint64) *Error {
+	return &Error{
+		ErrorCode: errorCode,
+		Message:   errorMessages[errorCode],
+		Cause:     cause,
+		Index:     index,
+	}
+}
+
+// Node is a key (or directory) representation returned by the API
+type Node struct {
+	Key           string     `json:"key,omitempty"`
+	Value         *string    `json:"value,omitempty"`
+	Dir           bool       `json:"dir,omitempty"`
+	Expiration    *time.Time `json:"expiration,omitempty"`
+	TTL           int64      `json:"ttl,omitempty"`
+	Nodes         []*Node    `json:"nodes,omitempty"`
+	ModifiedIndex uint64     `json:"modifiedIndex,omitempty"`
+	CreatedIndex  uint64     `json:"createdIndex,omitempty"`
+}
+
+// Event is a result of the store operation
+type Event struct {
+	Action   string `json:"action"`
+	Node     *Node  `json:"node"`
+	PrevNode *Node  `json:"prevNode,omitempty"`
+
+	index uint64
+}
+
+type SetOpts struct {
+	// TTL is zero if the key shouldn't expire
+	TTL       time.Duration
+	PrevExist *bool
+	PrevIndex uint64
+	PrevValue *string
+	Dir       bool
+	Refresh   bool
+}
+
+type DeleteOpts struct {
+	PrevIndex uint64
+	PrevValue *string
+	Dir       bool
+	Recursive bool
+}
+
+type storeNode struct {
+	Key           string     `json:"key"`
+	Value         string     `json:"value"`
+	Dir           bool       `json:"dir,omitempty"`
+	Expiration    *time.Time `json:"expiration,omitempty"`
+	CreatedIndex  uint64     `json:"created_index"`
+	ModifiedIndex uint64     `json:"modified_index"`
+}
+
+type watcher struct {
+	key       string
+	recursive bool
+	eventCh   chan *Event
+}
+
+// Store is an in-memory implementation of the etcd v2 keys storage.
+// Directories are created implicitly, when the key is set
+type Store struct {
+	mutex sync.Mutex
+
+	index  uint64
+	nodes  map[string]*storeNode
+	events []*Event
+
+	watchers map[*watcher]struct{}
+
+	now func() time.Time
+}
+
+func NewStore() *Store {
+	return &Store{
+		nodes:    make(map[string]*storeNode),
+		watchers: make(map[*watcher]struct{}),
+		now:      time.Now,
+	}
+}
+
+// Index returns the current store index
+func (store *Store) Index() uint64 {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.index
+}
+
+func (store *Store) Get(key string, recursive bool) (*Event, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.expire()
+	key = cleanKey(key)
+
+	if node, found := store.nodes[key]; found && !node.Dir {
+		return &Event{Action: "get", Node: store.toNode(node)}, nil
+	}
+
+	if !store.isDir(key) {
+		return nil, newError(ErrCodeKeyNotFound, key, store.index)
+	}
+
+	return &Event{Action: "get", Node: store.dirNode(key, recursive)}, nil
+}
+
+func (store *Store) Set(key, value string, opts SetOpts) (*Event, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.expire()
+	key = cleanKey(key)
+
+	if key == "/" {
+		return nil, newError(ErrCodeRootROnly, key, store.index)
+	}
+
+	prevNode, exists := store.nodes[key]
+	if !exists && store.isDir(key) {
+		if !opts.Dir {
+			return nil, newError(ErrCodeNotFile, key, store.index)
+		}
+		return nil, newError(ErrCodeNodeExist, key, store.index)
+	}
+
+	if exists && prevNode.Dir != opts.Dir {
+		if prevNode.Dir {
+			return nil, newError(ErrCodeNotFile, key, store.index)
+		}
+		return nil, newError(ErrCodeNotDir, key, store.index)
+	}
+
+	for parent := parentKey(key); parent != "/"; parent = parentKey(parent) {
+		if node, found := store.nodes[parent]; found && !node.Dir {
+			return nil, newError(ErrCodeNotDir, parent, store.index)
+		}
+	}
+
+	action := "set"
+
+	if opts.PrevExist != nil {
+		if *opts.PrevExist && !exists {
+			return nil, newError(ErrCodeKeyNotFound, key, store.index)
+		}
+		if !*opts.PrevExist && exists {
+			return nil, newError(ErrCodeNodeExist, key, store.index)
+		}
+
+		action = "create"
+		if *opts.PrevExist {
+			action = "update"
+		}
+	}
+
+	if opts.PrevIndex != 0 || opts.PrevValue != nil {
+		if !exists {
+			return nil, newError(ErrCodeKeyNotFound, key, store.index)
+		}
+		if err := store.compare(prevNode, opts.PrevIndex, opts.PrevValue); err != nil {
+			return nil, err
+		}
+
+		action = "compareAndSwap"
+	}
+
+	if opts.Refresh {
+		if !exists {
+			return nil, newError(ErrCodeKeyNotFound, key, store.index)
+		}
+
+		// refresh only updates the key TTL and doesn't notify watchers
+		store.index++
+		prevCopy := *prevNode
+		prevNode.ModifiedIndex = store.index
+		prevNode.Expiration = store.getExpiration(opts.TTL)
+
+		return &Event{
+			Action:   "update",
+			Node:     store.toNode(prevNode),
+			PrevNode: store.toNode(&prevCopy),
+			index:    store.index,
+		}, nil
+	}
+
+	store.index++
+
+	node := &storeNode{
+		Key:           key,
+		Value:         value,
+		Dir:           opts.Dir,
+		Expiration:    store.getExpiration(opts.TTL),
+		CreatedIndex:  store.index,
+		ModifiedIndex: store.index,
+	}
+
+	event := &Event{Action: action, index: store.index}
+
+	if exists {
+		node.CreatedIndex = prevNode.CreatedIndex
+		event.PrevNode = store.toNode(prevNode)
+	}
+
+	store.nodes[key] = node
+	event.Node = store.toNode(node)
+
+	store.notify(event)
+
+	return event, nil
+}
+
+// CreateInOrder creates a key with an automatically generated
+// increasing name in the specified directory
+func (store *Store) CreateInOrder(dirKey, value string, ttl time.Duration) (*Event, error) {
+	prevExist := false
+
+	return store.Set(
+		fmt.Sprintf("%s/%020d", cleanKey(dirKey), store.Index()+1),
+		value,
+		SetOpts{TTL: ttl, PrevExist: &prevExist},
+	)
+}
+
+func (store *Store) Delete(key string, opts DeleteOpts) (*Event, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.expire()
+	key = cleanKey(key)
+
+	if key == "/" {
+		return nil, newError(ErrCodeRootROnly, key, store.index)
+	}
+
+	prevNode, exists := store.nodes[key]
+	isDir := (exists && prevNode.Dir) || (!exists && store.isDir(key))
+
+	if !exists && !isDir {
+		return nil, newError(ErrCodeKeyNotFound, key, store.index)
+	}
+
+	action := "delete"
+
+	if isDir {
+		if !opts.Dir && !opts.Recursive {
+			return nil, newError(ErrCodeNotFile, key, store.index)
+		}
+
+		if !opts.Recursive && len(store.children(key, false)) > 0 {
+			return nil, newError(ErrCodeDirNotEmpty, key, store.index)
+		}
+	} else {
+		if opts.PrevIndex != 0 || opts.PrevValue != nil {
+			if err := store.compare(prevNode, opts.PrevIndex, opts.PrevValue); err != nil {
+				return nil, err
+			}
+
+			action = "compareAndDelete"
+		}
+	}
+
+	store.index++
+
+	event := &Event{Action: action, index: store.index}
+
+	if isDir {
+		event.Node = &Node{Key: key, Dir: true, ModifiedIndex: store.index}
+		event.PrevNode = &Node{Key: key, Dir: true}
+		if exists {
+			event.Node.CreatedIndex = prevNode.CreatedIndex
+			event.PrevNode.CreatedIndex = prevNode.CreatedIndex
+			event.PrevNode.ModifiedIndex = prevNode.ModifiedIndex
+		}
+
+		for _, child := range store.children(key, true) {
+			delete(store.nodes, child.Key)
+		}
+	} else {
+		event.Node = &Node{Key: key, ModifiedIndex: store.index, CreatedIndex: prevNode.CreatedIndex}
+		event.PrevNode = store.toNode(prevNode)
+	}
+
+	delete(store.nodes, key)
+
+	store.notify(event)
+
+	return event, nil
+}
+
+// Watch returns a channel the first event matching the key is sent to.
+// If waitIndex is specified, events history is checked first.
+// The returned cancel function should be called when the event isn't needed anymore
+func (store *Store) Watch(key string, recursive bool, waitIndex uint64) (<-chan *Event, func(), error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.expire()
+	key = cleanKey(key)
+
+	eventCh := make(chan *Event, 1)
+	noop := func() {}
+
+	if waitIndex != 0 && waitIndex <= store.index {
+		if len(store.events) > 0 && waitIndex < store.events[0].index {
+			return nil, noop, newError(ErrCodeEventIndexLost,
+				fmt.Sprintf("the requested history has been cleared [%d/%d]", store.events[0].index, waitIndex),
+				store.index,
+			)
+		}
+
+		for _, event := range store.events {
+			if event.index >= waitIndex && eventMatches(event, key, recursive) {
+				eventCh <- event
+				return eventCh, noop, nil
+			}
+		}
+	}
+
+	w := &watcher{
+		key:       key,
+		recursive: recursive,
+		eventCh:   eventCh,
+	}
+
+	store.watchers[w] = struct{}{}
+
+	cancel := func() {
+		store.mutex.Lock()
+		defer store.mutex.Unlock()
+
+		delete(store.watchers, w)
+	}
+
+	return eventCh, cancel, nil
+}
+
+// ExpireKeys removes keys which TTL is over
+func (store *Store) ExpireKeys() {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.expire()
+}
+
+type storeSnapshot struct {
+	Index uint64       `json:"index"`
+	Nodes []*storeNode `json:"nodes"`
+}
+
+// Save returns the store snapshot
+func (store *Store) Save() ([]byte, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	snapshot := storeSnapshot{
+		Index: store.index,
+		Nodes: store.children("/", true),
+	}
+
+	return json.Marshal(snapshot)
+}
+
+// Recover restores the store from the snapshot
+func (store *Store) Recover(data []byte) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	var snapshot storeSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return err
+	}
+
+	store.index = snapshot.Index
+	store.nodes = make(map[string]*storeNode)
+	store.events = nil
+
+	for _, node := range snapshot.Nodes {
+		store.nodes[node.Key] = node
+	}
+
+	return nil
+}
+
+func (store *Store) expire() {
+	now := store.now()
+
+	var expiredKeys []string
+	for key, node := range store.nodes {
+		if node.Expiration != nil && !node.Expiration.After(now) {
+			expiredKeys = append(expiredKeys, key)
+		}
+	}
+
+	sort.Strings(expiredKeys)
+
+	for _, key := range expiredKeys {
+		node, found := store.nodes[key]
+		if !found {
+			// removed with the expired directory
+			continue
+		}
+
+		store.index++
+
+		event := &Event{
+			Action:   "expire",
+			Node:     &Node{Key: key, Dir: node.Dir, ModifiedIndex: store.index, CreatedIndex: node.CreatedIndex},
+			PrevNode: store.toNode(node),
+			index:    store.index,
+		}
+
+		for _, child := range store.children(key, true) {
+			delete(store.nodes, child.Key)
+		}
+		delete(store.nodes, key)
+
+		store.notify(event)
+	}
+}
+
+func (store *Store) notify(event *Event) {
+	store.events = append(store.events, event)
+	if len(store.events) > eventsHistorySize {
+		store.events = store.events[len(store.events)-eventsHistorySize:]
+	}
+
+	for w := range store.watchers {
+		if eventMatches(event, w.key, w.recursive) {
+			w.eventCh <- event
+			delete(store.watchers, w)
+		}
+	}
+}
+
+func (store *Store) compare(node *storeNode, prevIndex uint64, prevValue *string) error {
+	if node.Dir {
+		return newError(ErrCodeNotFile, node.Key, store.index)
+	}
+
+	var failed []string
+	if prevIndex != 0 && node.ModifiedIndex != prevIndex {
+		failed = append(failed, fmt.Sprintf("[%d != %d]", prevIndex, node.ModifiedIndex))
+	}
+
+	if prevValue != nil && node.Value != *prevValue {
+		failed = append(failed, fmt.Sprintf("[%s != %s]", *prevValue, node.Value))
+	}
+
+	if len(failed) > 0 {
+		return newError(ErrCodeTestFailed, strings.Join(failed, " "), store.index)
+	}
+
+	return nil
+}
+
+func (store *Store) getExpiration(ttl time.Duration) *time.Time {
+	if ttl <= 0 {
+		return nil
+	}
+
+	expiration := store.now().Add(ttl)
+
+	return &expiration
+}
+
+func (store *Store) isDir(key string) bool {
+	if key == "/" {
+		return true
+	}
+
+	if node, found := store.nodes[key]; found {
+		return node.Dir
+	}
+
+	prefix := key + "/"
+	for nodeKey := range store.nodes {
+		if strings.HasPrefix(nodeKey, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// children returns nodes stored under the directory sorted by key.
+// Implicit directories aren't included
+func (store *Store) children(dirKey string, recursive bool) []*storeNode {
+	prefix := dirKey + "/"
+	if dirKey == "/" {
+		prefix = "/"
+	}
+
+	var children []*storeNode
+	for key, node := range store.nodes {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		if !recursive && strings.Contains(strings.TrimPrefix(key, prefix), "/") {
+			continue
+		}
+
+		children = append(children, node)
+	}
+
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].Key < children[j].Key
+	})
+
+	return children
+}
+
+func (store *Store) dirNode(dirKey string, recursive bool) *Node {
+	dir := &Node{Dir: true}
+	if dirKey != "/" {
+		dir.Key = dirKey
+	}
+
+	if node, found := store.nodes[dirKey]; found {
+		dir.CreatedIndex = node.CreatedIndex
+		dir.ModifiedIndex = node.ModifiedIndex
+	}
+
+	prefix := dirKey + "/"
+	if dirKey == "/" {
+		prefix = "/"
+	}
+
+	// direct children names, including implicit directories
+	childKeys := make(map[string]struct{})
+	for key := range store.nodes {
+		if strings.HasPrefix(key, prefix) {
+			name := strings.SplitN(strings.TrimPrefix(key, prefix), "/", 2)[0]
+			childKeys[prefix+name] = struct{}{}
+		}
+	}
+
+	sortedKeys := make([]string, 0, len(childKeys))
+	for key := range childKeys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	for _, key := range sortedKeys {
+		if node, found := store.nodes[key]; found && !node.Dir {
+			dir.Nodes = append(dir.Nodes, store.toNode(node))
+		} else if recursive {
+			dir.Nodes = append(dir.Nodes, store.dirNode(key, true))
+		} else {
+			child := &Node{Key: key, Dir: true}
+			if found {
+				child.CreatedIndex = node.CreatedIndex
+				child.ModifiedIndex = node.ModifiedIndex
+			}
+			dir.Nodes = append(dir.Nodes, child)
+		}
+	}
+
+	return dir
+}
+
+func (store *Store) toNode(node *storeNode) *Node {
+	result := &Node{
+		Key:           node.Key,
+		Dir:           node.Dir,
+		CreatedIndex:  node.CreatedIndex,
+		ModifiedIndex: node.ModifiedIndex,
+	}
+
+	if !node.Dir {
+		value := node.Value
+		result.Value = &value
+	}
+
+	if node.Expiration != nil {
+		expiration := *node.Expiration
+		result.Expiration = &expiration
+
+		result.TTL = int64(node.Expiration.Sub(store.now()).Round(time.Second) / time.Second)
+		if result.TTL <= 0 {
+			result.TTL = 1
+		}
+	}
+
+	return result
+}
+
+func eventMatches(event *Event, key string, recursive bool) bool {
+	eventKey := event.Node.Key
+
+	if eventKey == key {
+		return true
+	}
+
+	// keys under the watched directory
+	if recursive && (key == "/" || strings.HasPrefix(eventKey, key+"/")) {
+		return true
+	}
+
+	// the watched key is removed with its directory
+	if event.Node.Dir && (event.Action == "delete" || event.Action == "expire") &&
+		strings.HasPrefix(key, eventKey+"/") {
+		return true
+	}
+
+	return false
+}
+
+func cleanKey(key string) string {
+	parts := strings.Split(key, "/")
+
+	var cleanParts []string
+	for _, part := range parts {
+		if part != "" && part != "." {
+			cleanParts = append(cleanParts, part)
+		}
+	}
+
+	return "/" + strings.Join(cleanParts, "/")
+}
+
+func parentKey(key string) string {
+	lastSlash := strings.LastIndex(key, "/")
+	if lastSlash <= 0 {
+		return "/"
+	}
+
+	return key[:lastSlash]
+}
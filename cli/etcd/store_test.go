@@ -0,0 +1,255 @@
+package etcd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getErrorCode(err error) int {
+	if etcdErr, ok := err.(*Error); ok {
+		return etcdErr.ErrorCode
+	}
+
+	return 0
+}
+
+func TestStoreSetGet(t *testing.T) {
+	assert := assert.New(t)
+
+	store := NewStore()
+
+	_, err := store.Get("/cartridge/leaders", false)
+	assert.Equal(ErrCodeKeyNotFound, getErrorCode(err))
+
+	event, err := store.Set("/cartridge/leaders", "{}", SetOpts{})
+	assert.Nil(err)
+	assert.Equal("set", event.Action)
+	assert.Equal("/cartridge/leaders", event.Node.Key)
+	assert.Equal("{}", *event.Node.Value)
+	assert.Equal(uint64(1), event.Node.CreatedIndex)
+	assert.Equal(uint64(1), event.Node.ModifiedIndex)
+	assert.Nil(event.PrevNode)
+
+	event, err = store.Set("cartridge//leaders/", "{\"rs\": \"srv\"}", SetOpts{})
+	assert.Nil(err)
+	assert.Equal(uint64(1), event.Node.CreatedIndex)
+	assert.Equal(uint64(2), event.Node.ModifiedIndex)
+	assert.Equal("{}", *event.PrevNode.Value)
+
+	event, err = store.Get("/cartridge/leaders", false)
+	assert.Nil(err)
+	assert.Equal("get", event.Action)
+	assert.Equal("{\"rs\": \"srv\"}", *event.Node.Value)
+
+	// directories are created implicitly
+	_, err = store.Set("/cartridge/lock", "session", SetOpts{})
+	assert.Nil(err)
+
+	event, err = store.Get("/cartridge", false)
+	assert.Nil(err)
+	assert.True(event.Node.Dir)
+	assert.Len(event.Node.Nodes, 2)
+	assert.Equal("/cartridge/leaders", event.Node.Nodes[0].Key)
+	assert.Equal("/cartridge/lock", event.Node.Nodes[1].Key)
+
+	event, err = store.Get("/", true)
+	assert.Nil(err)
+	assert.True(event.Node.Dir)
+	assert.Len(event.Node.Nodes, 1)
+	assert.Len(event.Node.Nodes[0].Nodes, 2)
+
+	// value can't be set to the directory
+	_, err = store.Set("/cartridge", "value", SetOpts{})
+	assert.Equal(ErrCodeNotFile, getErrorCode(err))
+
+	// key can't be set in the file
+	_, err = store.Set("/cartridge/lock/key", "value", SetOpts{})
+	assert.Equal(ErrCodeNotDir, getErrorCode(err))
+
+	assert.Equal(uint64(3), store.Index())
+}
+
+func TestStoreCompareAndSwap(t *testing.T) {
+	assert := assert.New(t)
+
+	store := NewStore()
+
+	prevExist := false
+	event, err := store.Set("/lock", "session-1", SetOpts{PrevExist: &prevExist})
+	assert.Nil(err)
+	assert.Equal("create", event.Action)
+
+	_, err = store.Set("/lock", "session-2", SetOpts{PrevExist: &prevExist})
+	assert.Equal(ErrCodeNodeExist, getErrorCode(err))
+
+	prevExist = true
+	event, err = store.Set("/lock", "session-1", SetOpts{PrevExist: &prevExist})
+	assert.Nil(err)
+	assert.Equal("update", event.Action)
+
+	_, err = store.Set("/unknown", "value", SetOpts{PrevExist: &prevExist})
+	assert.Equal(ErrCodeKeyNotFound, getErrorCode(err))
+
+	prevValue := "session-2"
+	_, err = store.Set("/lock", "session-3", SetOpts{PrevValue: &prevValue})
+	assert.Equal(ErrCodeTestFailed, getErrorCode(err))
+
+	prevValue = "session-1"
+	event, err = store.Set("/lock", "session-3", SetOpts{PrevValue: &prevValue, PrevIndex: 2})
+	assert.Nil(err)
+	assert.Equal("compareAndSwap", event.Action)
+
+	_, err = store.Delete("/lock", DeleteOpts{PrevIndex: 2})
+	assert.Equal(ErrCodeTestFailed, getErrorCode(err))
+
+	event, err = store.Delete("/lock", DeleteOpts{PrevIndex: 3})
+	assert.Nil(err)
+	assert.Equal("compareAndDelete", event.Action)
+	assert.Equal("session-3", *event.PrevNode.Value)
+
+	_, err = store.Delete("/lock", DeleteOpts{})
+	assert.Equal(ErrCodeKeyNotFound, getErrorCode(err))
+}
+
+func TestStoreDeleteDir(t *testing.T) {
+	assert := assert.New(t)
+
+	store := NewStore()
+
+	_, err := store.Set("/dir/a", "a", SetOpts{})
+	assert.Nil(err)
+	_, err = store.Set("/dir/sub/b", "b", SetOpts{})
+	assert.Nil(err)
+
+	_, err = store.Delete("/dir", DeleteOpts{})
+	assert.Equal(ErrCodeNotFile, getErrorCode(err))
+
+	_, err = store.Delete("/dir", DeleteOpts{Dir: true})
+	assert.Equal(ErrCodeDirNotEmpty, getErrorCode(err))
+
+	event, err := store.Delete("/dir", DeleteOpts{Recursive: true})
+	assert.Nil(err)
+	assert.Equal("delete", event.Action)
+	assert.True(event.Node.Dir)
+
+	_, err = store.Get("/dir/sub/b", false)
+	assert.Equal(ErrCodeKeyNotFound, getErrorCode(err))
+}
+
+func TestStoreTTL(t *testing.T) {
+	assert := assert.New(t)
+
+	store := NewStore()
+
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	event, err := store.Set("/lock", "session", SetOpts{TTL: 10 * time.Second})
+	assert.Nil(err)
+	assert.Equal(int64(10), event.Node.TTL)
+	assert.NotNil(event.Node.Expiration)
+
+	eventCh, cancel, err := store.Watch("/lock", false, 0)
+	assert.Nil(err)
+	defer cancel()
+
+	// refresh doesn't notify watchers
+	now = now.Add(5 * time.Second)
+	prevExist := true
+	event, err = store.Set("/lock", "", SetOpts{TTL: 10 * time.Second, PrevExist: &prevExist, Refresh: true})
+	assert.Nil(err)
+	assert.Equal("session", *event.Node.Value)
+	assert.Len(eventCh, 0)
+
+	now = now.Add(9 * time.Second)
+	store.ExpireKeys()
+	_, err = store.Get("/lock", false)
+	assert.Nil(err)
+
+	now = now.Add(time.Second)
+	store.ExpireKeys()
+	_, err = store.Get("/lock", false)
+	assert.Equal(ErrCodeKeyNotFound, getErrorCode(err))
+
+	event = <-eventCh
+	assert.Equal("expire", event.Action)
+	assert.Equal("session", *event.PrevNode.Value)
+}
+
+func TestStoreWatch(t *testing.T) {
+	assert := assert.New(t)
+
+	store := NewStore()
+
+	eventCh, cancel, err := store.Watch("/cartridge", true, 0)
+	assert.Nil(err)
+	defer cancel()
+
+	_, err = store.Set("/other", "value", SetOpts{})
+	assert.Nil(err)
+	assert.Len(eventCh, 0)
+
+	_, err = store.Set("/cartridge/leaders", "value", SetOpts{})
+	assert.Nil(err)
+
+	event := <-eventCh
+	assert.Equal("set", event.Action)
+	assert.Equal("/cartridge/leaders", event.Node.Key)
+
+	// events history
+	eventCh, cancel, err = store.Watch("/other", false, 1)
+	assert.Nil(err)
+	defer cancel()
+
+	event = <-eventCh
+	assert.Equal(uint64(1), event.Node.ModifiedIndex)
+
+	// history is waited for the specified index
+	eventCh, cancel, err = store.Watch("/other", false, 3)
+	assert.Nil(err)
+	defer cancel()
+	assert.Len(eventCh, 0)
+
+	_, err = store.Set("/other", "new-value", SetOpts{})
+	assert.Nil(err)
+
+	event = <-eventCh
+	assert.Equal("new-value", *event.Node.Value)
+
+	// cleared history
+	for i := 0; i < eventsHistorySize; i++ {
+		_, err = store.Set("/other", "value", SetOpts{})
+		assert.Nil(err)
+	}
+
+	_, _, err = store.Watch("/other", false, 1)
+	assert.Equal(ErrCodeEventIndexLost, getErrorCode(err))
+}
+
+func TestStoreSaveRecover(t *testing.T) {
+	assert := assert.New(t)
+
+	store := NewStore()
+
+	_, err := store.Set("/cartridge/leaders", "leaders", SetOpts{})
+	assert.Nil(err)
+	_, err = store.Set("/cartridge/lock", "lock", SetOpts{TTL: time.Minute})
+	assert.Nil(err)
+
+	data, err := store.Save()
+	assert.Nil(err)
+
+	recovered := NewStore()
+	assert.Nil(recovered.Recover(data))
+
+	assert.Equal(uint64(2), recovered.Index())
+
+	event, err := recovered.Get("/cartridge/lock", false)
+	assert.Nil(err)
+	assert.Equal("lock", *event.Node.Value)
+	assert.NotNil(event.Node.Expiration)
+
+	assert.NotNil(recovered.Recover([]byte("not a snapshot")))
+}
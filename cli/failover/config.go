@@ -0,0 +1,310 @@
+package failover
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/tarantool/cartridge-cli/cli/common"
+	"gopkg.in/yaml.v3"
+)
+
+// FailoverConfig describes failover parameters
+// specified in the failover.yml file or via `failover set` flags.
+// Parameters are checked by `check` tag:
+//   - required - parameter should be specified;
+//   - positive - number should be greater than zero;
+//   - non-negative - number shouldn't be less than zero;
+//   - oneof=a b c - string should be one of the listed values.
+type FailoverConfig struct {
+	Mode          string `yaml:"mode,omitempty" check:"oneof=stateful eventual raft disabled"`
+	StateProvider string `yaml:"state_provider,omitempty" check:"oneof=stateboard etcd2"`
+
+	FailoverTimeout  *float64 `yaml:"failover_timeout,omitempty" check:"positive"`
+	FencingEnabled   *bool    `yaml:"fencing_enabled,omitempty"`
+	FencingTimeout   *float64 `yaml:"fencing_timeout,omitempty" check:"positive"`
+	FencingPause     *float64 `yaml:"fencing_pause,omitempty" check:"positive"`
+	LeaderAutoreturn *bool    `yaml:"leader_autoreturn,omitempty"`
+	AutoreturnDelay  *float64 `yaml:"autoreturn_delay,omitempty" check:"non-negative"`
+	CheckCookieHash  *bool    `yaml:"check_cookie_hash,omitempty"`
+
+	StateboardParams *StateboardParams `yaml:"stateboard_params,omitempty"`
+	Etcd2Params      *Etcd2Params      `yaml:"etcd2_params,omitempty"`
+}
+
+type StateboardParams struct {
+	URI      string `yaml:"uri" check:"required"`
+	Password string `yaml:"password" check:"required"`
+}
+
+type Etcd2Params struct {
+	Prefix    string   `yaml:"prefix,omitempty"`
+	LockDelay *float64 `yaml:"lock_delay,omitempty" check:"positive"`
+	Endpoints []string `yaml:"endpoints,omitempty"`
+	Username  string   `yaml:"username,omitempty"`
+	Password  string   `yaml:"password,omitempty"`
+}
+
+// ToFailoverOpts converts config to options passed to Cartridge
+func (config *FailoverConfig) ToFailoverOpts() (*FailoverOpts, error) {
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to encode failover configuration: %s", err)
+	}
+
+	// nested maps are decoded to the type of the target map,
+	// so the plain map is used here
+	opts := make(map[string]interface{})
+	if err := yaml.Unmarshal(content, &opts); err != nil {
+		return nil, fmt.Errorf("Failed to decode failover configuration: %s", err)
+	}
+
+	failoverOpts := FailoverOpts(opts)
+
+	return &failoverOpts, nil
+}
+
+// decodeStrict decodes YAML (or JSON, that is a subset of YAML) content to
+// the value of the specified struct type.
+// Unknown parameters, parameters with invalid types and values
+// are reported with the line and column they are specified on
+func decodeStrict(content []byte, value interface{}) error {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return err
+	}
+
+	if len(root.Content) == 0 {
+		return fmt.Errorf("Configuration is empty")
+	}
+
+	document := root.Content[0]
+	if err := checkNode(document, reflect.TypeOf(value).Elem(), ""); err != nil {
+		return err
+	}
+
+	return document.Decode(value)
+}
+
+func checkNode(node *yaml.Node, valueType reflect.Type, paramName string) error {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	if node.Tag == "!!null" {
+		return nil
+	}
+
+	if valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+
+	switch valueType.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return nodeErrorf(node, "%s should be a map", getParamTitle(paramName))
+		}
+
+		return checkMappingNode(node, valueType, paramName)
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			return nodeErrorf(node, "%s should be a list", getParamTitle(paramName))
+		}
+
+		for i, elemNode := range node.Content {
+			if err := checkNode(elemNode, valueType.Elem(), fmt.Sprintf("%s[%d]", paramName, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.String:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!str" {
+			return nodeErrorf(node, "%s should be a string", getParamTitle(paramName))
+		}
+	case reflect.Float64:
+		if node.Kind != yaml.ScalarNode || (node.Tag != "!!int" && node.Tag != "!!float") {
+			return nodeErrorf(node, "%s should be a number", getParamTitle(paramName))
+		}
+	case reflect.Bool:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			return nodeErrorf(node, "%s should be a boolean", getParamTitle(paramName))
+		}
+	default:
+		return fmt.Errorf("Type %s of %s isn't supported", valueType, getParamTitle(paramName))
+	}
+
+	return nil
+}
+
+func checkMappingNode(node *yaml.Node, structType reflect.Type, paramName string) error {
+	fields := make(map[string]reflect.StructField)
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fields[getFieldParamName(field)] = field
+	}
+
+	specified := make(map[string]bool)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		fullName := getFullParamName(paramName, keyNode.Value)
+
+		field, found := fields[keyNode.Value]
+		if !found {
+			knownNames := make([]string, 0, len(fields))
+			for name := range fields {
+				knownNames = append(knownNames, name)
+			}
+
+			if suggestion := getClosestName(keyNode.Value, knownNames); suggestion != "" {
+				return nodeErrorf(keyNode, "Unknown parameter %q, did you mean %q?",
+					fullName, getFullParamName(paramName, suggestion))
+			}
+
+			return nodeErrorf(keyNode, "Unknown parameter %q", fullName)
+		}
+
+		if err := checkNode(valueNode, field.Type, fullName); err != nil {
+			return err
+		}
+
+		if err := checkNodeValue(valueNode, field, fullName); err != nil {
+			return err
+		}
+
+		specified[keyNode.Value] = valueNode.Tag != "!!null"
+	}
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name := getFieldParamName(field)
+
+		if hasCheck(field, "required") && !specified[name] {
+			return nodeErrorf(node, "%s is required", getParamTitle(getFullParamName(paramName, name)))
+		}
+	}
+
+	return nil
+}
+
+func checkNodeValue(node *yaml.Node, field reflect.StructField, paramName string) error {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	if node.Tag == "!!null" {
+		return nil
+	}
+
+	for _, check := range strings.Split(field.Tag.Get("check"), ",") {
+		switch {
+		case check == "positive" || check == "non-negative":
+			var value float64
+			if err := node.Decode(&value); err != nil {
+				return nodeErrorf(node, "%s should be a number", getParamTitle(paramName))
+			}
+
+			if check == "positive" && value <= 0 {
+				return nodeErrorf(node, "%s should be positive", getParamTitle(paramName))
+			}
+
+			if check == "non-negative" && value < 0 {
+				return nodeErrorf(node, "%s shouldn't be negative", getParamTitle(paramName))
+			}
+		case strings.HasPrefix(check, "oneof="):
+			allowedValues := strings.Fields(strings.TrimPrefix(check, "oneof="))
+			if !common.StringSliceContains(allowedValues, node.Value) {
+				return nodeErrorf(node, "%s should be one of: %s",
+					getParamTitle(paramName), strings.Join(allowedValues, ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+func hasCheck(field reflect.StructField, checkName string) bool {
+	return common.StringSliceContains(strings.Split(field.Tag.Get("check"), ","), checkName)
+}
+
+func getFieldParamName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("yaml"), ",")[0]
+}
+
+func getFullParamName(parentName, name string) string {
+	if parentName == "" {
+		return name
+	}
+
+	return fmt.Sprintf("%s.%s", parentName, name)
+}
+
+func getParamTitle(paramName string) string {
+	if paramName == "" {
+		return "Configuration"
+	}
+
+	return fmt.Sprintf("%q", paramName)
+}
+
+func nodeErrorf(node *yaml.Node, format string, args ...interface{}) error {
+	return fmt.Errorf("line %d, column %d: %s", node.Line, node.Column, fmt.Sprintf(format, args...))
+}
+
+// getClosestName returns the known name that differs from the specified one
+// by no more than two characters (probably, it's a misprint).
+// Empty string is returned if there is no such name
+func getClosestName(name string, knownNames []string) string {
+	const maxDistance = 2
+
+	sort.Strings(knownNames)
+
+	closestName := ""
+	closestDistance := maxDistance + 1
+
+	for _, knownName := range knownNames {
+		if distance := getEditDistance(name, knownName); distance < closestDistance {
+			closestName = knownName
+			closestDistance = distance
+		}
+	}
+
+	return closestName
+}
+
+// getEditDistance returns the Levenshtein distance between two strings
+func getEditDistance(a, b string) int {
+	prevRow := make([]int, len(b)+1)
+	for j := range prevRow {
+		prevRow[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		row := make([]int, len(b)+1)
+		row[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			substitutionCost := 1
+			if a[i-1] == b[j-1] {
+				substitutionCost = 0
+			}
+
+			row[j] = minInt(prevRow[j]+1, row[j-1]+1, prevRow[j-1]+substitutionCost)
+		}
+
+		prevRow = row
+	}
+
+	return prevRow[len(b)]
+}
+
+func minInt(values ...int) int {
+	min := values[0]
+	for _, value := range values[1:] {
+		if value < min {
+			min = value
+		}
+	}
+
+	return min
+}
@@ -0,0 +1,151 @@
+local cartridge = require('cartridge')
+local failover = require('cartridge.failover')
+local membership = require('membership')
+
+local function get_state_provider_session()
+    local client = require('cartridge.vars').new('cartridge.failover').client
+    if client == nil then
+        return nil, 'State provider client is not initialized'
+    end
+
+    return client:get_session()
+end
+
+local function get_coordinator()
+    if type(failover.get_coordinator) == 'function' then
+        return failover.get_coordinator()
+    end
+
+    -- old Cartridge versions don't expose the coordinator,
+    -- so it's requested from the state provider directly
+    local session, err = get_state_provider_session()
+    if session == nil then
+        return nil, err
+    end
+
+    return session:get_coordinator()
+end
+
+-- get_leaders returns the leaders appointed by the coordinator
+-- as a map replicaset_uuid -> instance_uuid
+local function get_leaders()
+    local session, err = get_state_provider_session()
+    if session == nil then
+        return nil, err
+    end
+
+    return session:get_leaders()
+end
+
+local function format_error(err)
+    return tostring(type(err) == 'table' and err.err or err)
+end
+
+local params = cartridge.failover_get_params()
+
+local servers, err = cartridge.admin_get_servers()
+if err ~= nil then
+    err = err.err
+end
+assert(err == nil, tostring(err))
+
+local replicasets, err = cartridge.admin_get_replicasets()
+if err ~= nil then
+    err = err.err
+end
+assert(err == nil, tostring(err))
+
+local servers_by_uuid = {}
+for _, server in pairs(servers) do
+    if server.uuid ~= nil and server.uuid ~= '' then
+        servers_by_uuid[server.uuid] = server
+    end
+end
+
+local state = {
+    mode = params.mode,
+    leaders = {},
+    suspected = {},
+}
+
+-- in stateful mode leaders are appointed by the coordinator,
+-- so they are requested from the state provider
+local state_provider_leaders
+
+if params.mode == 'stateful' then
+    state.state_provider = params.state_provider
+
+    local ok, coordinator, err = pcall(get_coordinator)
+    if not ok then
+        state.state_provider_error = tostring(coordinator)
+    elseif err ~= nil then
+        state.state_provider_error = format_error(err)
+    else
+        state.state_provider_connected = true
+
+        if coordinator ~= nil then
+            local server = servers_by_uuid[coordinator.uuid] or {}
+            state.coordinator = {
+                alias = server.alias,
+                uri = coordinator.uri,
+            }
+        end
+
+        local ok, leaders, err = pcall(get_leaders)
+        if not ok then
+            state.state_provider_error = tostring(leaders)
+        elseif err ~= nil then
+            state.state_provider_error = format_error(err)
+        else
+            state_provider_leaders = leaders or {}
+            state.leaders_from_state_provider = true
+        end
+    end
+end
+
+for _, replicaset in pairs(replicasets) do
+    local leader = {
+        replicaset_alias = replicaset.alias,
+    }
+
+    if state_provider_leaders ~= nil then
+        local leader_uuid = state_provider_leaders[replicaset.uuid]
+        local server = servers_by_uuid[leader_uuid]
+        if server ~= nil then
+            leader.alias = server.alias
+            leader.uri = server.uri
+        end
+    elseif replicaset.active_master ~= nil then
+        leader.alias = replicaset.active_master.alias
+        leader.uri = replicaset.active_master.uri
+    end
+
+    table.insert(state.leaders, leader)
+end
+
+table.sort(state.leaders, function(a, b) return a.replicaset_alias < b.replicaset_alias end)
+
+for _, server in pairs(servers) do
+    local member = membership.get_member(server.uri)
+    local status = member ~= nil and member.status or 'unknown'
+
+    if status ~= 'alive' then
+        table.insert(state.suspected, {
+            alias = server.alias,
+            uri = server.uri,
+            status = status,
+        })
+    end
+end
+
+table.sort(state.suspected, function(a, b) return a.uri < b.uri end)
+
+if type(failover.is_suppressed) == 'function' then
+    state.suppressed = failover.is_suppressed()
+end
+
+if type(failover.is_paused) == 'function' then
+    state.paused = failover.is_paused()
+end
+
+return state
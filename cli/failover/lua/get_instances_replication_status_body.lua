@@ -0,0 +1,75 @@
+local fiber = require('fiber')
+local pool = require('cartridge.pool')
+
+local uris, timeout = ...
+
+local INSTANCE_STATUS_BODY = [[
+    local vclock = setmetatable({}, {__serialize = 'map'})
+    for id, lsn in pairs(box.info.vclock) do
+        -- 0 component counts local changes that aren't replicated
+        if id ~= 0 then
+            vclock[tostring(id)] = lsn
+        end
+    end
+
+    return {
+        uuid = box.info.uuid,
+        ro = box.info.ro,
+        vclock = vclock,
+    }
+]]
+
+local function get_status(uri)
+    local conn, err = pool.connect(uri, {wait_connected = false})
+    if conn == nil then
+        return nil, tostring(err)
+    end
+
+    local ok, res = pcall(conn.eval, conn, INSTANCE_STATUS_BODY, {}, {timeout = timeout})
+    if not ok then
+        return nil, tostring(res)
+    end
+
+    return res
+end
+
+-- Instances are polled in parallel, each request is limited by the timeout
+local result = {}
+local done = fiber.channel(#uris)
+
+for i, uri in ipairs(uris) do
+    local status = {uri = uri}
+    result[i] = status
+
+    fiber.create(function()
+        local res, err = get_status(uri)
+        if res == nil then
+            status.error = err
+        else
+            status.uuid = res.uuid
+            status.ro = res.ro
+            status.vclock = setmetatable(res.vclock, {__serialize = 'map'})
+        end
+
+        status.done = true
+        done:put(true, 0)
+    end)
+end
+
+-- connection establishment is included in the eval timeout,
+-- so all requests should be finished by the deadline
+local deadline = fiber.clock() + timeout + 1
+for _ = 1, #uris do
+    if done:get(math.max(deadline - fiber.clock(), 0)) == nil then
+        break
+    end
+end
+
+for _, status in ipairs(result) do
+    if not status.done then
+        status.error = 'Timed out'
+    end
+    status.done = nil
+end
+
+return unpack(result)
@@ -0,0 +1,11 @@
+local cartridge = require('cartridge')
+
+local replicaset_uuid, instance_uuid, opts = ...
+
+local res, err = cartridge.failover_promote({[replicaset_uuid] = instance_uuid}, opts)
+
+if err ~= nil then
+    return nil, err.err
+end
+
+return res, nil
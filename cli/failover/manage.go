@@ -10,7 +10,6 @@ import (
 )
 
 type FailoverOpts map[string]interface{}
-type ProviderParams map[string]interface{}
 
 func (failoverOpts FailoverOpts) Manage(ctx *context.Ctx) error {
 	conn, err := cluster.ConnectToSomeRunningInstance(ctx)
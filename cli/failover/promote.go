@@ -0,0 +1,304 @@
+package failover
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/tarantool/cartridge-cli/cli/cluster"
+	"github.com/tarantool/cartridge-cli/cli/common"
+	"github.com/tarantool/cartridge-cli/cli/connector"
+	"github.com/tarantool/cartridge-cli/cli/context"
+	"github.com/tarantool/cartridge-cli/cli/project"
+	"github.com/tarantool/cartridge-cli/cli/replicasets"
+)
+
+const (
+	// time to wait for a response from each replica set instance
+	instanceStatusTimeout = 3 * time.Second
+
+	waitWritableCheckInterval = 500 * time.Millisecond
+
+	healthyInstanceStatus = "healthy"
+)
+
+type InstanceReplicationStatus struct {
+	URI  string `mapstructure:"uri"`
+	UUID string `mapstructure:"uuid"`
+
+	RO     bool              `mapstructure:"ro"`
+	Vclock map[string]uint64 `mapstructure:"vclock"`
+
+	Error string `mapstructure:"error"`
+}
+
+func (status *InstanceReplicationStatus) DecodeMsgpack(d *msgpack.Decoder) error {
+	return common.DecodeMsgpackStruct(d, status)
+}
+
+// Promote makes the specified instance a leader of the replica set
+func Promote(ctx *context.Ctx, args []string) error {
+	replicasetName, instanceName := args[0], args[1]
+
+	if err := project.FillCtx(ctx); err != nil {
+		return err
+	}
+
+	conn, err := cluster.ConnectToSomeJoinedInstance(ctx)
+	if err != nil {
+		return err
+	}
+
+	topologyReplicaset, err := getTopologyReplicaset(conn, replicasetName)
+	if err != nil {
+		return err
+	}
+
+	topologyInstance := getTopologyInstanceByAlias(topologyReplicaset, instanceName)
+	if topologyInstance == nil {
+		return common.ErrWrapCheckInstanceNameCommonMisprint([]string{instanceName}, ctx.Project.Name,
+			fmt.Errorf("Instance %s not found in replica set %s", instanceName, replicasetName))
+	}
+
+	if topologyInstance.UUID == topologyReplicaset.LeaderUUID {
+		log.Infof("Instance %s is already a leader of replica set %s", instanceName, replicasetName)
+		return nil
+	}
+
+	log.Infof("Promote instance %s to be a leader of replica set %s", instanceName, replicasetName)
+
+	if err := promoteLeader(conn, topologyReplicaset, topologyInstance, ctx.Failover.ForceInconsistency); err != nil {
+		return err
+	}
+
+	log.Infof("Leader promoted successfully")
+
+	return nil
+}
+
+// Switchover promotes the most up-to-date replica to be a leader of
+// the replica set and waits until the new leader becomes writable
+func Switchover(ctx *context.Ctx, args []string) error {
+	replicasetName := args[0]
+
+	if err := project.FillCtx(ctx); err != nil {
+		return err
+	}
+
+	conn, err := cluster.ConnectToSomeJoinedInstance(ctx)
+	if err != nil {
+		return err
+	}
+
+	topologyReplicaset, err := getTopologyReplicaset(conn, replicasetName)
+	if err != nil {
+		return err
+	}
+
+	instancesURIs := make([]string, len(topologyReplicaset.Instances))
+	for i, topologyInstance := range topologyReplicaset.Instances {
+		instancesURIs[i] = topologyInstance.URI
+	}
+
+	instancesStatus, err := getInstancesReplicationStatus(conn, instancesURIs)
+	if err != nil {
+		return err
+	}
+
+	candidate, err := getSwitchoverCandidate(topologyReplicaset, instancesStatus)
+	if err != nil {
+		return fmt.Errorf("Failed to choose a new leader of replica set %s: %s", replicasetName, err)
+	}
+
+	log.Infof("Switch replica set %s leader to %s", replicasetName, candidate.Alias)
+
+	if err := promoteLeader(conn, topologyReplicaset, candidate, false); err != nil {
+		return err
+	}
+
+	log.Infof("Wait until %s becomes writable", candidate.Alias)
+
+	if err := waitInstanceIsWritable(conn, candidate.URI, ctx.Failover.SwitchoverTimeout); err != nil {
+		return err
+	}
+
+	log.Infof("Leader switched successfully")
+
+	return nil
+}
+
+func getTopologyReplicaset(conn *connector.Conn, replicasetName string) (*replicasets.TopologyReplicaset, error) {
+	topologyReplicasets, err := replicasets.GetTopologyReplicasets(conn)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get current topology replica sets: %s", err)
+	}
+
+	topologyReplicaset := topologyReplicasets.GetByAlias(replicasetName)
+	if topologyReplicaset == nil {
+		return nil, fmt.Errorf("Replica set %s isn't found in current topology", replicasetName)
+	}
+
+	return topologyReplicaset, nil
+}
+
+func getTopologyInstanceByAlias(topologyReplicaset *replicasets.TopologyReplicaset,
+	alias string) *replicasets.TopologyInstance {
+
+	for _, topologyInstance := range topologyReplicaset.Instances {
+		if topologyInstance.Alias == alias {
+			return topologyInstance
+		}
+	}
+
+	return nil
+}
+
+func promoteLeader(conn *connector.Conn, topologyReplicaset *replicasets.TopologyReplicaset,
+	topologyInstance *replicasets.TopologyInstance, forceInconsistency bool) error {
+
+	promoteOpts := map[string]interface{}{
+		"force_inconsistency": forceInconsistency,
+	}
+
+	req := connector.EvalReq(promoteLeaderBody, topologyReplicaset.UUID, topologyInstance.UUID, promoteOpts).
+		SetReadTimeout(cluster.SimpleOperationTimeout)
+
+	result, err := conn.Exec(req)
+	if err != nil {
+		return fmt.Errorf("Failed to promote leader: %s", err)
+	}
+
+	if len(result) == 2 {
+		if funcErr := result[1]; funcErr != nil {
+			return fmt.Errorf("Failed to promote leader: %s", funcErr)
+		}
+	}
+
+	return nil
+}
+
+func getInstancesReplicationStatus(conn *connector.Conn, uris []string) ([]*InstanceReplicationStatus, error) {
+	// Instances are polled in parallel, so the request time doesn't depend
+	// on the number of instances, only on the instance status timeout
+	req := connector.EvalReq(getInstancesReplicationStatusBody, uris, instanceStatusTimeout.Seconds()).
+		SetReadTimeout(instanceStatusTimeout + cluster.SimpleOperationTimeout)
+
+	var instancesStatus []*InstanceReplicationStatus
+	if err := conn.ExecTyped(req, &instancesStatus); err != nil {
+		return nil, fmt.Errorf("Failed to get instances replication status: %s", err)
+	}
+
+	return instancesStatus, nil
+}
+
+// getSwitchoverCandidate returns the most up-to-date replica of the replica set.
+// Disabled, expelled and unhealthy replicas are skipped.
+// The replica which vclock dominates vclocks of all other replicas
+// (i.e. each component is greater than or equal) is chosen.
+// If there is no such replica (replicas have diverged) or several replicas
+// have equal vclocks, the first one by failover priority is chosen
+func getSwitchoverCandidate(topologyReplicaset *replicasets.TopologyReplicaset,
+	instancesStatus []*InstanceReplicationStatus) (*replicasets.TopologyInstance, error) {
+
+	instancesStatusByURI := make(map[string]*InstanceReplicationStatus)
+	for _, instanceStatus := range instancesStatus {
+		instancesStatusByURI[instanceStatus.URI] = instanceStatus
+	}
+
+	// topology instances are sorted by failover priority
+	var candidates []*replicasets.TopologyInstance
+	var candidatesVclocks []map[string]uint64
+
+	for _, topologyInstance := range topologyReplicaset.Instances {
+		if topologyInstance.UUID == topologyReplicaset.LeaderUUID {
+			continue
+		}
+
+		if topologyInstance.Disabled || topologyInstance.Expelled {
+			log.Debugf("Instance %s is disabled or expelled", topologyInstance.Alias)
+			continue
+		}
+
+		if topologyInstance.Status != "" && topologyInstance.Status != healthyInstanceStatus {
+			log.Debugf("Instance %s is %s", topologyInstance.Alias, topologyInstance.Status)
+			continue
+		}
+
+		instanceStatus, found := instancesStatusByURI[topologyInstance.URI]
+		if !found {
+			log.Debugf("Replication status of %s isn't received", topologyInstance.Alias)
+			continue
+		}
+
+		if instanceStatus.Error != "" {
+			log.Debugf("Failed to get replication status of %s: %s", topologyInstance.Alias, instanceStatus.Error)
+			continue
+		}
+
+		candidates = append(candidates, topologyInstance)
+		candidatesVclocks = append(candidatesVclocks, instanceStatus.Vclock)
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("No available replicas found")
+	}
+
+	for i, vclock := range candidatesVclocks {
+		dominates := true
+		for _, otherVclock := range candidatesVclocks {
+			if !vclockDominates(vclock, otherVclock) {
+				dominates = false
+				break
+			}
+		}
+
+		if dominates {
+			return candidates[i], nil
+		}
+	}
+
+	log.Warnf("Replicas vclocks have diverged, the first replica by failover priority is chosen")
+
+	return candidates[0], nil
+}
+
+// vclockDominates returns true if each component of the vclock
+// is greater than or equal to the same component of the other one
+func vclockDominates(vclock, otherVclock map[string]uint64) bool {
+	for id, otherLSN := range otherVclock {
+		if vclock[id] < otherLSN {
+			return false
+		}
+	}
+
+	return true
+}
+
+func waitInstanceIsWritable(conn *connector.Conn, uri string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		instancesStatus, err := getInstancesReplicationStatus(conn, []string{uri})
+		if err != nil {
+			return err
+		}
+
+		reason := "instance is read-only"
+		if len(instancesStatus) != 1 {
+			reason = "instance status isn't received"
+		} else if instancesStatus[0].Error != "" {
+			reason = instancesStatus[0].Error
+		} else if !instancesStatus[0].RO {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Timeout %s was reached while waiting for the new leader to become writable: %s",
+				timeout, reason)
+		}
+
+		time.Sleep(waitWritableCheckInterval)
+	}
+}
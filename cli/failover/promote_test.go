@@ -0,0 +1,91 @@
+package failover
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tarantool/cartridge-cli/cli/replicasets"
+)
+
+func TestGetSwitchoverCandidate(t *testing.T) {
+	assert := assert.New(t)
+
+	topologyReplicaset := &replicasets.TopologyReplicaset{
+		UUID:  "rpl-uuid",
+		Alias: "storage",
+		Instances: replicasets.TopologyInstances{
+			{Alias: "storage-1", UUID: "uuid-1", URI: "localhost:3301"},
+			{Alias: "storage-2", UUID: "uuid-2", URI: "localhost:3302"},
+			{Alias: "storage-3", UUID: "uuid-3", URI: "localhost:3303"},
+		},
+		LeaderUUID: "uuid-1",
+	}
+
+	// the most up-to-date replica is chosen, leader is skipped
+	candidate, err := getSwitchoverCandidate(topologyReplicaset, []*InstanceReplicationStatus{
+		{URI: "localhost:3301", Vclock: map[string]uint64{"1": 100, "2": 10}},
+		{URI: "localhost:3302", Vclock: map[string]uint64{"1": 90, "2": 10}},
+		{URI: "localhost:3303", Vclock: map[string]uint64{"1": 95, "2": 10}},
+	})
+	assert.Nil(err)
+	assert.Equal("storage-3", candidate.Alias)
+
+	// replicas with equal vclocks are chosen by failover priority
+	candidate, err = getSwitchoverCandidate(topologyReplicaset, []*InstanceReplicationStatus{
+		{URI: "localhost:3303", Vclock: map[string]uint64{"1": 100}},
+		{URI: "localhost:3302", Vclock: map[string]uint64{"1": 100}},
+	})
+	assert.Nil(err)
+	assert.Equal("storage-2", candidate.Alias)
+
+	// unavailable replicas are skipped
+	candidate, err = getSwitchoverCandidate(topologyReplicaset, []*InstanceReplicationStatus{
+		{URI: "localhost:3302", Error: "Connection refused"},
+		{URI: "localhost:3303", Vclock: map[string]uint64{"1": 10}},
+	})
+	assert.Nil(err)
+	assert.Equal("storage-3", candidate.Alias)
+
+	// replica which vclock dominates is chosen regardless of the vclock sum
+	candidate, err = getSwitchoverCandidate(topologyReplicaset, []*InstanceReplicationStatus{
+		{URI: "localhost:3302", Vclock: map[string]uint64{"1": 100, "2": 10}},
+		{URI: "localhost:3303", Vclock: map[string]uint64{"1": 100, "2": 10, "3": 1}},
+	})
+	assert.Nil(err)
+	assert.Equal("storage-3", candidate.Alias)
+
+	// diverged replicas are chosen by failover priority
+	candidate, err = getSwitchoverCandidate(topologyReplicaset, []*InstanceReplicationStatus{
+		{URI: "localhost:3302", Vclock: map[string]uint64{"1": 100, "2": 5}},
+		{URI: "localhost:3303", Vclock: map[string]uint64{"1": 90, "2": 50}},
+	})
+	assert.Nil(err)
+	assert.Equal("storage-2", candidate.Alias)
+
+	// no available replicas
+	_, err = getSwitchoverCandidate(topologyReplicaset, []*InstanceReplicationStatus{
+		{URI: "localhost:3301", Vclock: map[string]uint64{"1": 100}},
+		{URI: "localhost:3302", Error: "Connection refused"},
+	})
+	assert.EqualError(err, "No available replicas found")
+
+	// disabled and unhealthy replicas are skipped
+	topologyReplicaset.Instances[1].Disabled = true
+	topologyReplicaset.Instances[2].Status = "unhealthy"
+	_, err = getSwitchoverCandidate(topologyReplicaset, []*InstanceReplicationStatus{
+		{URI: "localhost:3302", Vclock: map[string]uint64{"1": 100}},
+		{URI: "localhost:3303", Vclock: map[string]uint64{"1": 100}},
+	})
+	assert.EqualError(err, "No available replicas found")
+}
+
+func TestVclockDominates(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(vclockDominates(nil, nil))
+	assert.True(vclockDominates(map[string]uint64{"1": 100}, nil))
+	assert.True(vclockDominates(map[string]uint64{"1": 100, "2": 10}, map[string]uint64{"1": 100, "2": 10}))
+	assert.True(vclockDominates(map[string]uint64{"1": 100, "2": 10}, map[string]uint64{"1": 90}))
+	assert.False(vclockDominates(map[string]uint64{"1": 100}, map[string]uint64{"1": 90, "2": 1}))
+	assert.False(vclockDominates(map[string]uint64{"1": 100, "2": 5}, map[string]uint64{"1": 90, "2": 50}))
+}
@@ -1,7 +1,6 @@
 package failover
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/apex/log"
@@ -39,47 +38,65 @@ func Set(ctx *context.Ctx) error {
 }
 
 func getFailoverOpts(ctx *context.Ctx) (*FailoverOpts, error) {
-	failoverOpts, err := initFailoverOpts(ctx)
+	failoverConfig, err := getFailoverConfig(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if (*failoverOpts)["mode"] == "stateful" {
-		if _, found := (*failoverOpts)["state_provider"]; found && ctx.Failover.ProviderParamsJSON != "" {
-			var providerParams ProviderParams
-			if err := json.Unmarshal([]byte(ctx.Failover.ProviderParamsJSON), &providerParams); err != nil {
+	return failoverConfig.ToFailoverOpts()
+}
+
+func getFailoverConfig(ctx *context.Ctx) (*FailoverConfig, error) {
+	failoverConfig, err := initFailoverConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if failoverConfig.Mode == "stateful" && ctx.Failover.ProviderParamsJSON != "" {
+		providerParamsJSON := []byte(ctx.Failover.ProviderParamsJSON)
+
+		switch failoverConfig.StateProvider {
+		case "stateboard":
+			var stateboardParams StateboardParams
+			if err := decodeStrict(providerParamsJSON, &stateboardParams); err != nil {
 				return nil, fmt.Errorf("Failed to parse provider parameters: %s", err)
 			}
 
-			if (*failoverOpts)["state_provider"] == "stateboard" {
-				(*failoverOpts)["stateboard_params"] = providerParams
-			} else if (*failoverOpts)["state_provider"] == "etcd2" {
-				(*failoverOpts)["etcd2_params"] = providerParams
+			failoverConfig.StateboardParams = &stateboardParams
+		case "etcd2":
+			var etcd2Params Etcd2Params
+			if err := decodeStrict(providerParamsJSON, &etcd2Params); err != nil {
+				return nil, fmt.Errorf("Failed to parse provider parameters: %s", err)
 			}
+
+			failoverConfig.Etcd2Params = &etcd2Params
 		}
 	}
 
-	if err := validateSetFailoverOpts(failoverOpts); err != nil {
+	if err := validateSetFailoverConfig(failoverConfig); err != nil {
 		return nil, err
 	}
 
-	return failoverOpts, nil
+	return failoverConfig, nil
 }
 
-func initFailoverOpts(ctx *context.Ctx) (*FailoverOpts, error) {
-	failoverOpts := FailoverOpts{
-		"mode": ctx.Failover.Mode,
-	}
+func initFailoverConfig(ctx *context.Ctx) (*FailoverConfig, error) {
+	failoverConfig := FailoverConfig{}
 
 	if ctx.Failover.ParamsJSON != "" {
-		if err := json.Unmarshal([]byte(ctx.Failover.ParamsJSON), &failoverOpts); err != nil {
+		if err := decodeStrict([]byte(ctx.Failover.ParamsJSON), &failoverConfig); err != nil {
 			return nil, fmt.Errorf("Failed to parse failover parameters: %s", err)
 		}
 	}
 
+	// mode specified in parameters has a priority
+	if failoverConfig.Mode == "" {
+		failoverConfig.Mode = ctx.Failover.Mode
+	}
+
 	if ctx.Failover.StateProvider != "" {
-		failoverOpts["state_provider"] = ctx.Failover.StateProvider
+		failoverConfig.StateProvider = ctx.Failover.StateProvider
 	}
 
-	return &failoverOpts, nil
+	return &failoverConfig, nil
 }
@@ -38,3 +38,57 @@ func TestBadValidateFailoverSet(t *testing.T) {
 	_, err = getFailoverOpts(&ctx)
 	assert.Equal("Please, specify --state-provider flag when using stateful mode", err.Error())
 }
+
+func TestBadFailoverSetParams(t *testing.T) {
+	assert := assert.New(t)
+
+	// Misprint in failover parameters
+	ctx := context.Ctx{}
+	ctx.Failover.Mode = "eventual"
+	ctx.Failover.ParamsJSON = `{"fencing_enabled": true, "fencing_timout": 10}`
+	_, err := getFailoverOpts(&ctx)
+	assert.EqualError(err, `Failed to parse failover parameters: line 1, column 27: `+
+		`Unknown parameter "fencing_timout", did you mean "fencing_timeout"?`)
+
+	// Invalid parameter type
+	ctx = context.Ctx{}
+	ctx.Failover.Mode = "eventual"
+	ctx.Failover.ParamsJSON = `{"failover_timeout": "10"}`
+	_, err = getFailoverOpts(&ctx)
+	assert.EqualError(err, `Failed to parse failover parameters: line 1, column 22: "failover_timeout" should be a number`)
+
+	// Unknown stateboard parameter
+	ctx = context.Ctx{}
+	ctx.Failover.Mode = "stateful"
+	ctx.Failover.StateProvider = "stateboard"
+	ctx.Failover.ProviderParamsJSON = `{"uri": "localhost:4401", "password": "passwd", "lock_delay": 10}`
+	_, err = getFailoverOpts(&ctx)
+	assert.EqualError(err, `Failed to parse provider parameters: line 1, column 49: Unknown parameter "lock_delay"`)
+
+	// Invalid etcd2 parameter value
+	ctx = context.Ctx{}
+	ctx.Failover.Mode = "stateful"
+	ctx.Failover.StateProvider = "etcd2"
+	ctx.Failover.ProviderParamsJSON = `{"lock_delay": -10}`
+	_, err = getFailoverOpts(&ctx)
+	assert.EqualError(err, `Failed to parse provider parameters: line 1, column 16: "lock_delay" should be positive`)
+
+	// Valid parameters
+	ctx = context.Ctx{}
+	ctx.Failover.Mode = "stateful"
+	ctx.Failover.StateProvider = "etcd2"
+	ctx.Failover.ParamsJSON = `{"fencing_enabled": true, "fencing_timeout": 10}`
+	ctx.Failover.ProviderParamsJSON = `{"prefix": "/app", "endpoints": ["http://localhost:2379"]}`
+	failoverOpts, err := getFailoverOpts(&ctx)
+	assert.Nil(err)
+	assert.Equal(&FailoverOpts{
+		"mode":            "stateful",
+		"state_provider":  "etcd2",
+		"fencing_enabled": true,
+		"fencing_timeout": 10,
+		"etcd2_params": map[string]interface{}{
+			"prefix":    "/app",
+			"endpoints": []interface{}{"http://localhost:2379"},
+		},
+	}, failoverOpts)
+}
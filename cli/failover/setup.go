@@ -9,7 +9,6 @@ import (
 	"github.com/tarantool/cartridge-cli/cli/common"
 	"github.com/tarantool/cartridge-cli/cli/context"
 	"github.com/tarantool/cartridge-cli/cli/project"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -59,10 +58,14 @@ func getFailoverOptsFromFile(ctx *context.Ctx) (*FailoverOpts, error) {
 		return nil, fmt.Errorf("Failed to read %s file: %s", ctx.Failover.File, err)
 	}
 
-	var failoverOpts FailoverOpts
-	if err := yaml.Unmarshal(fileContent, &failoverOpts); err != nil {
-		return nil, fmt.Errorf("Failed to parse failover configurations: %s", err)
+	var failoverConfig FailoverConfig
+	if err := decodeStrict(fileContent, &failoverConfig); err != nil {
+		return nil, err
 	}
 
-	return &failoverOpts, nil
+	if failoverConfig.Mode == "" {
+		return nil, fmt.Errorf("Failover mode should be specified")
+	}
+
+	return failoverConfig.ToFailoverOpts()
 }
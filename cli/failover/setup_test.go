@@ -1,6 +1,7 @@
 package failover
 
 import (
+	"io/ioutil"
 	"os"
 	"testing"
 
@@ -57,7 +58,7 @@ func TestValidateFailoverYMLFile(t *testing.T) {
 				"password": "pass",
 			},
 			"etcd2_params": map[string]interface{}{
-				"uri":      "uri",
+				"prefix":   "prefix",
 				"password": "pass",
 			},
 		},
@@ -100,6 +101,130 @@ func TestValidateFailoverYMLFile(t *testing.T) {
 	}
 }
 
+func TestValidateInvalidFailoverYMLFile(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Ctx{}
+	ctx.Failover.File = "failover_validate_test"
+	defer os.Remove(ctx.Failover.File)
+
+	invalidConfigurations := []struct {
+		Content string
+		Err     string
+	}{
+		// Misprint in parameter name
+		{
+			"mode: eventual\nfailover_timout: 10\n",
+			`line 2, column 1: Unknown parameter "failover_timout", did you mean "failover_timeout"?`,
+		},
+		// Unknown parameter
+		{
+			"mode: eventual\nsome_param: 10\n",
+			`line 2, column 1: Unknown parameter "some_param"`,
+		},
+		// Unknown provider parameter
+		{
+			"mode: stateful\nstate_provider: etcd2\netcd2_params:\n  prefix: /\n  uri: localhost:2379\n",
+			`line 5, column 3: Unknown parameter "etcd2_params.uri"`,
+		},
+		// No mode
+		{
+			"failover_timeout: 10\n",
+			"Failover mode should be specified",
+		},
+		// Invalid mode
+		{
+			"mode: some-mode\n",
+			`line 1, column 7: "mode" should be one of: stateful, eventual, raft, disabled`,
+		},
+		// Invalid state provider
+		{
+			"mode: stateful\nstate_provider: etcd3\n",
+			`line 2, column 17: "state_provider" should be one of: stateboard, etcd2`,
+		},
+		// Invalid types
+		{
+			"mode: eventual\nfailover_timeout: ten\n",
+			`line 2, column 19: "failover_timeout" should be a number`,
+		},
+		{
+			"mode: eventual\nfencing_enabled: 1\n",
+			`line 2, column 18: "fencing_enabled" should be a boolean`,
+		},
+		{
+			"mode: stateful\netcd2_params:\n  endpoints: http://localhost:2379\n",
+			`line 3, column 14: "etcd2_params.endpoints" should be a list`,
+		},
+		{
+			"mode: stateful\netcd2_params:\n  endpoints:\n  - http://localhost:2379\n  - 2379\n",
+			`line 5, column 5: "etcd2_params.endpoints[1]" should be a string`,
+		},
+		{
+			"mode: stateful\nstateboard_params: localhost:4401\n",
+			`line 2, column 20: "stateboard_params" should be a map`,
+		},
+		{
+			"- mode: stateful\n",
+			`line 1, column 1: Configuration should be a map`,
+		},
+		// Invalid values
+		{
+			"mode: eventual\nfencing_timeout: 0\n",
+			`line 2, column 18: "fencing_timeout" should be positive`,
+		},
+		{
+			"mode: stateful\nautoreturn_delay: -1\n",
+			`line 2, column 19: "autoreturn_delay" shouldn't be negative`,
+		},
+		// Required provider parameter is missed
+		{
+			"mode: stateful\nstate_provider: stateboard\nstateboard_params:\n  uri: localhost:4401\n",
+			`line 4, column 3: "stateboard_params.password" is required`,
+		},
+		// Empty file
+		{
+			"",
+			"Configuration is empty",
+		},
+	}
+
+	for _, conf := range invalidConfigurations {
+		assert.Nil(ioutil.WriteFile(ctx.Failover.File, []byte(conf.Content), 0644))
+
+		_, err := getFailoverOptsFromFile(&ctx)
+		assert.EqualError(err, conf.Err, conf.Content)
+	}
+}
+
+func TestFailoverConfigToFailoverOpts(t *testing.T) {
+	assert := assert.New(t)
+
+	var failoverConfig FailoverConfig
+	err := decodeStrict([]byte(`
+mode: stateful
+state_provider: stateboard
+failover_timeout: 20
+fencing_enabled: false
+stateboard_params:
+  uri: localhost:4401
+  password: passwd
+`), &failoverConfig)
+	assert.Nil(err)
+
+	failoverOpts, err := failoverConfig.ToFailoverOpts()
+	assert.Nil(err)
+	assert.Equal(&FailoverOpts{
+		"mode":             "stateful",
+		"state_provider":   "stateboard",
+		"failover_timeout": 20,
+		"fencing_enabled":  false,
+		"stateboard_params": map[string]interface{}{
+			"uri":      "localhost:4401",
+			"password": "passwd",
+		},
+	}, failoverOpts)
+}
+
 func createYmlFileWithContent(fileName string, content map[string]interface{}) error {
 	failoverFile, err := os.Create(fileName)
 	if err != nil {
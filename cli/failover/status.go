@@ -2,11 +2,16 @@ package failover
 
 import (
 	"fmt"
+	"os"
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/apex/log"
+	"github.com/mattn/go-isatty"
+	"github.com/vmihailenco/msgpack/v5"
+
 	"github.com/tarantool/cartridge-cli/cli/cluster"
 	"github.com/tarantool/cartridge-cli/cli/common"
 	"github.com/tarantool/cartridge-cli/cli/connector"
@@ -14,28 +19,209 @@ import (
 	"github.com/tarantool/cartridge-cli/cli/project"
 )
 
+type FailoverInstance struct {
+	Alias  string `mapstructure:"alias"`
+	URI    string `mapstructure:"uri"`
+	Status string `mapstructure:"status"`
+}
+
+type FailoverLeader struct {
+	ReplicasetAlias string `mapstructure:"replicaset_alias"`
+	Alias           string `mapstructure:"alias"`
+	URI             string `mapstructure:"uri"`
+}
+
+// FailoverState describes the live failover state
+// as it's seen by the instance CLI is connected to
+type FailoverState struct {
+	Mode    string            `mapstructure:"mode"`
+	Leaders []*FailoverLeader `mapstructure:"leaders"`
+	// LeadersFromStateProvider is true if leaders are received from
+	// the state provider, otherwise they are taken from the local topology
+	LeadersFromStateProvider bool `mapstructure:"leaders_from_state_provider"`
+
+	Coordinator            *FailoverInstance `mapstructure:"coordinator"`
+	StateProvider          string            `mapstructure:"state_provider"`
+	StateProviderConnected bool              `mapstructure:"state_provider_connected"`
+	StateProviderError     string            `mapstructure:"state_provider_error"`
+
+	Suspected []*FailoverInstance `mapstructure:"suspected"`
+
+	// Suppressed and Paused are nil if Cartridge doesn't support them
+	Suppressed *bool `mapstructure:"suppressed"`
+	Paused     *bool `mapstructure:"paused"`
+}
+
+func (failoverState *FailoverState) DecodeMsgpack(d *msgpack.Decoder) error {
+	return common.DecodeMsgpackStruct(d, failoverState)
+}
+
 func Status(ctx *context.Ctx) error {
 	if err := project.FillCtx(ctx); err != nil {
 		return err
 	}
 
+	if !ctx.Failover.Watch {
+		return printStatus(ctx)
+	}
+
+	for {
+		if isatty.IsTerminal(os.Stdout.Fd()) {
+			// clear the screen
+			fmt.Print("\033[H\033[2J")
+		}
+
+		fmt.Printf("Every %s: cartridge failover status    %s\n\n",
+			ctx.Failover.WatchInterval, time.Now().Format(time.RFC1123))
+
+		// the cluster can be unavailable for a while during incidents,
+		// so errors don't stop watching
+		if err := printStatus(ctx); err != nil {
+			log.Errorf("%s", err)
+		}
+
+		select {
+		case <-ctx.Cli.Context.Done():
+			return nil
+		case <-time.After(ctx.Failover.WatchInterval):
+		}
+	}
+}
+
+func printStatus(ctx *context.Ctx) error {
 	conn, err := cluster.ConnectToSomeRunningInstance(ctx)
 	if err != nil {
 		return fmt.Errorf("Failed to connect to some instance: %s", err)
 	}
+	defer conn.Close()
 
 	var result []map[string]interface{}
 	if err := conn.ExecTyped(connector.EvalReq(getFailoverParamsBody), &result); err != nil {
 		return fmt.Errorf("Failed to get current failover status: %s", err)
 	}
 
+	failoverState, err := getFailoverState(conn)
+	if err != nil {
+		return err
+	}
+
 	log.Infof("Current failover status: ")
 
 	print(getFailoverStatusPrettyString(result[0]))
 
+	log.Infof("Current failover state: ")
+
+	print(getFailoverStateSummary(failoverState))
+
 	return nil
 }
 
+func getFailoverState(conn *connector.Conn) (*FailoverState, error) {
+	req := connector.EvalReq(getFailoverStateBody).SetReadTimeout(cluster.SimpleOperationTimeout)
+
+	var result []*FailoverState
+	if err := conn.ExecTyped(req, &result); err != nil {
+		return nil, fmt.Errorf("Failed to get current failover state: %s", err)
+	}
+
+	if len(result) != 1 {
+		return nil, fmt.Errorf("Failed to get current failover state: unexpected response %v", result)
+	}
+
+	return result[0], nil
+}
+
+func getFailoverStateSummary(failoverState *FailoverState) string {
+	// example state summary:
+	//
+	//  • active leaders:
+	//      • router: router (localhost:3301)
+	//      • s-1: s1-master (localhost:3302)
+	//  • coordinator: router (localhost:3301)
+	//  • state provider: stateboard | connected
+	//  • suspected instances:
+	//      • s1-replica (localhost:3303): dead
+	//  • suppressed: false
+
+	leadersStr := common.ColorCyan.Sprint("active leaders")
+	if failoverState.Mode == "stateful" && !failoverState.LeadersFromStateProvider {
+		leadersStr += common.ColorWarn.Sprint(" (from local topology, state provider is unavailable)")
+	}
+
+	summary := []string{fmt.Sprintf(" • %s:", leadersStr)}
+
+	for _, leader := range failoverState.Leaders {
+		leaderStr := common.ColorErr.Sprint("no leader")
+		if leader.URI != "" {
+			leaderStr = fmt.Sprintf("%s (%s)", leader.Alias, leader.URI)
+		}
+
+		summary = append(summary, fmt.Sprintf("     • %s: %s", leader.ReplicasetAlias, leaderStr))
+	}
+
+	if failoverState.Mode == "stateful" {
+		coordinatorStr := common.ColorWarn.Sprint("none")
+		if failoverState.Coordinator != nil {
+			coordinatorStr = getFailoverInstanceString(failoverState.Coordinator)
+		} else if !failoverState.StateProviderConnected {
+			coordinatorStr = common.ColorWarn.Sprint("unknown")
+		}
+
+		summary = append(summary, fmt.Sprintf(" • %s: %s", common.ColorCyan.Sprint("coordinator"), coordinatorStr))
+
+		stateProvider := failoverState.StateProvider
+		if stateProvider == "tarantool" {
+			stateProvider = "stateboard"
+		}
+
+		connectivityStr := common.ColorGreen.Sprint("connected")
+		if !failoverState.StateProviderConnected {
+			connectivityStr = common.ColorErr.Sprintf("not connected: %s", failoverState.StateProviderError)
+		}
+
+		summary = append(summary, fmt.Sprintf(" • %s: %s | %s",
+			common.ColorCyan.Sprint("state provider"), stateProvider, connectivityStr,
+		))
+	}
+
+	if len(failoverState.Suspected) == 0 {
+		summary = append(summary, fmt.Sprintf(" • %s: %s",
+			common.ColorCyan.Sprint("suspected instances"), common.ColorGreen.Sprint("none"),
+		))
+	} else {
+		summary = append(summary, fmt.Sprintf(" • %s:", common.ColorCyan.Sprint("suspected instances")))
+
+		for _, instance := range failoverState.Suspected {
+			statusColor := common.ColorErr
+			if instance.Status == "suspect" {
+				statusColor = common.ColorWarn
+			}
+
+			summary = append(summary, fmt.Sprintf("     • %s: %s",
+				getFailoverInstanceString(instance), statusColor.Sprint(instance.Status),
+			))
+		}
+	}
+
+	if failoverState.Suppressed != nil {
+		summary = append(summary, fmt.Sprintf(" • %s: %t", common.ColorCyan.Sprint("suppressed"), *failoverState.Suppressed))
+	}
+
+	if failoverState.Paused != nil {
+		summary = append(summary, fmt.Sprintf(" • %s: %t", common.ColorCyan.Sprint("paused"), *failoverState.Paused))
+	}
+
+	return strings.Join(summary, "\n") + "\n"
+}
+
+func getFailoverInstanceString(instance *FailoverInstance) string {
+	if instance.Alias == "" {
+		return instance.URI
+	}
+
+	return fmt.Sprintf("%s (%s)", instance.Alias, instance.URI)
+}
+
 func getFailoverStatusPrettyString(resultMap map[string]interface{}) string {
 	if _, found := resultMap["tarantool_params"]; found {
 		resultMap["stateboard_params"] = resultMap["tarantool_params"]
@@ -0,0 +1,78 @@
+package failover
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFailoverStateSummary(t *testing.T) {
+	assert := assert.New(t)
+
+	color.NoColor = true
+
+	suppressed := false
+
+	// stateful failover
+	failoverState := &FailoverState{
+		Mode: "stateful",
+		Leaders: []*FailoverLeader{
+			{ReplicasetAlias: "router", Alias: "router", URI: "localhost:3301"},
+			{ReplicasetAlias: "s-1", Alias: "s1-master", URI: "localhost:3302"},
+			{ReplicasetAlias: "s-2"},
+		},
+		LeadersFromStateProvider: true,
+		Coordinator:              &FailoverInstance{Alias: "router", URI: "localhost:3301"},
+		StateProvider:            "tarantool",
+		StateProviderConnected:   true,
+		Suspected: []*FailoverInstance{
+			{Alias: "s1-replica", URI: "localhost:3303", Status: "dead"},
+			{URI: "localhost:3304", Status: "suspect"},
+		},
+		Suppressed: &suppressed,
+	}
+
+	assert.Equal(` • active leaders:
+     • router: router (localhost:3301)
+     • s-1: s1-master (localhost:3302)
+     • s-2: no leader
+ • coordinator: router (localhost:3301)
+ • state provider: stateboard | connected
+ • suspected instances:
+     • s1-replica (localhost:3303): dead
+     • localhost:3304: suspect
+ • suppressed: false
+`, getFailoverStateSummary(failoverState))
+
+	// state provider isn't available
+	failoverState = &FailoverState{
+		Mode: "stateful",
+		Leaders: []*FailoverLeader{
+			{ReplicasetAlias: "s-1", Alias: "s1-master", URI: "localhost:3302"},
+		},
+		StateProvider:      "etcd2",
+		StateProviderError: "Connection refused",
+	}
+
+	assert.Equal(` • active leaders (from local topology, state provider is unavailable):
+     • s-1: s1-master (localhost:3302)
+ • coordinator: unknown
+ • state provider: etcd2 | not connected: Connection refused
+ • suspected instances: none
+`, getFailoverStateSummary(failoverState))
+
+	// eventual failover
+	failoverState = &FailoverState{
+		Mode: "eventual",
+		Leaders: []*FailoverLeader{
+			{ReplicasetAlias: "s-1", Alias: "s1-master", URI: "localhost:3302"},
+		},
+		StateProviderError: "should be ignored",
+	}
+
+	assert.Equal(` • active leaders:
+     • s-1: s1-master (localhost:3302)
+ • suspected instances: none
+`, getFailoverStateSummary(failoverState))
+}
@@ -9,18 +9,18 @@ var (
 	exampleStateboardParamsJSON = `{"uri": "localhost:4401", "password": "passwd"}`
 )
 
-func validateSetFailoverOpts(opts *FailoverOpts) error {
-	switch (*opts)["mode"] {
+func validateSetFailoverConfig(config *FailoverConfig) error {
+	switch config.Mode {
 	case "eventual":
-		if err := validateEventualMode(opts); err != nil {
+		if err := validateEventualMode(config); err != nil {
 			return err
 		}
 	case "stateful":
-		if err := validateStatefulMode(opts); err != nil {
+		if err := validateStatefulMode(config); err != nil {
 			return err
 		}
 	case "raft":
-		if err := validateEventualMode(opts); err != nil {
+		if err := validateEventualMode(config); err != nil {
 			return err
 		}
 	case "disabled":
@@ -32,22 +32,22 @@ func validateSetFailoverOpts(opts *FailoverOpts) error {
 	return nil
 }
 
-func validateEventualMode(opts *FailoverOpts) error {
-	if _, found := (*opts)["state_provider"]; found {
+func validateEventualMode(config *FailoverConfig) error {
+	if config.StateProvider != "" {
 		return fmt.Errorf(eventualModeParamsError, "state-provider")
 	}
 
 	return nil
 }
 
-func validateStatefulMode(opts *FailoverOpts) error {
-	if _, found := (*opts)["state_provider"]; !found {
+func validateStatefulMode(config *FailoverConfig) error {
+	if config.StateProvider == "" {
 		return fmt.Errorf("Please, specify --state-provider flag when using stateful mode")
 	}
 
-	switch (*opts)["state_provider"] {
+	switch config.StateProvider {
 	case "stateboard":
-		if _, found := (*opts)["stateboard_params"]; !found {
+		if config.StateboardParams == nil {
 			return fmt.Errorf(
 				"Please, specify params for stateboard state provider, using --provider-params '%s'",
 				exampleStateboardParamsJSON,
@@ -0,0 +1,246 @@
+package profile
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/tarantool/cartridge-cli/cli/common"
+	"github.com/tarantool/cartridge-cli/cli/context"
+)
+
+const (
+	xdgConfigHomeEnv = "XDG_CONFIG_HOME"
+
+	profilesFileDir  = "cartridge"
+	profilesFileName = "profiles.yml"
+)
+
+// ProfilesConf describes named connection profiles.
+//
+// Example:
+//
+//	production:
+//	  hosts:
+//	    - name: router
+//	      uri: tarantool-1.example.com:3301
+//	    - name: storage
+//	      uri: tarantool-2.example.com:3302
+//	  default_instance: router
+//	  user: admin
+//	  password_env: PRODUCTION_PASSWORD
+//	  ssl:
+//	    ca_file: ~/.config/cartridge/production-ca.crt
+type ProfilesConf map[string]*Profile
+
+// Profile describes connection parameters of one cluster.
+// Only one of password sources can be specified
+type Profile struct {
+	Hosts           []*HostConf `yaml:"hosts"`
+	DefaultInstance string      `yaml:"default_instance"`
+
+	User            string `yaml:"user"`
+	Password        string `yaml:"password"`
+	PasswordEnv     string `yaml:"password_env"`
+	PasswordFile    string `yaml:"password_file"`
+	PasswordCommand string `yaml:"password_command"`
+
+	Transport string  `yaml:"transport"`
+	SSL       SSLConf `yaml:"ssl"`
+}
+
+// HostConf describes one instance of the cluster.
+// Name can be used instead of the URI in commands arguments
+type HostConf struct {
+	Name string `yaml:"name"`
+	URI  string `yaml:"uri"`
+}
+
+// SSLConf describes SSL transport options
+type SSLConf struct {
+	KeyFile    string `yaml:"key_file"`
+	CertFile   string `yaml:"cert_file"`
+	CaFile     string `yaml:"ca_file"`
+	Ciphers    string `yaml:"ciphers"`
+	ServerName string `yaml:"server_name"`
+}
+
+// GetProfilesFilePath returns the path to the profiles file:
+// $XDG_CONFIG_HOME/cartridge/profiles.yml or ~/.config/cartridge/profiles.yml
+func GetProfilesFilePath() (string, error) {
+	configDir := os.Getenv(xdgConfigHomeEnv)
+
+	if configDir == "" {
+		homeDir, err := common.GetHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("Failed to get home directory: %s", err)
+		}
+
+		configDir = filepath.Join(homeDir, ".config")
+	}
+
+	return filepath.Join(configDir, profilesFileDir, profilesFileName), nil
+}
+
+// GetProfile reads the profiles file and returns the profile with the specified name
+func GetProfile(profileName string) (*Profile, error) {
+	profilesFilePath, err := GetProfilesFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	profilesConf, err := getProfilesConf(profilesFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, found := profilesConf[profileName]
+	if !found || profile == nil {
+		return nil, fmt.Errorf("Profile %s isn't found in %s", profileName, profilesFilePath)
+	}
+
+	if err := profile.check(); err != nil {
+		return nil, fmt.Errorf("Invalid profile %s: %s", profileName, err)
+	}
+
+	return profile, nil
+}
+
+func getProfilesConf(profilesFilePath string) (ProfilesConf, error) {
+	if _, err := os.Stat(profilesFilePath); err != nil {
+		return nil, fmt.Errorf("Failed to use profiles file: %s", err)
+	}
+
+	content, err := common.GetFileContentBytes(profilesFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read profiles file: %s", err)
+	}
+
+	var profilesConf ProfilesConf
+	if err := yaml.UnmarshalStrict(content, &profilesConf); err != nil {
+		return nil, fmt.Errorf("Failed to parse profiles file %s: %s", profilesFilePath, err)
+	}
+
+	return profilesConf, nil
+}
+
+func (profile *Profile) check() error {
+	if len(profile.Hosts) == 0 {
+		return fmt.Errorf("No hosts specified")
+	}
+
+	addedNames := make(map[string]bool)
+	for i, hostConf := range profile.Hosts {
+		if hostConf == nil || hostConf.URI == "" {
+			return fmt.Errorf("URI isn't specified for hosts[%d]", i)
+		}
+
+		if hostConf.Name == "" {
+			continue
+		}
+
+		if addedNames[hostConf.Name] {
+			return fmt.Errorf("Host %s is specified more than once", hostConf.Name)
+		}
+
+		addedNames[hostConf.Name] = true
+	}
+
+	if profile.DefaultInstance != "" && !addedNames[profile.DefaultInstance] {
+		return fmt.Errorf("Default instance %s isn't found in hosts", profile.DefaultInstance)
+	}
+
+	passwordSourcesNum := 0
+	for _, passwordSource := range []string{
+		profile.Password, profile.PasswordEnv, profile.PasswordFile, profile.PasswordCommand,
+	} {
+		if passwordSource != "" {
+			passwordSourcesNum++
+		}
+	}
+
+	if passwordSourcesNum > 1 {
+		return fmt.Errorf("Only one of password, password_env, password_file and password_command can be specified")
+	}
+
+	return nil
+}
+
+// GetURI returns URI of the host with the specified name.
+// If there is no such host, the specified string is considered to be an URI itself.
+// If the name is empty, the default instance URI is returned
+func (profile *Profile) GetURI(name string) string {
+	if name == "" {
+		return profile.getDefaultURI()
+	}
+
+	for _, hostConf := range profile.Hosts {
+		if hostConf.Name == name {
+			return hostConf.URI
+		}
+	}
+
+	return name
+}
+
+// getDefaultURI returns URI of the default instance or the first host URI
+func (profile *Profile) getDefaultURI() string {
+	if profile.DefaultInstance != "" {
+		return profile.GetURI(profile.DefaultInstance)
+	}
+
+	return profile.Hosts[0].URI
+}
+
+// GetPassword returns the password from the specified source
+func (profile *Profile) GetPassword() (string, error) {
+	switch {
+	case profile.PasswordEnv != "":
+		password, found := os.LookupEnv(profile.PasswordEnv)
+		if !found {
+			return "", fmt.Errorf("Environment variable %s isn't set", profile.PasswordEnv)
+		}
+		return password, nil
+
+	case profile.PasswordFile != "":
+		passwordFilePath, err := common.ExpandHomeDir(profile.PasswordFile)
+		if err != nil {
+			return "", err
+		}
+
+		password, err := common.GetFileContent(passwordFilePath)
+		if err != nil {
+			return "", fmt.Errorf("Failed to read password file: %s", err)
+		}
+		return strings.TrimRight(password, "\r\n"), nil
+
+	case profile.PasswordCommand != "":
+		output, err := exec.Command("sh", "-c", profile.PasswordCommand).Output()
+		if err != nil {
+			return "", fmt.Errorf("Failed to get password using command: %s", err)
+		}
+		return strings.TrimRight(string(output), "\r\n"), nil
+
+	default:
+		return profile.Password, nil
+	}
+}
+
+// GetSSLCtx returns SSL options with expanded files paths
+func (profile *Profile) GetSSLCtx() (context.SSLCtx, error) {
+	sslCtx := context.SSLCtx(profile.SSL)
+
+	for _, path := range []*string{&sslCtx.KeyFile, &sslCtx.CertFile, &sslCtx.CaFile} {
+		expandedPath, err := common.ExpandHomeDir(*path)
+		if err != nil {
+			return sslCtx, err
+		}
+		*path = expandedPath
+	}
+
+	return sslCtx, nil
+}
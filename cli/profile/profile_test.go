@@ -0,0 +1,164 @@
+package profile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tarantool/cartridge-cli/cli/context"
+)
+
+func writeProfilesFile(t *testing.T, configDir string, content string) {
+	profilesDir := filepath.Join(configDir, profilesFileDir)
+	if err := os.MkdirAll(profilesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(profilesDir, profilesFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetProfile(t *testing.T) {
+	assert := assert.New(t)
+
+	configDir, err := ioutil.TempDir("", "config")
+	assert.Nil(err)
+	defer os.RemoveAll(configDir)
+
+	os.Setenv(xdgConfigHomeEnv, configDir)
+	defer os.Unsetenv(xdgConfigHomeEnv)
+
+	profilesFilePath, err := GetProfilesFilePath()
+	assert.Nil(err)
+	assert.Equal(filepath.Join(configDir, "cartridge", "profiles.yml"), profilesFilePath)
+
+	// no profiles file
+	_, err = GetProfile("production")
+	assert.Contains(err.Error(), "Failed to use profiles file")
+
+	writeProfilesFile(t, configDir, `
+production:
+  hosts:
+    - name: router
+      uri: tarantool-1.example.com:3301
+    - name: storage
+      uri: tarantool-2.example.com:3302
+  default_instance: storage
+  user: admin
+  password_env: TEST_PROFILE_PASSWORD
+  transport: ssl
+  ssl:
+    ca_file: /etc/ssl/ca.crt
+    server_name: tarantool
+staging:
+  hosts:
+    - uri: localhost:3301
+  password: secret
+bad-default:
+  hosts:
+    - uri: localhost:3301
+  default_instance: router
+several-passwords:
+  hosts:
+    - uri: localhost:3301
+  password: secret
+  password_env: TEST_PROFILE_PASSWORD
+`)
+
+	profile, err := GetProfile("production")
+	assert.Nil(err)
+	assert.Equal("admin", profile.User)
+	assert.Equal("tarantool-2.example.com:3302", profile.GetURI(""))
+	assert.Equal("tarantool-1.example.com:3301", profile.GetURI("router"))
+	assert.Equal("localhost:3301", profile.GetURI("localhost:3301"))
+
+	sslCtx, err := profile.GetSSLCtx()
+	assert.Nil(err)
+	assert.Equal(context.SSLCtx{CaFile: "/etc/ssl/ca.crt", ServerName: "tarantool"}, sslCtx)
+
+	// password from env
+	_, err = profile.GetPassword()
+	assert.EqualError(err, "Environment variable TEST_PROFILE_PASSWORD isn't set")
+
+	os.Setenv("TEST_PROFILE_PASSWORD", "env-secret")
+	defer os.Unsetenv("TEST_PROFILE_PASSWORD")
+
+	password, err := profile.GetPassword()
+	assert.Nil(err)
+	assert.Equal("env-secret", password)
+
+	// first host is used by default
+	profile, err = GetProfile("staging")
+	assert.Nil(err)
+	assert.Equal("localhost:3301", profile.GetURI(""))
+
+	password, err = profile.GetPassword()
+	assert.Nil(err)
+	assert.Equal("secret", password)
+
+	// errors
+	_, err = GetProfile("unknown")
+	assert.EqualError(err, "Profile unknown isn't found in "+profilesFilePath)
+
+	_, err = GetProfile("bad-default")
+	assert.EqualError(err, "Invalid profile bad-default: Default instance router isn't found in hosts")
+
+	_, err = GetProfile("several-passwords")
+	assert.EqualError(err, "Invalid profile several-passwords: "+
+		"Only one of password, password_env, password_file and password_command can be specified")
+
+	// unknown field
+	writeProfilesFile(t, configDir, `
+production:
+  hosts:
+    - uri: localhost:3301
+  passwd: secret
+`)
+
+	_, err = GetProfile("production")
+	assert.Contains(err.Error(), "Failed to parse profiles file")
+}
+
+func TestGetPassword(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "password")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	passwordFilePath := filepath.Join(dir, "password")
+	assert.Nil(ioutil.WriteFile(passwordFilePath, []byte("file-secret\n"), 0600))
+
+	var password string
+	var profile *Profile
+
+	// file
+	profile = &Profile{PasswordFile: passwordFilePath}
+	password, err = profile.GetPassword()
+	assert.Nil(err)
+	assert.Equal("file-secret", password)
+
+	profile = &Profile{PasswordFile: filepath.Join(dir, "unknown")}
+	_, err = profile.GetPassword()
+	assert.Contains(err.Error(), "Failed to read password file")
+
+	// command
+	profile = &Profile{PasswordCommand: "echo command-secret"}
+	password, err = profile.GetPassword()
+	assert.Nil(err)
+	assert.Equal("command-secret", password)
+
+	profile = &Profile{PasswordCommand: "exit 1"}
+	_, err = profile.GetPassword()
+	assert.Contains(err.Error(), "Failed to get password using command")
+
+	// no password
+	profile = &Profile{}
+	password, err = profile.GetPassword()
+	assert.Nil(err)
+	assert.Equal("", password)
+}
@@ -27,6 +27,11 @@ const (
 	defaultLogDir         = "/var/log/tarantool"
 	defaultAppsDir        = "/usr/share/tarantool/"
 	defaultStateboardFlag = false
+	defaultEtcdFlag       = false
+	defaultEtcdListen     = "localhost:2379"
+	defaultEtcdBinary     = "etcd"
+
+	defaultHistorySize = 10000
 
 	confPathSection       = "cfg"
 	runDirSection         = "run-dir"
@@ -35,6 +40,13 @@ const (
 	appsDirSection        = "apps-dir"
 	entrypointSection     = "script"
 	confStateboardSection = "stateboard"
+	confEtcdSection       = "etcd"
+	etcdListenSection     = "etcd-listen"
+	etcdBinarySection     = "etcd-binary"
+	etcdEmbeddedSection   = "etcd-embedded"
+	historyFileSection    = "history-file"
+	historySizeSection    = "history-size"
+	historyScopeSection   = "history-scope"
 )
 
 type PathOpts struct {
@@ -44,6 +56,12 @@ type PathOpts struct {
 	GetAbs          bool
 }
 
+type IntOpts struct {
+	SpecifiedValue  int
+	ConfSectionName string
+	DefaultValue    int
+}
+
 type FlagOpts struct {
 	SpecifiedFlag   bool
 	ConfSectionName string
@@ -69,6 +87,13 @@ func GetStateboardWorkDir(ctx *context.Ctx) string {
 	)
 }
 
+func GetEtcdWorkDir(ctx *context.Ctx) string {
+	return filepath.Join(
+		ctx.Running.DataDir,
+		GetEtcdName(ctx),
+	)
+}
+
 func GetInstancePidFile(ctx *context.Ctx, instanceName string) string {
 	pidFileName := fmt.Sprintf("%s.pid", GetInstanceID(ctx, instanceName))
 	return filepath.Join(
@@ -85,6 +110,14 @@ func GetStateboardPidFile(ctx *context.Ctx) string {
 	)
 }
 
+func GetEtcdPidFile(ctx *context.Ctx) string {
+	pidFileName := fmt.Sprintf("%s.pid", GetEtcdName(ctx))
+	return filepath.Join(
+		ctx.Running.RunDir,
+		pidFileName,
+	)
+}
+
 func GetInstanceConsoleSock(ctx *context.Ctx, instanceName string) string {
 	consoleSockName := fmt.Sprintf("%s.control", GetInstanceID(ctx, instanceName))
 	return filepath.Join(
@@ -117,6 +150,14 @@ func GetStateboardNotifySockPath(ctx *context.Ctx) string {
 	)
 }
 
+func GetEtcdNotifySockPath(ctx *context.Ctx) string {
+	notifySockName := fmt.Sprintf("%s.notify", GetEtcdName(ctx))
+	return filepath.Join(
+		ctx.Running.RunDir,
+		notifySockName,
+	)
+}
+
 func GetInstanceLogFile(ctx *context.Ctx, instanceName string) string {
 	return filepath.Join(
 		ctx.Running.LogDir,
@@ -131,6 +172,13 @@ func GetStateboardLogFile(ctx *context.Ctx) string {
 	)
 }
 
+func GetEtcdLogFile(ctx *context.Ctx) string {
+	return filepath.Join(
+		ctx.Running.LogDir,
+		fmt.Sprintf("%s.log", GetEtcdName(ctx)),
+	)
+}
+
 func GetAppEntrypointPath(ctx *context.Ctx) string {
 	return filepath.Join(ctx.Running.AppDir, ctx.Running.Entrypoint)
 }
@@ -156,6 +204,27 @@ func getFlag(conf map[string]interface{}, opts FlagOpts) (bool, error) {
 	return flag, nil
 }
 
+func getInt(conf map[string]interface{}, opts IntOpts) (int, error) {
+	var value int
+
+	if opts.SpecifiedValue != 0 {
+		value = opts.SpecifiedValue
+	} else if valueFromConf, found := conf[opts.ConfSectionName]; found {
+		var ok bool
+		if value, ok = valueFromConf.(int); !ok {
+			return 0, fmt.Errorf("%s config value should be integer", opts.ConfSectionName)
+		}
+	} else {
+		value = opts.DefaultValue
+	}
+
+	if value < 0 {
+		return 0, fmt.Errorf("%s value shouldn't be negative", opts.ConfSectionName)
+	}
+
+	return value, nil
+}
+
 func getPath(conf map[string]interface{}, opts PathOpts) (string, error) {
 	var path string
 	var err error
@@ -278,6 +347,69 @@ func SetLocalRunningPaths(ctx *context.Ctx) error {
 		return fmt.Errorf("Failed to detect stateboard flag: %s", err)
 	}
 
+	// set etcd options
+	ctx.Running.WithEtcd, err = getFlag(conf, FlagOpts{
+		SpecifiedFlag:   ctx.Running.WithEtcd,
+		ConfSectionName: confEtcdSection,
+		DefaultFlag:     defaultEtcdFlag,
+		FlagIsSet:       ctx.Running.EtcdFlagIsSet,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to detect etcd flag: %s", err)
+	}
+
+	ctx.Running.EtcdListen, err = getPath(conf, PathOpts{
+		SpecifiedPath:   ctx.Running.EtcdListen,
+		ConfSectionName: etcdListenSection,
+		DefaultPath:     defaultEtcdListen,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to detect etcd listen address: %s", err)
+	}
+
+	ctx.Running.EtcdBinary, err = getPath(conf, PathOpts{
+		SpecifiedPath:   ctx.Running.EtcdBinary,
+		ConfSectionName: etcdBinarySection,
+		DefaultPath:     defaultEtcdBinary,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to detect etcd binary: %s", err)
+	}
+
+	ctx.Running.EtcdEmbedded, err = getFlag(conf, FlagOpts{
+		SpecifiedFlag:   ctx.Running.EtcdEmbedded,
+		ConfSectionName: etcdEmbeddedSection,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to detect etcd-embedded flag: %s", err)
+	}
+
+	// set console history options
+	ctx.Connect.HistoryFile, err = getPath(conf, PathOpts{
+		SpecifiedPath:   ctx.Connect.HistoryFile,
+		ConfSectionName: historyFileSection,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to detect console history file: %s", err)
+	}
+
+	ctx.Connect.HistoryScope, err = getPath(conf, PathOpts{
+		SpecifiedPath:   ctx.Connect.HistoryScope,
+		ConfSectionName: historyScopeSection,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to detect console history scope: %s", err)
+	}
+
+	ctx.Connect.HistorySize, err = getInt(conf, IntOpts{
+		SpecifiedValue:  ctx.Connect.HistorySize,
+		ConfSectionName: historySizeSection,
+		DefaultValue:    defaultHistorySize,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to detect console history size: %s", err)
+	}
+
 	return nil
 }
 
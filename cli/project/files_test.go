@@ -127,3 +127,48 @@ func TestGetPath(t *testing.T) {
 	})
 	assert.True(strings.Contains(err.Error(), "config value should be string"))
 }
+
+func TestGetInt(t *testing.T) {
+	assert := assert.New(t)
+
+	var err error
+	var value int
+
+	const sectionName = "sectionName"
+
+	// value is specified
+	value, err = getInt(map[string]interface{}{sectionName: 5}, IntOpts{
+		SpecifiedValue:  10,
+		ConfSectionName: sectionName,
+		DefaultValue:    100,
+	})
+	assert.Nil(err)
+	assert.Equal(10, value)
+
+	// value from conf
+	value, err = getInt(map[string]interface{}{sectionName: 5}, IntOpts{
+		ConfSectionName: sectionName,
+		DefaultValue:    100,
+	})
+	assert.Nil(err)
+	assert.Equal(5, value)
+
+	// default value
+	value, err = getInt(nil, IntOpts{
+		ConfSectionName: sectionName,
+		DefaultValue:    100,
+	})
+	assert.Nil(err)
+	assert.Equal(100, value)
+
+	// bad values
+	_, err = getInt(map[string]interface{}{sectionName: "5"}, IntOpts{
+		ConfSectionName: sectionName,
+	})
+	assert.EqualError(err, "sectionName config value should be integer")
+
+	_, err = getInt(map[string]interface{}{sectionName: -1}, IntOpts{
+		ConfSectionName: sectionName,
+	})
+	assert.EqualError(err, "sectionName value shouldn't be negative")
+}
@@ -45,6 +45,10 @@ func GetStateboardName(ctx *context.Ctx) string {
 	return fmt.Sprintf("%s-stateboard", ctx.Project.Name)
 }
 
+func GetEtcdName(ctx *context.Ctx) string {
+	return fmt.Sprintf("%s-etcd", ctx.Project.Name)
+}
+
 func SetProjectPath(ctx *context.Ctx) error {
 	var err error
 
@@ -2,13 +2,12 @@ package repair
 
 import (
 	"fmt"
-	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/tarantool/cartridge-cli/cli/common"
 	"github.com/tarantool/cartridge-cli/cli/context"
-	"github.com/tarantool/cartridge-cli/cli/project"
 )
 
 type AppConfigs struct {
@@ -16,18 +15,24 @@ type AppConfigs struct {
 	instancesByHash      map[string][]string
 	confByHash           map[string]*TopologyConfType
 	confPathByInstanceID map[string]string
+	instanceByID         map[string]*appInstance
+	modTimeByHash        map[string]time.Time
 }
 
-func getAppConfigs(instanceNames []string, ctx *context.Ctx) (AppConfigs, error) {
+func getAppConfigs(instances []*appInstance, ctx *context.Ctx) (AppConfigs, error) {
 	var appConfigs AppConfigs
 	appConfigs.instancesByHash = make(map[string][]string)
 	appConfigs.confByHash = make(map[string]*TopologyConfType)
 	appConfigs.confPathByInstanceID = make(map[string]string)
+	appConfigs.instanceByID = make(map[string]*appInstance)
+	appConfigs.modTimeByHash = make(map[string]time.Time)
 
-	for _, instanceName := range instanceNames {
-		workDirPath := project.GetInstanceWorkDir(ctx, instanceName)
+	for _, instance := range instances {
+		fs := instance.Host.fs
+		instanceID := instance.ID()
+		workDirPath := instance.WorkDir(ctx)
 
-		topologyConfPath, err := getTopologyConfPath(workDirPath)
+		topologyConfPath, err := getTopologyConfPath(fs, workDirPath)
 		if err != nil {
 			return appConfigs, fmt.Errorf("Failed to get cluster-wide config path: %s", err)
 		}
@@ -38,21 +43,29 @@ func getAppConfigs(instanceNames []string, ctx *context.Ctx) (AppConfigs, error)
 			continue
 		}
 
-		appConfigs.confPathByInstanceID[instanceName] = topologyConfPath
+		appConfigs.confPathByInstanceID[instanceID] = topologyConfPath
+		appConfigs.instanceByID[instanceID] = instance
 
-		if _, err := os.Stat(topologyConfPath); err != nil {
+		fileInfo, err := fs.Stat(topologyConfPath)
+		if err != nil {
 			return appConfigs, fmt.Errorf("Failed to use topology config: %s", err)
 		}
 
-		hash, err := common.FileSHA256Hex(topologyConfPath)
+		confContent, err := fs.ReadFile(topologyConfPath)
 		if err != nil {
 			return appConfigs, fmt.Errorf("Failed to get config hash: %s", err)
 		}
 
-		appConfigs.instancesByHash[hash] = append(appConfigs.instancesByHash[hash], instanceName)
+		hash := common.BytesSHA256Hex(confContent)
+
+		appConfigs.instancesByHash[hash] = append(appConfigs.instancesByHash[hash], instanceID)
+
+		if fileInfo.ModTime().After(appConfigs.modTimeByHash[hash]) {
+			appConfigs.modTimeByHash[hash] = fileInfo.ModTime()
+		}
 
 		if _, found := appConfigs.confByHash[hash]; !found {
-			if appConfigs.confByHash[hash], err = getTopologyConf(topologyConfPath); err != nil {
+			if appConfigs.confByHash[hash], err = getTopologyConf(fs, topologyConfPath); err != nil {
 				return appConfigs, fmt.Errorf("Failed to parse topology config %s: %s", topologyConfPath, err)
 			}
 		}
@@ -60,7 +73,11 @@ func getAppConfigs(instanceNames []string, ctx *context.Ctx) (AppConfigs, error)
 	}
 
 	if len(appConfigs.confByHash) == 0 {
-		return appConfigs, fmt.Errorf("No cluster-wide configs found in %s", ctx.Running.DataDir)
+		if len(instances) > 0 && instances[0].Host.IsLocal() {
+			return appConfigs, fmt.Errorf("No cluster-wide configs found in %s", ctx.Running.DataDir)
+		}
+
+		return appConfigs, fmt.Errorf("No cluster-wide configs found on specified hosts")
 	}
 
 	for _, instanceIDs := range appConfigs.instancesByHash {
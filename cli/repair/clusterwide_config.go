@@ -8,7 +8,6 @@ import (
 
 	"github.com/mitchellh/mapstructure"
 
-	"github.com/tarantool/cartridge-cli/cli/common"
 	"gopkg.in/yaml.v2"
 )
 
@@ -38,6 +37,7 @@ type RawConfType map[interface{}]interface{}
 type InstanceConfType struct {
 	AdvertiseURI   string `mapstructure:"uri"`
 	ReplicasetUUID string `mapstructure:"replicaset_uuid"`
+	Zone           string `mapstructure:"zone"`
 
 	IsExpelled bool
 	IsDisabled bool `mapstructure:"disabled"`
@@ -50,6 +50,10 @@ type ReplicasetConfType struct {
 	Leaders  []string        `mapstructure:"master"`
 	RolesMap map[string]bool `mapstructure:"roles"`
 
+	VshardGroup string  `mapstructure:"vshard_group"`
+	Weight      float64 `mapstructure:"weight"`
+	AllRW       bool    `mapstructure:"all_rw"`
+
 	Instances []string
 
 	Raw RawConfType
@@ -75,16 +79,16 @@ type TopologyConfFileInfo struct {
 
 // TOPOLOGY
 
-func getTopologyConfPath(workDir string) (string, error) {
+func getTopologyConfPath(fs hostFS, workDir string) (string, error) {
 	var topologyConfPath string
 
 	// check config directory <work-dir>/config/
 	confDirPath := filepath.Join(workDir, configDirName)
-	if _, err := os.Stat(confDirPath); err == nil {
+	if _, err := fs.Stat(confDirPath); err == nil {
 		// find <work-dir>/config/topology.yml
 		topologyConfPath = filepath.Join(confDirPath, topologyConfFilename)
 
-		if _, err := os.Stat(topologyConfPath); err == nil {
+		if _, err := fs.Stat(topologyConfPath); err == nil {
 			return topologyConfPath, nil
 		} else if !os.IsNotExist(err) {
 			return "", fmt.Errorf("Failed to use topology config file: %s", err)
@@ -95,7 +99,7 @@ func getTopologyConfPath(workDir string) (string, error) {
 
 	// try old format:  <work-dir>/config.yml
 	topologyConfPath = filepath.Join(workDir, configFileName)
-	if _, err := os.Stat(topologyConfPath); err == nil {
+	if _, err := fs.Stat(topologyConfPath); err == nil {
 		return topologyConfPath, nil
 	} else if !os.IsNotExist(err) {
 		return "", fmt.Errorf("Failed to use clusterwide config file: %s", err)
@@ -105,15 +109,15 @@ func getTopologyConfPath(workDir string) (string, error) {
 
 }
 
-func getTopologyConf(topologyConfPath string) (*TopologyConfType, error) {
+func getTopologyConf(fs hostFS, topologyConfPath string) (*TopologyConfType, error) {
 	var err error
 	var topologyConf TopologyConfType
 
-	if _, err := os.Stat(topologyConfPath); err != nil {
+	if _, err := fs.Stat(topologyConfPath); err != nil {
 		return nil, fmt.Errorf("Failed to use topology config path: %s", err)
 	}
 
-	confContent, err := common.GetFileContentBytes(topologyConfPath)
+	confContent, err := fs.ReadFile(topologyConfPath)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to read config: %s", err)
 	}
@@ -108,7 +108,7 @@ servers:
   srv-expelled: expelled
 `)
 
-	topologyConf, err = getTopologyConf(topologyConfPath)
+	topologyConf, err = getTopologyConf(localFS{}, topologyConfPath)
 	assert.Nil(err)
 
 	// instances
@@ -236,7 +236,7 @@ topology:
     srv-expelled: expelled
 `)
 
-	topologyConf, err = getTopologyConf(topologyConfPath)
+	topologyConf, err = getTopologyConf(localFS{}, topologyConfPath)
 	assert.Nil(err)
 
 	// instances
@@ -321,7 +321,7 @@ servers:
   srv-expelled: expelled
 `)
 
-	topologyConf, err = getTopologyConf(topologyConfPath)
+	topologyConf, err = getTopologyConf(localFS{}, topologyConfPath)
 	assert.Nil(err)
 
 	err = topologyConf.SetInstanceURI("srv-1", "localhost:3311")
@@ -380,7 +380,7 @@ servers:
   srv-expelled: expelled
 `)
 
-	topologyConf, err = getTopologyConf(topologyConfPath)
+	topologyConf, err = getTopologyConf(localFS{}, topologyConfPath)
 	assert.Nil(err)
 
 	err = topologyConf.RemoveInstance("srv-non-existant")
@@ -458,7 +458,7 @@ servers:
   srv-expelled: expelled
 `)
 
-	topologyConf, err = getTopologyConf(topologyConfPath)
+	topologyConf, err = getTopologyConf(localFS{}, topologyConfPath)
 	assert.Nil(err)
 
 	err = topologyConf.RemoveReplicaset("rpl-non-existent")
@@ -529,7 +529,7 @@ servers:
   srv-expelled: expelled
 `
 	topologyConfPath = writeTopologyConfig(workDir, confContent)
-	topologyConf, err = getTopologyConf(topologyConfPath)
+	topologyConf, err = getTopologyConf(localFS{}, topologyConfPath)
 	assert.Nil(err)
 
 	replicasetConf, _ := topologyConf.Replicasets["rpl-1"]
@@ -582,7 +582,7 @@ servers:
   srv-expelled: expelled
 `
 	topologyConfPath = writeTopologyConfig(workDir, confContent)
-	topologyConf, err = getTopologyConf(topologyConfPath)
+	topologyConf, err = getTopologyConf(localFS{}, topologyConfPath)
 	assert.Nil(err)
 
 	replicasetConf, _ := topologyConf.Replicasets["rpl-1"]
@@ -655,7 +655,7 @@ servers:
   srv-expelled: expelled
 `
 	topologyConfPath = writeTopologyConfig(workDir, confContent)
-	topologyConf, err = getTopologyConf(topologyConfPath)
+	topologyConf, err = getTopologyConf(localFS{}, topologyConfPath)
 	assert.Nil(err)
 
 	replicasetConf, _ := topologyConf.Replicasets["rpl-1"]
@@ -2,8 +2,6 @@ package repair
 
 import (
 	"fmt"
-	"io"
-	"io/ioutil"
 	"os"
 	"strings"
 
@@ -21,67 +19,67 @@ var (
 	minCartridgeMajorVersionForReloadStr = "2.0.0"
 )
 
-func getAppInstanceNames(ctx *context.Ctx) ([]string, error) {
-	if err := project.SetSystemRunningPaths(ctx); err != nil {
-		return nil, fmt.Errorf("Failed to get default paths: %s", err)
-	}
+func getAppInstances(hosts appHosts, ctx *context.Ctx) ([]*appInstance, error) {
+	instances := make([]*appInstance, 0)
 
-	if fileInfo, err := os.Stat(ctx.Running.DataDir); os.IsNotExist(err) {
-		return nil, fmt.Errorf("Data directory %s doesn't exist", ctx.Running.DataDir)
-	} else if err != nil {
-		return nil, fmt.Errorf("Failed to use specified data directory: %s", err)
-	} else if !fileInfo.IsDir() {
-		return nil, fmt.Errorf("%s is not a directory", ctx.Running.DataDir)
-	}
+	for _, host := range hosts {
+		if fileInfo, err := host.fs.Stat(host.DataDir); os.IsNotExist(err) {
+			return nil, fmt.Errorf("Data directory %s doesn't exist", host)
+		} else if err != nil {
+			return nil, fmt.Errorf("Failed to use specified data directory %s: %s", host, err)
+		} else if !fileInfo.IsDir() {
+			return nil, fmt.Errorf("%s is not a directory", host)
+		}
 
-	workDirs, err := ioutil.ReadDir(ctx.Running.DataDir)
-	if err != nil {
-		return nil, fmt.Errorf("Failed to list the data directory: %s", err)
-	}
+		workDirs, err := host.fs.ReadDir(host.DataDir)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to list the data directory %s: %s", host, err)
+		}
 
-	appWorkDirsPrefix := fmt.Sprintf("%s.", ctx.Project.Name)
-	instanceNames := make([]string, 0)
-	for _, workDir := range workDirs {
-		workDirName := workDir.Name()
-		if strings.HasPrefix(workDirName, appWorkDirsPrefix) {
-			instanceName := strings.SplitN(workDirName, ".", 2)[1]
-			if instanceName != "" {
-				instanceNames = append(instanceNames, instanceName)
+		appWorkDirsPrefix := fmt.Sprintf("%s.", ctx.Project.Name)
+		for _, workDir := range workDirs {
+			workDirName := workDir.Name()
+			if strings.HasPrefix(workDirName, appWorkDirsPrefix) {
+				instanceName := strings.SplitN(workDirName, ".", 2)[1]
+				if instanceName != "" {
+					instances = append(instances, &appInstance{
+						Name: instanceName,
+						Host: host,
+					})
+				}
 			}
 		}
 	}
 
-	if len(instanceNames) == 0 {
-		return nil, fmt.Errorf("No instance working directories found in %s", ctx.Running.DataDir)
+	if len(instances) == 0 {
+		if len(hosts) == 1 {
+			return nil, fmt.Errorf("No instance working directories found in %s", hosts[0])
+		}
+
+		return nil, fmt.Errorf("No instance working directories found on specified hosts")
 	}
 
-	return instanceNames, nil
+	return instances, nil
 }
 
 func getBackupPath(path string) string {
 	return fmt.Sprintf("%s.bak", path)
 }
 
-func createFileBackup(path string) (string, error) {
-	fileInfo, err := os.Stat(path)
+func createFileBackup(fs hostFS, path string) (string, error) {
+	fileInfo, err := fs.Stat(path)
 	if err != nil {
 		return "", fmt.Errorf("Failed to use specified path: %s", err)
 	}
 
-	file, err := os.Open(path)
+	content, err := fs.ReadFile(path)
 	if err != nil {
-		return "", fmt.Errorf("Failed to open file: %s", err)
+		return "", fmt.Errorf("Failed to read file: %s", err)
 	}
-	defer file.Close()
 
 	backupPath := getBackupPath(path)
-	backupFile, err := os.OpenFile(backupPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, fileInfo.Mode())
-	if err != nil {
-		return "", fmt.Errorf("Failed to open backup file: %s", err)
-	}
-
-	if _, err := io.Copy(backupFile, file); err != nil {
-		return "", fmt.Errorf("Failed to copy file content: %s", err)
+	if err := fs.WriteFile(backupPath, content, fileInfo.Mode()); err != nil {
+		return "", fmt.Errorf("Failed to write backup file: %s", err)
 	}
 
 	return backupPath, nil
@@ -118,9 +116,15 @@ func getDiffLines(confBefore []byte, confAfter []byte, from string, to string) (
 	return logLines, nil
 }
 
-func checkThatReloadIsPossible(instanceNames []string, ctx *context.Ctx) error {
-	for _, instanceName := range instanceNames {
-		consoleSock := project.GetInstanceConsoleSock(ctx, instanceName)
+func checkThatReloadIsPossible(instances []*appInstance, ctx *context.Ctx) error {
+	for _, instance := range instances {
+		if !instance.Host.IsLocal() {
+			return fmt.Errorf("Reload isn't supported for remote hosts")
+		}
+	}
+
+	for _, instance := range instances {
+		consoleSock := project.GetInstanceConsoleSock(ctx, instance.Name)
 
 		if _, err := os.Stat(consoleSock); err != nil {
 			continue
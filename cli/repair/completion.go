@@ -9,12 +9,18 @@ import (
 )
 
 func GetAllInstanceUUIDsComp(ctx *context.Ctx) ([]string, error) {
-	instanceNames, err := getAppInstanceNames(ctx)
+	hosts, err := getAppHosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer hosts.Close()
+
+	instances, err := getAppInstances(hosts, ctx)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to get application instances working directories: %s", err)
 	}
 
-	appConfigs, err := getAppConfigs(instanceNames, ctx)
+	appConfigs, err := getAppConfigs(instances, ctx)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to get application cluster-wide configs: %s", err)
 	}
@@ -41,12 +47,18 @@ func GetAllInstanceUUIDsComp(ctx *context.Ctx) ([]string, error) {
 }
 
 func GetInstanceHostsComp(instanceUUID string, ctx *context.Ctx) ([]string, error) {
-	instanceNames, err := getAppInstanceNames(ctx)
+	hosts, err := getAppHosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer hosts.Close()
+
+	instances, err := getAppInstances(hosts, ctx)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to get application instances working directories: %s", err)
 	}
 
-	appConfigs, err := getAppConfigs(instanceNames, ctx)
+	appConfigs, err := getAppConfigs(instances, ctx)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to get application cluster-wide configs: %s", err)
 	}
@@ -86,12 +98,18 @@ func GetInstanceHostsComp(instanceUUID string, ctx *context.Ctx) ([]string, erro
 }
 
 func GetAllReplicasetUUIDsComp(ctx *context.Ctx) ([]string, error) {
-	instanceNames, err := getAppInstanceNames(ctx)
+	hosts, err := getAppHosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer hosts.Close()
+
+	instances, err := getAppInstances(hosts, ctx)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to get application instances working directories: %s", err)
 	}
 
-	appConfigs, err := getAppConfigs(instanceNames, ctx)
+	appConfigs, err := getAppConfigs(instances, ctx)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to get application cluster-wide configs: %s", err)
 	}
@@ -118,12 +136,18 @@ func GetAllReplicasetUUIDsComp(ctx *context.Ctx) ([]string, error) {
 }
 
 func GetReplicasetInstancesComp(replicasetUUID string, ctx *context.Ctx) ([]string, error) {
-	instanceNames, err := getAppInstanceNames(ctx)
+	hosts, err := getAppHosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer hosts.Close()
+
+	instances, err := getAppInstances(hosts, ctx)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to get application instances working directories: %s", err)
 	}
 
-	appConfigs, err := getAppConfigs(instanceNames, ctx)
+	appConfigs, err := getAppConfigs(instances, ctx)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to get application cluster-wide configs: %s", err)
 	}
@@ -0,0 +1,207 @@
+package repair
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/apex/log"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/tarantool/cartridge-cli/cli/context"
+	"github.com/tarantool/cartridge-cli/cli/project"
+)
+
+// hostFS is a file system of the host where
+// application instances working directories are placed.
+type hostFS interface {
+	Stat(path string) (os.FileInfo, error)
+	ReadDir(path string) ([]os.FileInfo, error)
+	ReadFile(path string) ([]byte, error)
+	WriteFile(path string, data []byte, perm os.FileMode) error
+	Close() error
+}
+
+type appHost struct {
+	// Name is empty for the local host
+	Name    string
+	DataDir string
+
+	fs hostFS
+}
+
+type appHosts []*appHost
+
+type appInstance struct {
+	Name string
+	Host *appHost
+}
+
+// getAppHosts returns hosts where application instances are placed.
+// If inventory isn't specified, only local data directory is used.
+// Otherwise, all inventory hosts are connected via SSH.
+func getAppHosts(ctx *context.Ctx) (appHosts, error) {
+	if err := project.SetSystemRunningPaths(ctx); err != nil {
+		return nil, fmt.Errorf("Failed to get default paths: %s", err)
+	}
+
+	if ctx.Repair.Inventory == "" {
+		return appHosts{{DataDir: ctx.Running.DataDir, fs: localFS{}}}, nil
+	}
+
+	inventory, err := getInventoryConf(ctx.Repair.Inventory)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get inventory: %s", err)
+	}
+
+	var hosts appHosts
+	for _, hostConf := range inventory.Hosts {
+		log.Debugf("Connect to %s", hostConf.Host)
+
+		hostFS, err := connectSFTP(hostConf, &inventory.SSH)
+		if err != nil {
+			hosts.Close()
+			return nil, fmt.Errorf("Failed to connect to %s: %s", hostConf.Host, err)
+		}
+
+		dataDir := hostConf.DataDir
+		if dataDir == "" {
+			dataDir = ctx.Running.DataDir
+		}
+
+		hosts = append(hosts, &appHost{
+			Name:    hostConf.Host,
+			DataDir: dataDir,
+			fs:      hostFS,
+		})
+	}
+
+	return hosts, nil
+}
+
+func (hosts appHosts) Close() {
+	for _, host := range hosts {
+		if err := host.fs.Close(); err != nil {
+			log.Debugf("Failed to close connection to %s: %s", host.Name, err)
+		}
+	}
+}
+
+func (host *appHost) IsLocal() bool {
+	return host.Name == ""
+}
+
+// String returns a data directory with a host prefix (for remote hosts)
+func (host *appHost) String() string {
+	if host.IsLocal() {
+		return host.DataDir
+	}
+
+	return fmt.Sprintf("%s:%s", host.Name, host.DataDir)
+}
+
+// ID returns an instance name for the local host
+// and <instance-name>@<host> for remote ones
+func (instance *appInstance) ID() string {
+	if instance.Host.IsLocal() {
+		return instance.Name
+	}
+
+	return fmt.Sprintf("%s@%s", instance.Name, instance.Host.Name)
+}
+
+func (instance *appInstance) WorkDir(ctx *context.Ctx) string {
+	return filepath.Join(instance.Host.DataDir, project.GetInstanceID(ctx, instance.Name))
+}
+
+// LOCAL
+
+type localFS struct{}
+
+func (localFS) Stat(path string) (os.FileInfo, error) {
+	return os.Stat(path)
+}
+
+func (localFS) ReadDir(path string) ([]os.FileInfo, error) {
+	return ioutil.ReadDir(path)
+}
+
+func (localFS) ReadFile(path string) ([]byte, error) {
+	return ioutil.ReadFile(path)
+}
+
+func (localFS) WriteFile(path string, data []byte, perm os.FileMode) error {
+	return ioutil.WriteFile(path, data, perm)
+}
+
+func (localFS) Close() error {
+	return nil
+}
+
+// SFTP
+
+type sftpFS struct {
+	sshClient  *ssh.Client
+	sftpClient *sftp.Client
+	// agentConn is a connection to ssh-agent, it can be nil
+	agentConn net.Conn
+}
+
+func newSFTPFS(sshClient *ssh.Client, agentConn net.Conn) (*sftpFS, error) {
+	sftpClient, err := sftp.NewClient(sshClient)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to start SFTP session: %s", err)
+	}
+
+	return &sftpFS{
+		sshClient:  sshClient,
+		sftpClient: sftpClient,
+		agentConn:  agentConn,
+	}, nil
+}
+
+func (fs *sftpFS) Stat(path string) (os.FileInfo, error) {
+	return fs.sftpClient.Stat(path)
+}
+
+func (fs *sftpFS) ReadDir(path string) ([]os.FileInfo, error) {
+	return fs.sftpClient.ReadDir(path)
+}
+
+func (fs *sftpFS) ReadFile(path string) ([]byte, error) {
+	file, err := fs.sftpClient.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ioutil.ReadAll(file)
+}
+
+func (fs *sftpFS) WriteFile(path string, data []byte, perm os.FileMode) error {
+	file, err := fs.sftpClient.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(data); err != nil {
+		return err
+	}
+
+	return file.Chmod(perm)
+}
+
+func (fs *sftpFS) Close() error {
+	fs.sftpClient.Close()
+	err := fs.sshClient.Close()
+
+	if fs.agentConn != nil {
+		fs.agentConn.Close()
+	}
+
+	return err
+}
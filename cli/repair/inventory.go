@@ -0,0 +1,239 @@
+package repair
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"gopkg.in/yaml.v2"
+
+	"github.com/tarantool/cartridge-cli/cli/common"
+)
+
+const (
+	defaultSSHPort    = 22
+	sshConnectTimeout = 10 * time.Second
+
+	sshAuthSockEnv = "SSH_AUTH_SOCK"
+)
+
+// InventoryConf describes hosts where application instances are placed.
+//
+// Example:
+//
+//	ssh:
+//	  user: tarantool
+//	  identity_file: ~/.ssh/id_rsa
+//	hosts:
+//	  - host: tarantool-1.example.com
+//	  - host: tarantool-2.example.com:2222
+//	    data_dir: /opt/tarantool/data
+type InventoryConf struct {
+	SSH   InventorySSHConf     `yaml:"ssh"`
+	Hosts []*InventoryHostConf `yaml:"hosts"`
+}
+
+// InventorySSHConf describes common SSH connection parameters
+type InventorySSHConf struct {
+	User                  string `yaml:"user"`
+	Port                  int    `yaml:"port"`
+	IdentityFile          string `yaml:"identity_file"`
+	KnownHostsFile        string `yaml:"known_hosts_file"`
+	InsecureIgnoreHostKey bool   `yaml:"insecure_ignore_host_key"`
+}
+
+// InventoryHostConf describes one host.
+// Specified SSH parameters override the common ones.
+type InventoryHostConf struct {
+	Host         string `yaml:"host"`
+	Port         int    `yaml:"port"`
+	User         string `yaml:"user"`
+	IdentityFile string `yaml:"identity_file"`
+	DataDir      string `yaml:"data_dir"`
+}
+
+func getInventoryConf(inventoryPath string) (*InventoryConf, error) {
+	if _, err := os.Stat(inventoryPath); err != nil {
+		return nil, fmt.Errorf("Failed to use inventory file: %s", err)
+	}
+
+	content, err := common.GetFileContentBytes(inventoryPath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read inventory file: %s", err)
+	}
+
+	var inventory InventoryConf
+	if err := yaml.UnmarshalStrict(content, &inventory); err != nil {
+		return nil, fmt.Errorf("Failed to parse inventory file: %s", err)
+	}
+
+	if len(inventory.Hosts) == 0 {
+		return nil, fmt.Errorf("No hosts specified")
+	}
+
+	addedHosts := make(map[string]bool)
+	for i, hostConf := range inventory.Hosts {
+		if hostConf == nil || hostConf.Host == "" {
+			return nil, fmt.Errorf("Host isn't specified for hosts[%d]", i)
+		}
+
+		if addedHosts[hostConf.Host] {
+			return nil, fmt.Errorf("Host %s is specified more than once", hostConf.Host)
+		}
+
+		addedHosts[hostConf.Host] = true
+	}
+
+	return &inventory, nil
+}
+
+// getSSHAddress returns host:port address of the specified host
+func getSSHAddress(hostConf *InventoryHostConf, sshConf *InventorySSHConf) string {
+	if _, _, err := net.SplitHostPort(hostConf.Host); err == nil {
+		return hostConf.Host
+	}
+
+	port := defaultSSHPort
+	if hostConf.Port != 0 {
+		port = hostConf.Port
+	} else if sshConf.Port != 0 {
+		port = sshConf.Port
+	}
+
+	return net.JoinHostPort(hostConf.Host, strconv.Itoa(port))
+}
+
+// getSSHClientConfig returns SSH client config for the host.
+// If ssh-agent is used, the agent connection is returned too,
+// it should be closed when the SSH session is finished
+func getSSHClientConfig(hostConf *InventoryHostConf, sshConf *InventorySSHConf) (*ssh.ClientConfig, net.Conn, error) {
+	var err error
+
+	clientConfig := ssh.ClientConfig{
+		User:    hostConf.User,
+		Timeout: sshConnectTimeout,
+	}
+
+	if clientConfig.User == "" {
+		clientConfig.User = sshConf.User
+	}
+
+	if clientConfig.User == "" {
+		currentUser, err := user.Current()
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed to get current user: %s", err)
+		}
+
+		clientConfig.User = currentUser.Username
+	}
+
+	// host key
+	if sshConf.InsecureIgnoreHostKey {
+		clientConfig.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+	} else {
+		knownHostsFile := sshConf.KnownHostsFile
+		if knownHostsFile == "" {
+			knownHostsFile = filepath.Join("~", ".ssh", "known_hosts")
+		}
+
+		if knownHostsFile, err = common.ExpandHomeDir(knownHostsFile); err != nil {
+			return nil, nil, err
+		}
+
+		if clientConfig.HostKeyCallback, err = knownhosts.New(knownHostsFile); err != nil {
+			return nil, nil, fmt.Errorf("Failed to use known hosts file: %s", err)
+		}
+	}
+
+	// auth methods
+	identityFile := hostConf.IdentityFile
+	if identityFile == "" {
+		identityFile = sshConf.IdentityFile
+	}
+
+	if identityFile != "" {
+		signer, err := getIdentityFileSigner(identityFile)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		clientConfig.Auth = append(clientConfig.Auth, ssh.PublicKeys(signer))
+	}
+
+	// agent connection is established last,
+	// so it's never leaked on the config errors
+	var agentConn net.Conn
+	if authSock := os.Getenv(sshAuthSockEnv); authSock != "" {
+		if agentConn, err = net.Dial("unix", authSock); err == nil {
+			agentClient := agent.NewClient(agentConn)
+			clientConfig.Auth = append(clientConfig.Auth, ssh.PublicKeysCallback(agentClient.Signers))
+		}
+	}
+
+	if len(clientConfig.Auth) == 0 {
+		return nil, nil, fmt.Errorf(
+			"No SSH auth methods available. Please, specify identity_file or run ssh-agent",
+		)
+	}
+
+	return &clientConfig, agentConn, nil
+}
+
+func getIdentityFileSigner(identityFile string) (ssh.Signer, error) {
+	identityFile, err := common.ExpandHomeDir(identityFile)
+	if err != nil {
+		return nil, err
+	}
+
+	keyContent, err := ioutil.ReadFile(identityFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read identity file: %s", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(keyContent)
+	if err != nil {
+		if _, ok := err.(*ssh.PassphraseMissingError); ok {
+			return nil, fmt.Errorf(
+				"Identity file %s is protected by passphrase. Please, add it to ssh-agent", identityFile,
+			)
+		}
+
+		return nil, fmt.Errorf("Failed to parse identity file: %s", err)
+	}
+
+	return signer, nil
+}
+
+func connectSFTP(hostConf *InventoryHostConf, sshConf *InventorySSHConf) (*sftpFS, error) {
+	clientConfig, agentConn, err := getSSHClientConfig(hostConf, sshConf)
+	if err != nil {
+		return nil, err
+	}
+
+	sshClient, err := ssh.Dial("tcp", getSSHAddress(hostConf, sshConf), clientConfig)
+	if err != nil {
+		if agentConn != nil {
+			agentConn.Close()
+		}
+		return nil, fmt.Errorf("Failed to dial: %s", err)
+	}
+
+	fs, err := newSFTPFS(sshClient, agentConn)
+	if err != nil {
+		sshClient.Close()
+		if agentConn != nil {
+			agentConn.Close()
+		}
+		return nil, err
+	}
+
+	return fs, nil
+}
@@ -0,0 +1,332 @@
+package repair
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/tarantool/cartridge-cli/cli/context"
+)
+
+func writeInventory(dir string, content string) string {
+	inventoryPath := filepath.Join(dir, "inventory.yml")
+	if err := ioutil.WriteFile(inventoryPath, []byte(content), 0644); err != nil {
+		panic(fmt.Errorf("Failed to write inventory: %s", err))
+	}
+
+	return inventoryPath
+}
+
+// startSFTPServer starts SSH server that serves only SFTP subsystem
+// and accepts only specified client key.
+// It returns server address and a host key.
+func startSFTPServer(t *testing.T, clientKey ssh.PublicKey) (string, ssh.PublicKey) {
+	_, hostPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hostSigner, err := ssh.NewSignerFromKey(hostPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serverConfig := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(clientKey.Marshal()) {
+				return nil, fmt.Errorf("Unknown public key for %s", conn.User())
+			}
+			return nil, nil
+		},
+	}
+	serverConfig.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go serveSSHConn(conn, serverConfig)
+		}
+	}()
+
+	return listener.Addr().String(), hostSigner.PublicKey()
+}
+
+func serveSSHConn(conn net.Conn, serverConfig *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, serverConfig)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+
+		go func(requests <-chan *ssh.Request) {
+			for req := range requests {
+				// payload is a string with uint32 length prefix
+				isSFTP := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+				req.Reply(isSFTP, nil)
+			}
+		}(requests)
+
+		go func(channel ssh.Channel) {
+			defer channel.Close()
+
+			server, err := sftp.NewServer(channel)
+			if err != nil {
+				return
+			}
+			server.Serve()
+		}(channel)
+	}
+}
+
+func writeIdentityFile(dir string) (string, ssh.PublicKey) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		panic(err)
+	}
+
+	identityFile := filepath.Join(dir, "id_ed25519")
+	keyContent := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes})
+	if err := ioutil.WriteFile(identityFile, keyContent, 0600); err != nil {
+		panic(err)
+	}
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		panic(err)
+	}
+
+	return identityFile, sshPublicKey
+}
+
+func TestGetInventoryConf(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+
+	var err error
+	var inventory *InventoryConf
+
+	// OK
+	inventory, err = getInventoryConf(writeInventory(dir, `---
+ssh:
+  user: tarantool
+  port: 2222
+  identity_file: ~/.ssh/id_rsa
+hosts:
+  - host: host-1
+  - host: host-2:22
+    user: admin
+    data_dir: /opt/data
+`))
+	assert.Nil(err)
+	assert.Equal("tarantool", inventory.SSH.User)
+	assert.Equal(2222, inventory.SSH.Port)
+	assert.Len(inventory.Hosts, 2)
+	assert.Equal("host-1", inventory.Hosts[0].Host)
+	assert.Equal("admin", inventory.Hosts[1].User)
+	assert.Equal("/opt/data", inventory.Hosts[1].DataDir)
+
+	assert.Equal("host-1:2222", getSSHAddress(inventory.Hosts[0], &inventory.SSH))
+	assert.Equal("host-2:22", getSSHAddress(inventory.Hosts[1], &inventory.SSH))
+	assert.Equal("host-3:22", getSSHAddress(&InventoryHostConf{Host: "host-3"}, &InventorySSHConf{}))
+	assert.Equal("host-4:23", getSSHAddress(&InventoryHostConf{Host: "host-4", Port: 23}, &inventory.SSH))
+
+	// no hosts
+	_, err = getInventoryConf(writeInventory(dir, `---
+ssh:
+  user: tarantool
+`))
+	assert.EqualError(err, "No hosts specified")
+
+	// empty host
+	_, err = getInventoryConf(writeInventory(dir, `---
+hosts:
+  - host: host-1
+  - data_dir: /opt/data
+`))
+	assert.EqualError(err, "Host isn't specified for hosts[1]")
+
+	// duplicate host
+	_, err = getInventoryConf(writeInventory(dir, `---
+hosts:
+  - host: host-1
+  - host: host-1
+`))
+	assert.EqualError(err, "Host host-1 is specified more than once")
+
+	// unknown field
+	_, err = getInventoryConf(writeInventory(dir, `---
+hosts:
+  - host: host-1
+    password: secret
+`))
+	assert.NotNil(err)
+	assert.True(strings.HasPrefix(err.Error(), "Failed to parse inventory file"), err.Error())
+
+	// non-existent file
+	_, err = getInventoryConf(filepath.Join(dir, "unknown.yml"))
+	assert.NotNil(err)
+	assert.True(strings.HasPrefix(err.Error(), "Failed to use inventory file"), err.Error())
+}
+
+func TestRepairOverSSH(t *testing.T) {
+	assert := assert.New(t)
+
+	os.Unsetenv(sshAuthSockEnv)
+
+	dir := t.TempDir()
+
+	identityFile, clientPublicKey := writeIdentityFile(dir)
+	serverAddr, hostKey := startSFTPServer(t, clientPublicKey)
+
+	_, serverPort, err := net.SplitHostPort(serverAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the same server is accessed by two different names
+	host1 := serverAddr
+	host2 := net.JoinHostPort("localhost", serverPort)
+
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	knownHostsContent := strings.Join([]string{
+		knownhosts.Line([]string{knownhosts.Normalize(host1), knownhosts.Normalize(host2)}, hostKey),
+		"",
+	}, "\n")
+	if err := ioutil.WriteFile(knownHostsFile, []byte(knownHostsContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dataDir1 := filepath.Join(dir, "data-1")
+	dataDir2 := filepath.Join(dir, "data-2")
+
+	confV1 := `---
+servers:
+  srv-1:
+    disabled: false
+    replicaset_uuid: rpl-1
+    uri: localhost:3301
+replicasets:
+  rpl-1:
+    master:
+    - srv-1
+`
+	confV2 := confV1 + `    weight: 1
+`
+
+	confPath1 := writeTopologyConfig(filepath.Join(dataDir1, "myapp.i-1"), confV1)
+	confPath2 := writeTopologyConfig(filepath.Join(dataDir1, "myapp.i-2"), confV1)
+	confPath3 := writeTopologyConfig(filepath.Join(dataDir2, "myapp.i-3"), confV2)
+
+	inventoryPath := writeInventory(dir, fmt.Sprintf(`---
+ssh:
+  identity_file: %s
+  known_hosts_file: %s
+hosts:
+  - host: %s
+    data_dir: %s
+  - host: %s
+    data_dir: %s
+`, identityFile, knownHostsFile, host1, dataDir1, host2, dataDir2))
+
+	ctx := context.Ctx{}
+	ctx.Project.Name = "myapp"
+	ctx.Running.DataDir = filepath.Join(dir, "default-data-dir")
+	ctx.Repair.Inventory = inventoryPath
+
+	hosts, err := getAppHosts(&ctx)
+	assert.Nil(err)
+	defer hosts.Close()
+
+	assert.Len(hosts, 2)
+	assert.Equal(fmt.Sprintf("%s:%s", host1, dataDir1), hosts[0].String())
+
+	instances, err := getAppInstances(hosts, &ctx)
+	assert.Nil(err)
+
+	instanceIDs := make([]string, len(instances))
+	for i, instance := range instances {
+		instanceIDs[i] = instance.ID()
+	}
+	assert.ElementsMatch([]string{
+		fmt.Sprintf("i-1@%s", host1),
+		fmt.Sprintf("i-2@%s", host1),
+		fmt.Sprintf("i-3@%s", host2),
+	}, instanceIDs)
+
+	appConfigs, err := getAppConfigs(instances, &ctx)
+	assert.Nil(err)
+	assert.True(appConfigs.AreDifferent())
+
+	// reload isn't supported for remote hosts
+	err = checkThatReloadIsPossible(instances, &ctx)
+	assert.EqualError(err, "Reload isn't supported for remote hosts")
+
+	// sync configs via SFTP
+	assert.Nil(Sync(&ctx))
+
+	for _, confPath := range []string{confPath1, confPath2, confPath3} {
+		content, err := ioutil.ReadFile(confPath)
+		assert.Nil(err)
+		assert.Equal(confV1, string(content))
+	}
+
+	backupContent, err := ioutil.ReadFile(getBackupPath(confPath3))
+	assert.Nil(err)
+	assert.Equal(confV2, string(backupContent))
+
+	// unknown host key
+	ctx.Repair.Inventory = writeInventory(dir, fmt.Sprintf(`---
+ssh:
+  identity_file: %s
+  known_hosts_file: %s
+hosts:
+  - host: %s
+`, identityFile, filepath.Join(dir, "empty_known_hosts"), host1))
+	if err := ioutil.WriteFile(filepath.Join(dir, "empty_known_hosts"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = getAppHosts(&ctx)
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "key is unknown")
+}
@@ -43,6 +43,16 @@ func getIndentedString(n int, format string, a ...interface{}) string {
 func getTopologySummary(topologyConf *TopologyConfType, ctx *context.Ctx) ([]common.ResultMessage, error) {
 	var resMessages []common.ResultMessage
 
+	if ctx.Repair.ListFormat != "" {
+		graph, err := common.RenderTopologyGraph(getTopologyGraph(topologyConf), ctx.Repair.ListFormat)
+		if err != nil {
+			return nil, err
+		}
+
+		resMessages = append(resMessages, common.GetInfoMessage("%s", graph))
+		return resMessages, nil
+	}
+
 	failed := false
 
 	// instances
@@ -50,7 +60,7 @@ func getTopologySummary(topologyConf *TopologyConfType, ctx *context.Ctx) ([]com
 		failed = true
 		resMessages = append(resMessages, common.GetErrMessage("Failed to get instaces summary: %s", err))
 	} else {
-		resMessages = append(resMessages, common.GetInfoMessage(instancesSummary))
+		resMessages = append(resMessages, common.GetInfoMessage("%s", instancesSummary))
 	}
 
 	// replicasets
@@ -58,7 +68,7 @@ func getTopologySummary(topologyConf *TopologyConfType, ctx *context.Ctx) ([]com
 		failed = true
 		resMessages = append(resMessages, common.GetErrMessage("Failed to get replicasets summary: %s", err))
 	} else {
-		resMessages = append(resMessages, common.GetInfoMessage(replicasetsSummary))
+		resMessages = append(resMessages, common.GetInfoMessage("%s", replicasetsSummary))
 	}
 
 	resMessages = append(resMessages, common.GetInfoMessage(""))
@@ -168,3 +178,72 @@ func getReplicasetsSummary(topologyConf *TopologyConfType) (string, error) {
 
 	return strings.Join(summary, "\n"), nil
 }
+
+// getTopologyGraph returns topology graph
+// that can be rendered in Graphviz or Mermaid formats.
+// Expelled instances aren't shown
+func getTopologyGraph(topologyConf *TopologyConfType) *common.TopologyGraph {
+	var graph common.TopologyGraph
+
+	replicasetUUIDs := topologyConf.GetOrderedReplicasetUUIDs()
+	sort.SliceStable(replicasetUUIDs, func(i, j int) bool {
+		return topologyConf.Replicasets[replicasetUUIDs[i]].Alias < topologyConf.Replicasets[replicasetUUIDs[j]].Alias
+	})
+
+	for _, replicasetUUID := range replicasetUUIDs {
+		replicasetConf := topologyConf.Replicasets[replicasetUUID]
+
+		graphReplicaset := common.GraphReplicaset{
+			ID:          replicasetUUID,
+			Alias:       replicasetConf.Alias,
+			VshardGroup: replicasetConf.VshardGroup,
+			AllRW:       replicasetConf.AllRW,
+		}
+
+		if _, found := replicasetConf.RolesMap["vshard-storage"]; found {
+			weight := replicasetConf.Weight
+			graphReplicaset.Weight = &weight
+		}
+
+		for role, enabled := range replicasetConf.RolesMap {
+			if enabled {
+				graphReplicaset.Roles = append(graphReplicaset.Roles, role)
+			}
+		}
+		sort.Strings(graphReplicaset.Roles)
+
+		if len(replicasetConf.Leaders) > 0 {
+			graphReplicaset.LeaderID = replicasetConf.Leaders[0]
+		}
+
+		// leaders are shown first
+		instanceUUIDs := make([]string, 0)
+		addedInstances := make(map[string]bool)
+		for _, uuids := range [][]string{replicasetConf.Leaders, replicasetConf.Instances} {
+			for _, instanceUUID := range uuids {
+				if !addedInstances[instanceUUID] {
+					addedInstances[instanceUUID] = true
+					instanceUUIDs = append(instanceUUIDs, instanceUUID)
+				}
+			}
+		}
+
+		for _, instanceUUID := range instanceUUIDs {
+			instanceConf, found := topologyConf.Instances[instanceUUID]
+			if !found || instanceConf.IsExpelled {
+				continue
+			}
+
+			graphReplicaset.Instances = append(graphReplicaset.Instances, &common.GraphInstance{
+				ID:       instanceUUID,
+				URI:      instanceConf.AdvertiseURI,
+				Zone:     instanceConf.Zone,
+				Disabled: instanceConf.IsDisabled,
+			})
+		}
+
+		graph.Replicasets = append(graph.Replicasets, &graphReplicaset)
+	}
+
+	return &graph
+}
@@ -0,0 +1,91 @@
+package repair
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTopologyGraph(t *testing.T) {
+	assert := assert.New(t)
+
+	workDir, err := ioutil.TempDir("", "work-dir")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(workDir)
+
+	topologyConfPath := writeTopologyConfig(workDir, `---
+failover: false
+replicasets:
+  rpl-1:
+    alias: router
+    master:
+    - srv-1
+    roles:
+      vshard-router: true
+      app.roles.custom: true
+      disabled-role: false
+    weight: 0
+  rpl-2:
+    alias: unnamed
+    all_rw: true
+    master:
+    - srv-3
+    - srv-2
+    roles:
+      vshard-storage: true
+    vshard_group: hot
+    weight: 1
+servers:
+  srv-1:
+    disabled: false
+    replicaset_uuid: rpl-1
+    uri: localhost:3301
+    zone: msk
+  srv-2:
+    disabled: true
+    replicaset_uuid: rpl-2
+    uri: localhost:3302
+  srv-3:
+    disabled: false
+    replicaset_uuid: rpl-2
+    uri: localhost:3303
+  srv-expelled: expelled
+`)
+
+	topologyConf, err := getTopologyConf(localFS{}, topologyConfPath)
+	assert.Nil(err)
+
+	graph := getTopologyGraph(topologyConf)
+	assert.Len(graph.Replicasets, 2)
+
+	// unnamed replicaset goes first since it has an empty alias
+	storage := graph.Replicasets[0]
+	assert.Equal("rpl-2", storage.ID)
+	assert.Equal("", storage.Alias)
+	assert.Equal([]string{"vshard-storage"}, storage.Roles)
+	assert.Equal("hot", storage.VshardGroup)
+	assert.True(storage.AllRW)
+	assert.NotNil(storage.Weight)
+	assert.Equal(1.0, *storage.Weight)
+	assert.Equal("srv-3", storage.LeaderID)
+	assert.Len(storage.Instances, 2)
+	assert.Equal("srv-3", storage.Instances[0].ID)
+	assert.Equal("srv-2", storage.Instances[1].ID)
+	assert.True(storage.Instances[1].Disabled)
+
+	router := graph.Replicasets[1]
+	assert.Equal("rpl-1", router.ID)
+	assert.Equal("router", router.Alias)
+	assert.Equal([]string{"app.roles.custom", "vshard-router"}, router.Roles)
+	assert.Nil(router.Weight)
+	assert.False(router.AllRW)
+	assert.Equal("srv-1", router.LeaderID)
+	assert.Len(router.Instances, 1)
+	assert.Equal("localhost:3301", router.Instances[0].URI)
+	assert.Equal("msk", router.Instances[0].Zone)
+}
@@ -50,28 +50,23 @@ func patchConf(patchFunc PatchConfFuncType, topologyConf *TopologyConfType, ctx
 	return resMessages, nil
 }
 
-func rewriteConf(topologyConfPath string, topologyConf *TopologyConfType) ([]common.ResultMessage, error) {
+func rewriteConf(fs hostFS, topologyConfPath string, newConfContent []byte) ([]common.ResultMessage, error) {
 	var resMessages []common.ResultMessage
 
 	resMessages = append(resMessages, common.GetDebugMessage("Topology config file: %s", topologyConfPath))
 
-	backupPath, err := createFileBackup(topologyConfPath)
+	fileInfo, err := fs.Stat(topologyConfPath)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to create topology config backup: %s", err)
+		return nil, fmt.Errorf("Failed to use topology config: %s", err)
 	}
-	resMessages = append(resMessages, common.GetDebugMessage("Created backup file: %s", backupPath))
 
-	newConfContent, err := topologyConf.MarshalContent()
+	backupPath, err := createFileBackup(fs, topologyConfPath)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to get new config content: %s", err)
-	}
-
-	confFile, err := os.OpenFile(topologyConfPath, os.O_WRONLY|os.O_TRUNC, 0755)
-	if err != nil {
-		return nil, fmt.Errorf("Failed to open a new config: %s", err)
+		return nil, fmt.Errorf("Failed to create topology config backup: %s", err)
 	}
+	resMessages = append(resMessages, common.GetDebugMessage("Created backup file: %s", backupPath))
 
-	if _, err := confFile.Write(newConfContent); err != nil {
+	if err := fs.WriteFile(topologyConfPath, newConfContent, fileInfo.Mode()); err != nil {
 		return nil, fmt.Errorf("Failed to write a new config: %s", err)
 	}
 
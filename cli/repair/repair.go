@@ -20,6 +20,12 @@ type ProcessConfFuncType func(topologyConf *TopologyConfType, ctx *context.Ctx)
 type PatchConfFuncType func(topologyConf *TopologyConfType, ctx *context.Ctx) error
 
 func List(ctx *context.Ctx) error {
+	if ctx.Repair.ListFormat != "" {
+		if err := common.CheckGraphFormat(ctx.Repair.ListFormat); err != nil {
+			return err
+		}
+	}
+
 	log.Infof("Get current topology")
 	return Run(getTopologySummary, ctx, false)
 }
@@ -42,20 +48,26 @@ func SetLeader(ctx *context.Ctx) error {
 func Run(processConfFunc ProcessConfFuncType, ctx *context.Ctx, patchConf bool) error {
 	log.Debugf("Data directory is set to: %s", ctx.Running.DataDir)
 
-	instanceNames, err := getAppInstanceNames(ctx)
+	hosts, err := getAppHosts(ctx)
+	if err != nil {
+		return err
+	}
+	defer hosts.Close()
+
+	instances, err := getAppInstances(hosts, ctx)
 	if err != nil {
 		return fmt.Errorf("Failed to get application instances working directories: %s", err)
 	}
 
 	if patchConf && !ctx.Repair.DryRun && ctx.Repair.Reload {
-		if err := checkThatReloadIsPossible(instanceNames, ctx); err != nil {
+		if err := checkThatReloadIsPossible(instances, ctx); err != nil {
 			return fmt.Errorf(
 				"Configurations reload isn't possible: %s", err,
 			)
 		}
 	}
 
-	appConfigs, err := getAppConfigs(instanceNames, ctx)
+	appConfigs, err := getAppConfigs(instances, ctx)
 	if err != nil {
 		return fmt.Errorf("Failed to get application cluster-wide configs: %s", err)
 	}
@@ -152,45 +164,21 @@ func processConfigs(processConfFunc ProcessConfFuncType, appConfigs *AppConfigs,
 
 func writeConfigs(appConfigs *AppConfigs, ctx *context.Ctx) error {
 	writeConfResCh := make(common.ResChan)
+	newConfContentByHash := make(map[string][]byte)
 	for hash, topologyConf := range appConfigs.confByHash {
-		for _, instanceName := range appConfigs.instancesByHash[hash] {
-			go func(instanceName string, topologyConf *TopologyConfType, writeConfResCh common.ResChan) {
-				res := common.Result{
-					ID: instanceName,
-				}
+		newConfContent, err := topologyConf.MarshalContent()
+		if err != nil {
+			return fmt.Errorf("Failed to get new config content: %s", err)
+		}
 
-				topologyConfPath, found := appConfigs.confPathByInstanceID[instanceName]
-				if !found {
-					res.Status = common.ResStatusFailed
-					res.Error = project.InternalError("No config path found for instance %s", instanceName)
-				} else {
-					// rewrite
-					rewriteMessages, err := rewriteConf(topologyConfPath, topologyConf)
-					if err != nil {
-						res.Status = common.ResStatusFailed
-						res.Error = err
-					} else {
-						res.Status = common.ResStatusOk
-					}
-
-					res.Messages = append(res.Messages, rewriteMessages...)
-
-					if ctx.Repair.Reload {
-						// reload
-						reloadMessages, err := reloadConf(topologyConfPath, instanceName, ctx)
-						if err != nil {
-							res.Status = common.ResStatusFailed
-							res.Error = err
-						} else {
-							res.Status = common.ResStatusOk
-						}
-
-						res.Messages = append(res.Messages, reloadMessages...)
-					}
-				}
+		newConfContentByHash[hash] = newConfContent
+	}
 
-				writeConfResCh <- res
-			}(instanceName, topologyConf, writeConfResCh)
+	for hash, newConfContent := range newConfContentByHash {
+		for _, instanceID := range appConfigs.instancesByHash[hash] {
+			go func(instanceID string, newConfContent []byte, writeConfResCh common.ResChan) {
+				writeConfResCh <- writeInstanceConf(instanceID, newConfContent, appConfigs, ctx)
+			}(instanceID, newConfContent, writeConfResCh)
 		}
 	}
 
@@ -201,6 +189,50 @@ func writeConfigs(appConfigs *AppConfigs, ctx *context.Ctx) error {
 	return nil
 }
 
+// writeInstanceConf rewrites (and reloads if it's required)
+// cluster-wide config of the specified instance.
+func writeInstanceConf(instanceID string, newConfContent []byte,
+	appConfigs *AppConfigs, ctx *context.Ctx) common.Result {
+	res := common.Result{
+		ID: instanceID,
+	}
+
+	topologyConfPath, found := appConfigs.confPathByInstanceID[instanceID]
+	if !found {
+		res.Status = common.ResStatusFailed
+		res.Error = project.InternalError("No config path found for instance %s", instanceID)
+		return res
+	}
+
+	instance := appConfigs.instanceByID[instanceID]
+
+	// rewrite
+	rewriteMessages, err := rewriteConf(instance.Host.fs, topologyConfPath, newConfContent)
+	if err != nil {
+		res.Status = common.ResStatusFailed
+		res.Error = err
+	} else {
+		res.Status = common.ResStatusOk
+	}
+
+	res.Messages = append(res.Messages, rewriteMessages...)
+
+	if ctx.Repair.Reload {
+		// reload
+		reloadMessages, err := reloadConf(topologyConfPath, instance.Name, ctx)
+		if err != nil {
+			res.Status = common.ResStatusFailed
+			res.Error = err
+		} else {
+			res.Status = common.ResStatusOk
+		}
+
+		res.Messages = append(res.Messages, reloadMessages...)
+	}
+
+	return res
+}
+
 func waitResults(resCh common.ResChan, resultsN int) error {
 	var errors []error
 
@@ -0,0 +1,267 @@
+package repair
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/tarantool/cartridge-cli/cli/common"
+	"github.com/tarantool/cartridge-cli/cli/context"
+	"github.com/tarantool/cartridge-cli/cli/project"
+)
+
+const (
+	shortHashLen = 12
+)
+
+func Sync(ctx *context.Ctx) error {
+	if ctx.Repair.SyncSource != "" {
+		log.Infof("Synchronize cluster-wide configurations with %s", ctx.Repair.SyncSource)
+	} else {
+		log.Infof("Synchronize cluster-wide configurations")
+	}
+
+	log.Debugf("Data directory is set to: %s", ctx.Running.DataDir)
+
+	hosts, err := getAppHosts(ctx)
+	if err != nil {
+		return err
+	}
+	defer hosts.Close()
+
+	instances, err := getAppInstances(hosts, ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to get application instances working directories: %s", err)
+	}
+
+	if !ctx.Repair.DryRun && ctx.Repair.Reload {
+		if err := checkThatReloadIsPossible(instances, ctx); err != nil {
+			return fmt.Errorf(
+				"Configurations reload isn't possible: %s", err,
+			)
+		}
+	}
+
+	appConfigs, err := getAppConfigs(instances, ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to get application cluster-wide configs: %s", err)
+	}
+
+	if !appConfigs.AreDifferent() {
+		log.Infof("Clusterwide config is the same on all instances, nothing to sync")
+		return nil
+	}
+
+	log.Infof("Clusterwide config is diverged between instances:\n%s", appConfigs.getVariantsSummary())
+
+	sourceHash, err := appConfigs.getSyncSourceHash(ctx.Repair.SyncSource)
+	if err != nil {
+		return fmt.Errorf("Failed to choose source config: %s", err)
+	}
+
+	log.Infof(
+		"Config %s (%s) is used as a source",
+		getShortHash(sourceHash), strings.Join(appConfigs.instancesByHash[sourceHash], ", "),
+	)
+
+	sourceInstanceID := appConfigs.instancesByHash[sourceHash][0]
+	sourceConfPath := appConfigs.confPathByInstanceID[sourceInstanceID]
+	sourceConfContent, err := appConfigs.instanceByID[sourceInstanceID].Host.fs.ReadFile(sourceConfPath)
+	if err != nil {
+		return fmt.Errorf("Failed to read source config: %s", err)
+	}
+
+	log.Infof("Process application cluster-wide configurations...")
+	if err := processSyncConfigs(&appConfigs, sourceHash, sourceConfContent, ctx); err != nil {
+		return err
+	}
+
+	// early-return
+	if ctx.Repair.DryRun {
+		return nil
+	}
+
+	if !ctx.Repair.Reload {
+		log.Infof("Write application cluster-wide configurations...")
+		log.Warnf("To reload cluster-wide configurations use --reload flag")
+	} else {
+		log.Infof("Write and reload application cluster-wide configurations...")
+	}
+
+	if err := writeSyncConfigs(&appConfigs, sourceHash, sourceConfContent, ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getSyncSourceHash returns a hash of config that should be used as a source.
+// Source can be specified as an instance name or a config hash (prefix).
+// If source isn't specified, config used by the majority of instances is chosen.
+// In case of equal number of instances the most recently modified config wins.
+func (d *AppConfigs) getSyncSourceHash(source string) (string, error) {
+	if source == "" {
+		var sourceHash string
+		for _, hash := range d.hashes {
+			if sourceHash == "" {
+				sourceHash = hash
+				continue
+			}
+
+			instancesN := len(d.instancesByHash[hash])
+			sourceInstancesN := len(d.instancesByHash[sourceHash])
+
+			if instancesN > sourceInstancesN ||
+				instancesN == sourceInstancesN && d.modTimeByHash[hash].After(d.modTimeByHash[sourceHash]) {
+				sourceHash = hash
+			}
+		}
+
+		return sourceHash, nil
+	}
+
+	if _, found := d.confPathByInstanceID[source]; found {
+		for _, hash := range d.hashes {
+			if common.StringSliceContains(d.instancesByHash[hash], source) {
+				return hash, nil
+			}
+		}
+
+		return "", project.InternalError("No config hash found for instance %s", source)
+	}
+
+	var matchedHashes []string
+	for _, hash := range d.hashes {
+		if strings.HasPrefix(hash, source) {
+			matchedHashes = append(matchedHashes, hash)
+		}
+	}
+
+	switch len(matchedHashes) {
+	case 0:
+		return "", fmt.Errorf("No instance or config hash %s found", source)
+	case 1:
+		return matchedHashes[0], nil
+	default:
+		return "", fmt.Errorf("Config hash prefix %s is ambiguous", source)
+	}
+}
+
+func (d *AppConfigs) getVariantsSummary() string {
+	summary := make([]string, len(d.hashes))
+
+	for i, hash := range d.hashes {
+		summary[i] = fmt.Sprintf(
+			"%s%s: %s", indent,
+			common.ColorCyan.Sprint(getShortHash(hash)),
+			strings.Join(d.instancesByHash[hash], ", "),
+		)
+	}
+
+	return strings.Join(summary, "\n")
+}
+
+func getShortHash(hash string) string {
+	if len(hash) <= shortHashLen {
+		return hash
+	}
+
+	return hash[:shortHashLen]
+}
+
+func processSyncConfigs(appConfigs *AppConfigs, sourceHash string, sourceConfContent []byte, ctx *context.Ctx) error {
+	sourceConfPath := appConfigs.confPathByInstanceID[appConfigs.instancesByHash[sourceHash][0]]
+
+	processConfResCh := make(common.ResChan)
+	for _, hash := range appConfigs.hashes {
+		if hash == sourceHash {
+			continue
+		}
+
+		go func(hash string, processConfResCh common.ResChan) {
+			res := common.Result{
+				ID: strings.Join(appConfigs.instancesByHash[hash], ", "),
+			}
+
+			messages, err := getSyncConfMessages(appConfigs, hash, sourceConfPath, sourceConfContent, ctx)
+			if err != nil {
+				res.Status = common.ResStatusFailed
+				res.Error = err
+			} else {
+				res.Status = common.ResStatusOk
+			}
+
+			res.Messages = messages
+
+			processConfResCh <- res
+		}(hash, processConfResCh)
+	}
+
+	if err := waitResults(processConfResCh, len(appConfigs.hashes)-1); err != nil {
+		return fmt.Errorf("Failed to process cluster-wide configurations")
+	}
+
+	return nil
+}
+
+func getSyncConfMessages(appConfigs *AppConfigs, hash string, sourceConfPath string,
+	sourceConfContent []byte, ctx *context.Ctx) ([]common.ResultMessage, error) {
+	var resMessages []common.ResultMessage
+
+	// clusterwide config can't be synced between instances
+	// that use different config formats (one file or directory)
+	for _, instanceID := range appConfigs.instancesByHash[hash] {
+		confPath := appConfigs.confPathByInstanceID[instanceID]
+		if filepath.Base(confPath) != filepath.Base(sourceConfPath) {
+			return nil, fmt.Errorf(
+				"Instance %s uses clusterwide config format that differs from the source one", instanceID,
+			)
+		}
+	}
+
+	if !ctx.Repair.DryRun && !ctx.Cli.Verbose {
+		return resMessages, nil
+	}
+
+	instanceID := appConfigs.instancesByHash[hash][0]
+	confPath := appConfigs.confPathByInstanceID[instanceID]
+	currentConfContent, err := appConfigs.instanceByID[instanceID].Host.fs.ReadFile(confPath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read current config: %s", err)
+	}
+
+	configDiff, err := getDiffLines(currentConfContent, sourceConfContent, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get config difference: %s", err)
+	}
+
+	resMessages = append(resMessages, common.GetInfoMessage((strings.Join(configDiff, "\n") + "\n")))
+
+	return resMessages, nil
+}
+
+func writeSyncConfigs(appConfigs *AppConfigs, sourceHash string, sourceConfContent []byte, ctx *context.Ctx) error {
+	writeConfResCh := make(common.ResChan)
+	instancesToSyncN := 0
+
+	for _, hash := range appConfigs.hashes {
+		if hash == sourceHash {
+			continue
+		}
+
+		for _, instanceID := range appConfigs.instancesByHash[hash] {
+			go func(instanceID string, writeConfResCh common.ResChan) {
+				writeConfResCh <- writeInstanceConf(instanceID, sourceConfContent, appConfigs, ctx)
+			}(instanceID, writeConfResCh)
+
+			instancesToSyncN++
+		}
+	}
+
+	if err := waitResults(writeConfResCh, instancesToSyncN); err != nil {
+		return fmt.Errorf("failed to sync cluster-wide configurations for some instances")
+	}
+
+	return nil
+}
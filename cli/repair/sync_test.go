@@ -0,0 +1,75 @@
+package repair
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSyncSourceHash(t *testing.T) {
+	assert := assert.New(t)
+
+	var err error
+	var sourceHash string
+
+	now := time.Now()
+
+	appConfigs := AppConfigs{
+		hashes: []string{"aaa111", "aaa222", "bbb333"},
+		instancesByHash: map[string][]string{
+			"aaa111": {"i-1"},
+			"aaa222": {"i-2", "i-3"},
+			"bbb333": {"i-4", "i-5"},
+		},
+		confPathByInstanceID: map[string]string{
+			"i-1": "i-1/config/topology.yml",
+			"i-2": "i-2/config/topology.yml",
+			"i-3": "i-3/config/topology.yml",
+			"i-4": "i-4/config/topology.yml",
+			"i-5": "i-5/config/topology.yml",
+		},
+		modTimeByHash: map[string]time.Time{
+			"aaa111": now.Add(time.Hour),
+			"aaa222": now,
+			"bbb333": now.Add(time.Minute),
+		},
+	}
+
+	// majority, the most recently modified one is chosen
+	sourceHash, err = appConfigs.getSyncSourceHash("")
+	assert.Nil(err)
+	assert.Equal("bbb333", sourceHash)
+
+	// majority
+	appConfigs.instancesByHash["aaa222"] = []string{"i-2", "i-3", "i-6"}
+	sourceHash, err = appConfigs.getSyncSourceHash("")
+	assert.Nil(err)
+	assert.Equal("aaa222", sourceHash)
+
+	// instance name
+	sourceHash, err = appConfigs.getSyncSourceHash("i-1")
+	assert.Nil(err)
+	assert.Equal("aaa111", sourceHash)
+
+	sourceHash, err = appConfigs.getSyncSourceHash("i-5")
+	assert.Nil(err)
+	assert.Equal("bbb333", sourceHash)
+
+	// hash
+	sourceHash, err = appConfigs.getSyncSourceHash("aaa222")
+	assert.Nil(err)
+	assert.Equal("aaa222", sourceHash)
+
+	// hash prefix
+	sourceHash, err = appConfigs.getSyncSourceHash("bbb")
+	assert.Nil(err)
+	assert.Equal("bbb333", sourceHash)
+
+	_, err = appConfigs.getSyncSourceHash("aaa")
+	assert.EqualError(err, "Config hash prefix aaa is ambiguous")
+
+	// unknown
+	_, err = appConfigs.getSyncSourceHash("i-7")
+	assert.EqualError(err, "No instance or config hash i-7 found")
+}
@@ -1,6 +1,7 @@
 package replicasets
 
 import (
+	gocontext "context"
 	"fmt"
 	"strings"
 
@@ -16,7 +17,7 @@ func BootstrapVshard(ctx *context.Ctx, args []string) error {
 		return err
 	}
 
-	if err := bootstrapVshard(conn); err != nil {
+	if err := bootstrapVshard(ctx.Cli.Context, conn); err != nil {
 		return fmt.Errorf("failed to bootstrap vshard: %s", err)
 	}
 
@@ -25,8 +26,8 @@ func BootstrapVshard(ctx *context.Ctx, args []string) error {
 	return nil
 }
 
-func bootstrapVshard(conn *connector.Conn) error {
-	req := connector.EvalReq(bootstrapVshardBody)
+func bootstrapVshard(reqCtx gocontext.Context, conn *connector.Conn) error {
+	req := connector.EvalReq(bootstrapVshardBody).SetContext(reqCtx)
 
 	if _, err := conn.Exec(req); err != nil {
 		if strings.Contains(err.Error(), `Sharding config is empty`) {
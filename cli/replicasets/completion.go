@@ -2,6 +2,7 @@ package replicasets
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/tarantool/cartridge-cli/cli/cluster"
@@ -15,48 +16,109 @@ const (
 	completionEvalTimeout = 3 * time.Second
 )
 
-func GetReplicasetRolesComp(ctx *context.Ctx) ([]string, error) {
-	if ctx.Replicasets.ReplicasetName == "" {
-		return nil, fmt.Errorf("Please, specify replica set name via --replicaset flag")
+// GetReplicasetsComp returns aliases of the current topology replica sets
+func GetReplicasetsComp(ctx *context.Ctx) ([]string, error) {
+	conn, err := connectComp(ctx)
+	if err != nil {
+		return nil, err
 	}
+	defer conn.Close()
 
-	if err := project.FillCtx(ctx); err != nil {
+	topologyReplicasets, err := GetTopologyReplicasets(conn)
+	if err != nil {
 		return nil, err
 	}
 
-	conn, err := cluster.ConnectToSomeJoinedInstance(ctx)
+	var replicasetAliases []string
+	for _, topologyReplicaset := range *topologyReplicasets {
+		replicasetAliases = append(replicasetAliases, topologyReplicaset.Alias)
+	}
+	sort.Strings(replicasetAliases)
+
+	return replicasetAliases, nil
+}
+
+// GetReplicasetInstancesComp returns aliases of the specified replica set instances
+func GetReplicasetInstancesComp(ctx *context.Ctx, replicasetAlias string) ([]string, error) {
+	conn, err := connectComp(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer conn.Close()
 
-	topologyReplicaset, err := getTopologyReplicaset(conn, ctx.Replicasets.ReplicasetName)
+	topologyReplicaset, err := getTopologyReplicaset(conn, replicasetAlias)
 	if err != nil {
 		return nil, err
 	}
 
-	return topologyReplicaset.Roles, nil
+	instanceAliases := make([]string, len(topologyReplicaset.Instances))
+	for i, topologyInstance := range topologyReplicaset.Instances {
+		instanceAliases[i] = topologyInstance.Alias
+	}
+
+	return instanceAliases, nil
 }
 
-func GetReplicasetRolesToAddComp(ctx *context.Ctx) ([]string, error) {
-	if err := project.FillCtx(ctx); err != nil {
+// GetKnownRolesComp returns names of all roles known by the cluster
+func GetKnownRolesComp(ctx *context.Ctx) ([]string, error) {
+	conn, err := connectComp(ctx)
+	if err != nil {
 		return nil, err
 	}
+	defer conn.Close()
 
-	conn, err := cluster.ConnectToSomeJoinedInstance(ctx)
+	return getKnownRoleNames(conn)
+}
+
+// GetVshardGroupsComp returns names of vshard groups known by the cluster
+func GetVshardGroupsComp(ctx *context.Ctx) ([]string, error) {
+	conn, err := connectComp(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer conn.Close()
 
-	// get all known roles
-	var knownRoles []Role
-	req := connector.EvalReq(getKnownRolesBody).SetReadTimeout(cluster.SimpleOperationTimeout)
-	if err := conn.ExecTyped(req, &knownRoles); err != nil {
-		return nil, fmt.Errorf("Failed to get known roles: %s", err)
+	req := connector.EvalReq(getKnownVshardGroupsBody).SetReadTimeout(completionEvalTimeout)
+
+	var knownVshardGroups []string
+	if err := conn.ExecTyped(req, &knownVshardGroups); err != nil {
+		return nil, fmt.Errorf("Failed to get known vshard groups: %s", err)
 	}
 
-	roleNames := make([]string, len(knownRoles))
-	for i, role := range knownRoles {
-		roleNames[i] = role.Name
+	sort.Strings(knownVshardGroups)
+
+	return knownVshardGroups, nil
+}
+
+func GetReplicasetRolesComp(ctx *context.Ctx) ([]string, error) {
+	if ctx.Replicasets.ReplicasetName == "" {
+		return nil, fmt.Errorf("Please, specify replica set name via --replicaset flag")
+	}
+
+	conn, err := connectComp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	topologyReplicaset, err := getTopologyReplicaset(conn, ctx.Replicasets.ReplicasetName)
+	if err != nil {
+		return nil, err
+	}
+
+	return topologyReplicaset.Roles, nil
+}
+
+func GetReplicasetRolesToAddComp(ctx *context.Ctx) ([]string, error) {
+	conn, err := connectComp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	roleNames, err := getKnownRoleNames(conn)
+	if err != nil {
+		return nil, err
 	}
 
 	// get replicaset roles
@@ -75,3 +137,28 @@ func GetReplicasetRolesToAddComp(ctx *context.Ctx) ([]string, error) {
 
 	return rolesToAdd, nil
 }
+
+// connectComp connects to some instance joined to the cluster
+// to get the values for completion
+func connectComp(ctx *context.Ctx) (*connector.Conn, error) {
+	if err := project.FillCtx(ctx); err != nil {
+		return nil, err
+	}
+
+	return cluster.ConnectToSomeJoinedInstance(ctx)
+}
+
+func getKnownRoleNames(conn *connector.Conn) ([]string, error) {
+	var knownRoles []Role
+	req := connector.EvalReq(getKnownRolesBody).SetReadTimeout(completionEvalTimeout)
+	if err := conn.ExecTyped(req, &knownRoles); err != nil {
+		return nil, fmt.Errorf("Failed to get known roles: %s", err)
+	}
+
+	roleNames := make([]string, len(knownRoles))
+	for i, role := range knownRoles {
+		roleNames[i] = role.Name
+	}
+
+	return roleNames, nil
+}
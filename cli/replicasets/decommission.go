@@ -0,0 +1,288 @@
+package replicasets
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/apex/log"
+
+	"github.com/tarantool/cartridge-cli/cli/cluster"
+	"github.com/tarantool/cartridge-cli/cli/common"
+	"github.com/tarantool/cartridge-cli/cli/connector"
+	"github.com/tarantool/cartridge-cli/cli/context"
+	"github.com/tarantool/cartridge-cli/cli/project"
+	"github.com/tarantool/cartridge-cli/cli/vshard"
+)
+
+const (
+	vshardStorageRole = "vshard-storage"
+
+	defaultVshardGroup = "default"
+
+	bucketsDrainCheckInterval = 1 * time.Second
+)
+
+// Decommission removes the replica set from the cluster.
+// For vshard-storage replica set weight is set to 0 and
+// all buckets are waited to be moved to other replica sets.
+// Then replica set instances are disabled and expelled
+func Decommission(ctx *context.Ctx, args []string) error {
+	if err := project.FillCtx(ctx); err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return fmt.Errorf("Should be specified one argument - replica set name")
+	}
+
+	replicasetName := args[0]
+
+	conn, err := cluster.ConnectToSomeJoinedInstance(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	topologyReplicasets, err := GetTopologyReplicasets(conn)
+	if err != nil {
+		return fmt.Errorf("Failed to get current topology replica sets: %s", err)
+	}
+
+	topologyReplicaset := topologyReplicasets.GetByAlias(replicasetName)
+	if topologyReplicaset == nil {
+		return fmt.Errorf("Replica set %s isn't found in current topology", replicasetName)
+	}
+
+	if err := checkDecommissionIsSafe(topologyReplicaset, topologyReplicasets); err != nil {
+		return fmt.Errorf("Replica set %s can't be decommissioned: %s", replicasetName, err)
+	}
+
+	// instance can't be expelled via it's own socket,
+	// so all changes are applied via instance from other replica set
+	conn, err = connectToInstanceOutsideReplicaset(topologyReplicaset, topologyReplicasets, ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if common.StringSliceContains(topologyReplicaset.Roles, vshardStorageRole) {
+		if err := drainReplicasetBuckets(conn, topologyReplicaset, ctx); err != nil {
+			return err
+		}
+	}
+
+	instancesUUIDs := make([]string, len(topologyReplicaset.Instances))
+	for i, topologyInstance := range topologyReplicaset.Instances {
+		instancesUUIDs[i] = topologyInstance.UUID
+	}
+
+	if _, err := editInstances(conn, getDisableInstancesEditInstancesOpts(instancesUUIDs)); err != nil {
+		return fmt.Errorf("Failed to disable replica set instances: %s", err)
+	}
+
+	log.Infof("Replica set %s instances are disabled", replicasetName)
+
+	editInstancesOpts, err := getExpelInstancesEditInstancesOpts(instancesUUIDs)
+	if err != nil {
+		return fmt.Errorf("Failed to get edit_topology options for expelling instances: %s", err)
+	}
+
+	if _, err := editInstances(conn, editInstancesOpts); err != nil {
+		return fmt.Errorf("Failed to expel replica set instances: %s", err)
+	}
+
+	log.Infof("Replica set %s has been successfully decommissioned", replicasetName)
+
+	return nil
+}
+
+// checkDecommissionIsSafe checks that after removing the replica set
+// each vshard group still has a storage that can accept buckets
+// and there is still a vshard-router in the cluster
+func checkDecommissionIsSafe(topologyReplicaset *TopologyReplicaset, topologyReplicasets *TopologyReplicasets) error {
+	if common.StringSliceContains(topologyReplicaset.Roles, vshardRouterRole) {
+		routerFound := false
+		for _, otherReplicaset := range *topologyReplicasets {
+			if otherReplicaset.UUID != topologyReplicaset.UUID &&
+				common.StringSliceContains(otherReplicaset.Roles, vshardRouterRole) {
+				routerFound = true
+				break
+			}
+		}
+
+		if !routerFound {
+			return fmt.Errorf("It's the last replica set with %s role", vshardRouterRole)
+		}
+	}
+
+	if common.StringSliceContains(topologyReplicaset.Roles, vshardStorageRole) {
+		vshardGroup := getReplicasetVshardGroup(topologyReplicaset)
+
+		storageFound := false
+		for _, otherReplicaset := range *topologyReplicasets {
+			if otherReplicaset.UUID != topologyReplicaset.UUID &&
+				common.StringSliceContains(otherReplicaset.Roles, vshardStorageRole) &&
+				getReplicasetVshardGroup(otherReplicaset) == vshardGroup &&
+				otherReplicaset.Weight != nil && *otherReplicaset.Weight > 0 {
+				storageFound = true
+				break
+			}
+		}
+
+		if !storageFound {
+			return fmt.Errorf(
+				"It's the last replica set with %s role and non-zero weight in vshard group %s",
+				vshardStorageRole, vshardGroup,
+			)
+		}
+	}
+
+	return nil
+}
+
+func getReplicasetVshardGroup(topologyReplicaset *TopologyReplicaset) string {
+	if topologyReplicaset.VshardGroup == nil {
+		return defaultVshardGroup
+	}
+
+	return *topologyReplicaset.VshardGroup
+}
+
+func connectToInstanceOutsideReplicaset(topologyReplicaset *TopologyReplicaset,
+	topologyReplicasets *TopologyReplicasets, ctx *context.Ctx) (*connector.Conn, error) {
+	var instancesNames []string
+	for _, otherReplicaset := range *topologyReplicasets {
+		if otherReplicaset.UUID == topologyReplicaset.UUID {
+			continue
+		}
+
+		for _, topologyInstance := range otherReplicaset.Instances {
+			instancesNames = append(instancesNames, topologyInstance.Alias)
+		}
+	}
+
+	sort.Strings(instancesNames)
+
+	for _, instanceName := range instancesNames {
+		conn, err := cluster.ConnectToInstance(instanceName, ctx)
+		if err == nil {
+			return conn, nil
+		}
+
+		log.Debugf("Failed to connect to %s: %s", instanceName, err)
+	}
+
+	return nil, fmt.Errorf("Not found any running instance outside of replica set %s", topologyReplicaset.Alias)
+}
+
+func drainReplicasetBuckets(conn *connector.Conn, topologyReplicaset *TopologyReplicaset, ctx *context.Ctx) error {
+	// buckets are never created on the storage until vshard is bootstrapped,
+	// so there is nothing to wait for
+	groupsStatus, err := vshard.GetStatus(conn)
+	if err != nil {
+		return err
+	}
+
+	storage := vshard.GetStorageStatus(groupsStatus, topologyReplicaset.UUID)
+	if storage == nil {
+		// it never appears in the status while the vshard group is unknown
+		return fmt.Errorf("Replica set %s storage isn't found in any vshard group", topologyReplicaset.Alias)
+	}
+
+	if !storage.IsBootstrapped() {
+		log.Infof("Vshard isn't bootstrapped on replica set %s, buckets draining is skipped", topologyReplicaset.Alias)
+		return nil
+	}
+
+	if topologyReplicaset.Weight == nil || *topologyReplicaset.Weight != 0 {
+		editReplicasetOpts, err := getSetWeightEditReplicasetOpts(0, topologyReplicaset)
+		if err != nil {
+			return fmt.Errorf("Failed to get edit_topology options for setting weight: %s", err)
+		}
+
+		if _, err := editReplicaset(ctx.Cli.Context, conn, editReplicasetOpts); err != nil {
+			return fmt.Errorf("Failed to set replica set weight: %s", err)
+		}
+
+		log.Infof("Replica set %s weight is set to 0", topologyReplicaset.Alias)
+	}
+
+	log.Infof("Wait until buckets are moved from replica set %s", topologyReplicaset.Alias)
+
+	deadline := time.Now().Add(ctx.Replicasets.DecommissionTimeout)
+
+	var prevErr error
+	for {
+		storage, err := getReplicasetStorageStatus(conn, topologyReplicaset)
+		if err != nil {
+			// the error that doesn't change between polls isn't going to be fixed
+			// by waiting, e.g. the storage is unavailable or misconfigured
+			if prevErr != nil && prevErr.Error() == err.Error() {
+				return err
+			}
+
+			log.Debugf("%s", err)
+			prevErr = err
+		} else {
+			prevErr = nil
+
+			// pinned buckets are never moved by rebalancer
+			if storage.Pinned > 0 {
+				return fmt.Errorf(
+					"Replica set %s has %d pinned buckets that can't be moved",
+					topologyReplicaset.Alias, storage.Pinned,
+				)
+			}
+
+			bucketsLeft := storage.Active + storage.Sending + storage.Receiving + storage.Garbage
+			if bucketsLeft == 0 {
+				log.Infof("All buckets are moved from replica set %s", topologyReplicaset.Alias)
+				return nil
+			}
+
+			log.Debugf("%d buckets left on replica set %s", bucketsLeft, topologyReplicaset.Alias)
+			err = fmt.Errorf("%d buckets are still on replica set", bucketsLeft)
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf(
+				"Timeout %s was reached while waiting for buckets to be moved: %s",
+				ctx.Replicasets.DecommissionTimeout, err,
+			)
+		}
+
+		time.Sleep(bucketsDrainCheckInterval)
+	}
+}
+
+func getReplicasetStorageStatus(conn *connector.Conn, topologyReplicaset *TopologyReplicaset) (*vshard.StorageStatus, error) {
+	groupsStatus, err := vshard.GetStatus(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	storage := vshard.GetStorageStatus(groupsStatus, topologyReplicaset.UUID)
+	if storage == nil {
+		return nil, fmt.Errorf("Failed to get replica set %s buckets status", topologyReplicaset.Alias)
+	}
+
+	if storage.Error != "" {
+		return nil, fmt.Errorf("Failed to get replica set %s buckets status: %s", topologyReplicaset.Alias, storage.Error)
+	}
+
+	return storage, nil
+}
+
+func getDisableInstancesEditInstancesOpts(instancesUUIDs []string) *EditInstancesListOpts {
+	editInstancesOpts := make(EditInstancesListOpts, len(instancesUUIDs))
+
+	for i, instanceUUID := range instancesUUIDs {
+		editInstancesOpts[i] = &EditInstanceOpts{
+			InstanceUUID: instanceUUID,
+			Disabled:     true,
+		}
+	}
+
+	return &editInstancesOpts
+}
@@ -0,0 +1,89 @@
+package replicasets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDecommissionIsSafe(t *testing.T) {
+	assert := assert.New(t)
+
+	var err error
+
+	zeroWeight := 0.0
+	weight := 1.0
+	hotGroup := "hot"
+
+	topologyReplicasets := TopologyReplicasets{
+		"router-uuid": &TopologyReplicaset{
+			UUID:  "router-uuid",
+			Alias: "router",
+			Roles: []string{"vshard-router", "app.roles.custom"},
+		},
+		"s-1-uuid": &TopologyReplicaset{
+			UUID:   "s-1-uuid",
+			Alias:  "s-1",
+			Roles:  []string{"vshard-storage"},
+			Weight: &weight,
+		},
+		"s-2-uuid": &TopologyReplicaset{
+			UUID:   "s-2-uuid",
+			Alias:  "s-2",
+			Roles:  []string{"vshard-storage"},
+			Weight: &weight,
+		},
+		"hot-uuid": &TopologyReplicaset{
+			UUID:        "hot-uuid",
+			Alias:       "hot",
+			Roles:       []string{"vshard-storage"},
+			Weight:      &weight,
+			VshardGroup: &hotGroup,
+		},
+		"custom-uuid": &TopologyReplicaset{
+			UUID:  "custom-uuid",
+			Alias: "custom",
+			Roles: []string{"app.roles.custom"},
+		},
+	}
+
+	// storage with other storage in the same group
+	err = checkDecommissionIsSafe(topologyReplicasets["s-1-uuid"], &topologyReplicasets)
+	assert.Nil(err)
+
+	// replicaset without vshard roles
+	err = checkDecommissionIsSafe(topologyReplicasets["custom-uuid"], &topologyReplicasets)
+	assert.Nil(err)
+
+	// the last storage in the group
+	err = checkDecommissionIsSafe(topologyReplicasets["hot-uuid"], &topologyReplicasets)
+	assert.EqualError(err, "It's the last replica set with vshard-storage role and non-zero weight in vshard group hot")
+
+	// other storage in the group has zero weight
+	topologyReplicasets["s-2-uuid"].Weight = &zeroWeight
+	err = checkDecommissionIsSafe(topologyReplicasets["s-1-uuid"], &topologyReplicasets)
+	assert.EqualError(err, "It's the last replica set with vshard-storage role and non-zero weight in vshard group default")
+
+	// zero weight replicaset can be decommissioned if other storage has non-zero weight
+	err = checkDecommissionIsSafe(topologyReplicasets["s-2-uuid"], &topologyReplicasets)
+	assert.Nil(err)
+
+	// the last router
+	err = checkDecommissionIsSafe(topologyReplicasets["router-uuid"], &topologyReplicasets)
+	assert.EqualError(err, "It's the last replica set with vshard-router role")
+
+	// other router exists
+	topologyReplicasets["custom-uuid"].Roles = append(topologyReplicasets["custom-uuid"].Roles, "vshard-router")
+	err = checkDecommissionIsSafe(topologyReplicasets["router-uuid"], &topologyReplicasets)
+	assert.Nil(err)
+}
+
+func TestGetDisableInstancesEditInstancesOpts(t *testing.T) {
+	assert := assert.New(t)
+
+	opts := getDisableInstancesEditInstancesOpts([]string{"uuid-1", "uuid-2"})
+	assert.Equal(&EditInstancesListOpts{
+		&EditInstanceOpts{InstanceUUID: "uuid-1", Disabled: true},
+		&EditInstanceOpts{InstanceUUID: "uuid-2", Disabled: true},
+	}, opts)
+}
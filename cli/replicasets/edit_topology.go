@@ -1,6 +1,7 @@
 package replicasets
 
 import (
+	gocontext "context"
 	"fmt"
 	"strings"
 	"time"
@@ -36,6 +37,7 @@ type EditReplicasetsListOpts []*EditReplicasetOpts
 type EditInstanceOpts struct {
 	InstanceUUID string `structs:"uuid,omitempty"`
 	Expelled     bool   `structs:"expelled,omitempty"`
+	Disabled     bool   `structs:"disabled,omitempty"`
 }
 
 type EditInstancesListOpts []*EditInstanceOpts
@@ -85,13 +87,17 @@ func init() {
 	}
 }
 
-func editReplicasetsList(conn *connector.Conn, opts *EditReplicasetsListOpts) (*TopologyReplicasets, error) {
+// editReplicasetsList applies the replica sets changes via edit_topology.
+// The request is interrupted when reqCtx is done, but the changes
+// can still be applied by the cluster in this case
+func editReplicasetsList(reqCtx gocontext.Context, conn *connector.Conn,
+	opts *EditReplicasetsListOpts) (*TopologyReplicasets, error) {
 	waitForHealthy, err := cluster.HealthCheckIsNeeded(conn)
 	if err != nil {
 		return nil, err
 	}
 
-	req := connector.EvalReq(editReplicasetsBody, opts.ToMapsList())
+	req := connector.EvalReq(editReplicasetsBody, opts.ToMapsList()).SetContext(reqCtx)
 
 	var newTopologyReplicasetsList []*TopologyReplicaset
 	if err := conn.ExecTyped(req, &newTopologyReplicasetsList); err != nil {
@@ -101,7 +107,7 @@ func editReplicasetsList(conn *connector.Conn, opts *EditReplicasetsListOpts) (*
 	newTopologyReplicasets := getTopologyReplicasetsFromList(newTopologyReplicasetsList)
 
 	if waitForHealthy {
-		if err := waitForClusterIsHealthy(conn); err != nil {
+		if err := waitForClusterIsHealthy(reqCtx, conn); err != nil {
 			return nil, fmt.Errorf("Failed to wait for cluster to become healthy: %s", err)
 		}
 	}
@@ -109,9 +115,9 @@ func editReplicasetsList(conn *connector.Conn, opts *EditReplicasetsListOpts) (*
 	return newTopologyReplicasets, nil
 }
 
-func editReplicaset(conn *connector.Conn, opts *EditReplicasetOpts) (*TopologyReplicaset, error) {
+func editReplicaset(reqCtx gocontext.Context, conn *connector.Conn, opts *EditReplicasetOpts) (*TopologyReplicaset, error) {
 	editReplicasetsOpts := &EditReplicasetsListOpts{opts}
-	newTopologyReplicasets, err := editReplicasetsList(conn, editReplicasetsOpts)
+	newTopologyReplicasets, err := editReplicasetsList(reqCtx, conn, editReplicasetsOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -135,18 +141,19 @@ func editInstances(conn *connector.Conn, opts *EditInstancesListOpts) (bool, err
 	return true, nil
 }
 
-func waitForClusterIsHealthy(conn *connector.Conn) error {
+func waitForClusterIsHealthy(reqCtx gocontext.Context, conn *connector.Conn) error {
 	retryOpts := []retry.Option{
 		retry.MaxDelay(1 * time.Second),
 		retry.Attempts(30),
 		retry.LastErrorOnly(true),
+		retry.Context(reqCtx),
 		retry.RetryIf(func(err error) bool {
 			return !strings.Contains(err.Error(), "Received in bad format")
 		}),
 	}
 
 	checkClusterIsHealthyFunc := func() error {
-		req := connector.EvalReq(getClusterIsHealthyBody)
+		req := connector.EvalReq(getClusterIsHealthyBody).SetContext(reqCtx)
 		var isHealthy bool
 
 		if err := conn.ExecTyped(req, &isHealthy); err != nil {
@@ -47,7 +47,7 @@ func SetFailoverPriority(ctx *context.Ctx, args []string) error {
 			fmt.Errorf("Failed to get edit_topology options for setting failover priority: %s", err))
 	}
 
-	newTopologyReplicaset, err := editReplicaset(conn, editReplicasetOpts)
+	newTopologyReplicaset, err := editReplicaset(ctx.Cli.Context, conn, editReplicasetOpts)
 	if err != nil {
 		return fmt.Errorf("Failed to set failover priority: %s", err)
 	}
@@ -47,7 +47,7 @@ func Join(ctx *context.Ctx, args []string) error {
 		return err
 	}
 
-	topologyReplicasets, err := getTopologyReplicasets(conn)
+	topologyReplicasets, err := GetTopologyReplicasets(conn)
 	if err != nil {
 		return fmt.Errorf("Failed to get current topology replica sets: %s", err)
 	}
@@ -61,7 +61,7 @@ func Join(ctx *context.Ctx, args []string) error {
 			fmt.Errorf("Failed to get edit_topology options for joining instances: %s", err))
 	}
 
-	if _, err = editReplicaset(conn, editReplicasetOpts); err != nil {
+	if _, err = editReplicaset(ctx.Cli.Context, conn, editReplicasetOpts); err != nil {
 		return fmt.Errorf("Failed to join instances: %s", err)
 	}
 
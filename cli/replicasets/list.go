@@ -5,19 +5,41 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/apex/log"
 	"github.com/tarantool/cartridge-cli/cli/cluster"
 	"github.com/tarantool/cartridge-cli/cli/common"
+	"github.com/tarantool/cartridge-cli/cli/connector"
 	"github.com/tarantool/cartridge-cli/cli/context"
 	"github.com/tarantool/cartridge-cli/cli/project"
+	"github.com/vmihailenco/msgpack/v5"
 )
 
 const (
 	instanceMarker       = "•"
 	leaderInstanceMarker = "★"
+
+	// time to wait for a response from each instance
+	instanceUpstreamsTimeout = 3 * time.Second
 )
 
+type InstanceUpstreams struct {
+	URI       string              `mapstructure:"uri"`
+	Upstreams []*InstanceUpstream `mapstructure:"upstreams"`
+
+	Error string `mapstructure:"error"`
+}
+
+type InstanceUpstream struct {
+	UUID   string `mapstructure:"uuid"`
+	Status string `mapstructure:"status"`
+}
+
+func (instanceUpstreams *InstanceUpstreams) DecodeMsgpack(d *msgpack.Decoder) error {
+	return common.DecodeMsgpackStruct(d, instanceUpstreams)
+}
+
 func List(ctx *context.Ctx, args []string) error {
 	var err error
 
@@ -25,16 +47,39 @@ func List(ctx *context.Ctx, args []string) error {
 		return err
 	}
 
+	if ctx.Replicasets.ListFormat != "" {
+		if err := common.CheckGraphFormat(ctx.Replicasets.ListFormat); err != nil {
+			return err
+		}
+	}
+
 	conn, err := cluster.ConnectToSomeJoinedInstance(ctx)
 	if err != nil {
 		return err
 	}
 
-	topologyReplicasets, err := getTopologyReplicasets(conn)
+	topologyReplicasets, err := GetTopologyReplicasets(conn)
 	if err != nil {
 		return fmt.Errorf("Failed to get current topology replica sets: %s", err)
 	}
 
+	if ctx.Replicasets.ListFormat != "" {
+		instancesUpstreams, err := getInstancesUpstreams(conn, getTopologyInstancesURIs(topologyReplicasets))
+		if err != nil {
+			return err
+		}
+
+		graph, err := common.RenderTopologyGraph(
+			getTopologyReplicasetsGraph(topologyReplicasets, instancesUpstreams), ctx.Replicasets.ListFormat,
+		)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(graph)
+		return nil
+	}
+
 	replicasetsSummary := getTopologyReplicasetsSummary(topologyReplicasets)
 
 	log.Infof("Current replica sets:\n%s", replicasetsSummary)
@@ -42,7 +87,7 @@ func List(ctx *context.Ctx, args []string) error {
 	return nil
 }
 
-func getTopologyReplicasetsSummary(topologyReplicasets *TopologyReplicasets) string {
+func getSortedTopologyReplicasets(topologyReplicasets *TopologyReplicasets) []*TopologyReplicaset {
 	// sort replicasets by aliases
 	replicasetsList := make([]*TopologyReplicaset, len(*topologyReplicasets))
 	i := 0
@@ -55,6 +100,106 @@ func getTopologyReplicasetsSummary(topologyReplicasets *TopologyReplicasets) str
 		return replicasetsList[i].Alias < replicasetsList[j].Alias
 	})
 
+	return replicasetsList
+}
+
+func getTopologyInstancesURIs(topologyReplicasets *TopologyReplicasets) []string {
+	var uris []string
+
+	for _, topologyReplicaset := range getSortedTopologyReplicasets(topologyReplicasets) {
+		for _, topologyInstance := range topologyReplicaset.Instances {
+			if topologyInstance.URI != "" {
+				uris = append(uris, topologyInstance.URI)
+			}
+		}
+	}
+
+	return uris
+}
+
+// getInstancesUpstreams returns replication upstreams of the instances
+func getInstancesUpstreams(conn *connector.Conn, uris []string) ([]*InstanceUpstreams, error) {
+	// Instances are polled in parallel, so the request time doesn't depend
+	// on the number of instances, only on the instance upstreams timeout
+	req := connector.EvalReq(getInstancesUpstreamsBody, uris, instanceUpstreamsTimeout.Seconds()).
+		SetReadTimeout(instanceUpstreamsTimeout + cluster.SimpleOperationTimeout)
+
+	var instancesUpstreams []*InstanceUpstreams
+	if err := conn.ExecTyped(req, &instancesUpstreams); err != nil {
+		return nil, fmt.Errorf("Failed to get instances replication upstreams: %s", err)
+	}
+
+	return instancesUpstreams, nil
+}
+
+// getTopologyReplicasetsGraph returns topology graph
+// that can be rendered in Graphviz or Mermaid formats.
+// Replication links are built from the instances upstreams,
+// instances that failed to report upstreams are shown w/o links
+func getTopologyReplicasetsGraph(topologyReplicasets *TopologyReplicasets,
+	instancesUpstreams []*InstanceUpstreams) *common.TopologyGraph {
+
+	graph := common.TopologyGraph{
+		ShowReplication: true,
+	}
+
+	instancesUpstreamsByURI := make(map[string]*InstanceUpstreams)
+	for _, instanceUpstreams := range instancesUpstreams {
+		if instanceUpstreams.Error != "" {
+			log.Warnf("Failed to get %s replication upstreams: %s", instanceUpstreams.URI, instanceUpstreams.Error)
+			continue
+		}
+
+		instancesUpstreamsByURI[instanceUpstreams.URI] = instanceUpstreams
+	}
+
+	for _, topologyReplicaset := range getSortedTopologyReplicasets(topologyReplicasets) {
+		graphReplicaset := common.GraphReplicaset{
+			ID:       topologyReplicaset.UUID,
+			Alias:    topologyReplicaset.Alias,
+			Roles:    topologyReplicaset.Roles,
+			Weight:   topologyReplicaset.Weight,
+			LeaderID: topologyReplicaset.LeaderUUID,
+		}
+
+		if topologyReplicaset.VshardGroup != nil {
+			graphReplicaset.VshardGroup = *topologyReplicaset.VshardGroup
+		}
+
+		if topologyReplicaset.AllRW != nil {
+			graphReplicaset.AllRW = *topologyReplicaset.AllRW
+		}
+
+		for _, topologyInstance := range topologyReplicaset.Instances {
+			graphInstance := common.GraphInstance{
+				ID:       topologyInstance.UUID,
+				Alias:    topologyInstance.Alias,
+				URI:      topologyInstance.URI,
+				Zone:     topologyInstance.Zone,
+				Disabled: topologyInstance.Disabled,
+			}
+
+			if instanceUpstreams, found := instancesUpstreamsByURI[topologyInstance.URI]; found {
+				for _, upstream := range instanceUpstreams.Upstreams {
+					graphInstance.Upstreams = append(graphInstance.Upstreams, &common.GraphUpstream{
+						ID:     upstream.UUID,
+						Status: upstream.Status,
+					})
+				}
+			}
+
+			graphReplicaset.Instances = append(graphReplicaset.Instances, &graphInstance)
+		}
+
+		graph.Replicasets = append(graph.Replicasets, &graphReplicaset)
+	}
+
+	return &graph
+}
+
+func getTopologyReplicasetsSummary(topologyReplicasets *TopologyReplicasets) string {
+	replicasetsList := getSortedTopologyReplicasets(topologyReplicasets)
+
 	// get replicasets summaries in sorted aliases order
 	replicasetsSummary := make([]string, len(*topologyReplicasets))
 	for i, topologyReplicaset := range replicasetsList {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tarantool/cartridge-cli/cli/common"
 )
 
 func TestGetReplicasetSummary(t *testing.T) {
@@ -223,3 +224,76 @@ func TestGetReplicasetSummary(t *testing.T) {
 
 	assert.Equal(expSummary, summary)
 }
+
+func TestGetTopologyReplicasetsGraph(t *testing.T) {
+	assert := assert.New(t)
+
+	vshardGroup := "hot"
+	weight := 123.4
+	allRW := true
+
+	topologyReplicasets := getTopologyReplicasetsFromList([]*TopologyReplicaset{
+		{
+			UUID:        "rpl-2",
+			Alias:       "storage",
+			Roles:       []string{"vshard-storage"},
+			VshardGroup: &vshardGroup,
+			Weight:      &weight,
+			AllRW:       &allRW,
+			LeaderUUID:  "uuid-2",
+			Instances: TopologyInstances{
+				&TopologyInstance{Alias: "storage-1", UUID: "uuid-2", URI: "uri-2", Zone: "msk"},
+				&TopologyInstance{Alias: "storage-2", UUID: "uuid-3", URI: "uri-3", Disabled: true},
+			},
+		},
+		{
+			UUID:       "rpl-1",
+			Alias:      "router",
+			Roles:      []string{"vshard-router"},
+			LeaderUUID: "uuid-1",
+			Instances: TopologyInstances{
+				&TopologyInstance{Alias: "router-1", UUID: "uuid-1", URI: "uri-1"},
+			},
+		},
+	})
+
+	instancesUpstreams := []*InstanceUpstreams{
+		{URI: "uri-1"},
+		{URI: "uri-2", Upstreams: []*InstanceUpstream{{UUID: "uuid-3", Status: "follow"}}},
+		{URI: "uri-3", Error: "Timed out"},
+	}
+
+	graph := getTopologyReplicasetsGraph(topologyReplicasets, instancesUpstreams)
+	assert.True(graph.ShowReplication)
+	assert.Len(graph.Replicasets, 2)
+
+	// replicasets are sorted by aliases
+	router := graph.Replicasets[0]
+	assert.Equal("rpl-1", router.ID)
+	assert.Equal("router", router.Alias)
+	assert.Equal("", router.VshardGroup)
+	assert.Nil(router.Weight)
+	assert.False(router.AllRW)
+	assert.Len(router.Instances, 1)
+
+	storage := graph.Replicasets[1]
+	assert.Equal("rpl-2", storage.ID)
+	assert.Equal([]string{"vshard-storage"}, storage.Roles)
+	assert.Equal("hot", storage.VshardGroup)
+	assert.Equal(123.4, *storage.Weight)
+	assert.True(storage.AllRW)
+	assert.Equal("uuid-2", storage.LeaderID)
+	assert.Len(storage.Instances, 2)
+	assert.Equal("storage-1", storage.Instances[0].Alias)
+	assert.Equal("uri-2", storage.Instances[0].URI)
+	assert.Equal("msk", storage.Instances[0].Zone)
+	assert.False(storage.Instances[0].Disabled)
+	assert.True(storage.Instances[1].Disabled)
+
+	// upstreams of the instances that failed to report them aren't shown
+	assert.Nil(router.Instances[0].Upstreams)
+	assert.Equal([]*common.GraphUpstream{{ID: "uuid-3", Status: "follow"}}, storage.Instances[0].Upstreams)
+	assert.Nil(storage.Instances[1].Upstreams)
+
+	assert.Equal([]string{"uri-1", "uri-2", "uri-3"}, getTopologyInstancesURIs(topologyReplicasets))
+}
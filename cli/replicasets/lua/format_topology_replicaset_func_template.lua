@@ -1,11 +1,14 @@
 local function {{ .FormatTopologyReplicasetFuncName }}(replicaset)
     local instances = {}
-    for _, server in pairs(replicaset.servers) do
+    -- servers are sorted by failover priority
+    for _, server in ipairs(replicaset.servers) do
         local instance = {
             alias = server.alias,
             uuid = server.uuid,
             uri = server.uri,
             zone = server.zone,
+            status = server.status,
+            disabled = server.disabled,
         }
         table.insert(instances, instance)
     end
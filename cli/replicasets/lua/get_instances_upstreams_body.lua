@@ -0,0 +1,72 @@
+local fiber = require('fiber')
+local pool = require('cartridge.pool')
+
+local uris, timeout = ...
+
+local INSTANCE_UPSTREAMS_BODY = [[
+    local upstreams = {}
+    for _, replica in pairs(box.info.replication) do
+        if replica.upstream ~= nil then
+            -- replica UUID identifies the upstream peer
+            table.insert(upstreams, {
+                uuid = replica.uuid,
+                status = replica.upstream.status,
+            })
+        end
+    end
+
+    return upstreams
+]]
+
+local function get_upstreams(uri)
+    local conn, err = pool.connect(uri, {wait_connected = false})
+    if conn == nil then
+        return nil, tostring(err)
+    end
+
+    local ok, res = pcall(conn.eval, conn, INSTANCE_UPSTREAMS_BODY, {}, {timeout = timeout})
+    if not ok then
+        return nil, tostring(res)
+    end
+
+    return res
+end
+
+-- Instances are polled in parallel, each request is limited by the timeout
+local result = {}
+local done = fiber.channel(#uris)
+
+for i, uri in ipairs(uris) do
+    local instance = {uri = uri}
+    result[i] = instance
+
+    fiber.create(function()
+        local upstreams, err = get_upstreams(uri)
+        if upstreams == nil then
+            instance.error = err
+        else
+            instance.upstreams = setmetatable(upstreams, {__serialize = 'seq'})
+        end
+
+        instance.done = true
+        done:put(true, 0)
+    end)
+end
+
+-- connection establishment is included in the eval timeout,
+-- so all requests should be finished by the deadline
+local deadline = fiber.clock() + timeout + 1
+for _ = 1, #uris do
+    if done:get(math.max(deadline - fiber.clock(), 0)) == nil then
+        break
+    end
+end
+
+for _, instance in ipairs(result) do
+    if not instance.done then
+        instance.error = 'Timed out'
+    end
+    instance.done = nil
+end
+
+return unpack(result)
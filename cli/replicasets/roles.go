@@ -134,7 +134,7 @@ func updateRoles(ctx *context.Ctx, getNewRolesListFunc GetNewRolesListFunc, vsha
 		return fmt.Errorf("Failed to get edit_topology options for roles updating: %s", err)
 	}
 
-	newTopologyReplicaset, err := editReplicaset(conn, editReplicasetOpts)
+	newTopologyReplicaset, err := editReplicaset(ctx.Cli.Context, conn, editReplicasetOpts)
 	if err != nil {
 		return fmt.Errorf("Failed to update roles list: %s", err)
 	}
@@ -35,7 +35,7 @@ func Save(ctx *context.Ctx, args []string) error {
 		return err
 	}
 
-	topologyReplicasets, err := getTopologyReplicasets(conn)
+	topologyReplicasets, err := GetTopologyReplicasets(conn)
 	if err != nil {
 		return fmt.Errorf("Failed to get current topology replicasets: %s", err)
 	}
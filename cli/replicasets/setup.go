@@ -69,14 +69,14 @@ func Setup(ctx *context.Ctx, args []string) error {
 		return err
 	}
 
-	topologyReplicasets, err := getTopologyReplicasets(conn)
+	topologyReplicasets, err := GetTopologyReplicasets(conn)
 	if err != nil {
 		return fmt.Errorf("Failed to get current topology replicasets: %s", err)
 	}
 
 	log.Debugf("Setup replicasets")
 
-	newTopologyReplicasets, err := setupReplicasets(conn, replicasetsList, instancesConf, topologyReplicasets)
+	newTopologyReplicasets, err := setupReplicasets(ctx, conn, replicasetsList, instancesConf, topologyReplicasets)
 	if err != nil {
 		return err
 	}
@@ -97,14 +97,19 @@ func Setup(ctx *context.Ctx, args []string) error {
 		// vshard bootstrapping, so I've just added this `magic` retry
 		// to prevent confusing error.
 
+		if err := checkSetupIsInterrupted(ctx, "bootstrapping vshard"); err != nil {
+			return err
+		}
+
 		retryOpts := []retry.Option{
 			retry.MaxDelay(1 * time.Second),
 			retry.Attempts(5),
 			retry.LastErrorOnly(true),
+			retry.Context(ctx.Cli.Context),
 		}
 
 		bootstrapVshardFunc := func() error {
-			return bootstrapVshard(conn)
+			return bootstrapVshard(ctx.Cli.Context, conn)
 		}
 
 		if err := retry.Do(bootstrapVshardFunc, retryOpts...); err != nil {
@@ -117,8 +122,8 @@ func Setup(ctx *context.Ctx, args []string) error {
 	return nil
 }
 
-func setupReplicasets(conn *connector.Conn, replicasetsList *ReplicasetsList, instancesConf *cluster.InstancesConf,
-	topologyReplicasets *TopologyReplicasets) (*TopologyReplicasets, error) {
+func setupReplicasets(ctx *context.Ctx, conn *connector.Conn, replicasetsList *ReplicasetsList,
+	instancesConf *cluster.InstancesConf, topologyReplicasets *TopologyReplicasets) (*TopologyReplicasets, error) {
 
 	var err error
 
@@ -137,7 +142,7 @@ func setupReplicasets(conn *connector.Conn, replicasetsList *ReplicasetsList, in
 		// since in old Cartridge bootstrapping cluster from scratch should be
 		// performed on a single-server replicaset only
 
-		firstTopologyReplicaset, err := createFirstReplicasetInOldCartridge(conn, replicasetsList, instancesConf)
+		firstTopologyReplicaset, err := createFirstReplicasetInOldCartridge(ctx, conn, replicasetsList, instancesConf)
 		if err != nil {
 			return nil, err
 		}
@@ -145,8 +150,12 @@ func setupReplicasets(conn *connector.Conn, replicasetsList *ReplicasetsList, in
 		(*newTopologyReplicasets)[firstTopologyReplicaset.UUID] = firstTopologyReplicaset
 	}
 
+	if err := checkSetupIsInterrupted(ctx, "creating and updating replica sets"); err != nil {
+		return nil, err
+	}
+
 	// create new replicasets and update current
-	newTopologyReplicasets, err = createAndUpdateReplicasets(conn, replicasetsList, instancesConf, newTopologyReplicasets)
+	newTopologyReplicasets, err = createAndUpdateReplicasets(ctx, conn, replicasetsList, instancesConf, newTopologyReplicasets)
 	if err != nil {
 		return nil, err
 	}
@@ -156,7 +165,11 @@ func setupReplicasets(conn *connector.Conn, replicasetsList *ReplicasetsList, in
 	// to change failover priority.
 	// Generally, we know all instances UUIDs only after creating replicaset or
 	// joining new instances to the existing one.
-	newTopologyReplicasets, err = setFailoverPriority(conn, replicasetsList, newTopologyReplicasets)
+	if err := checkSetupIsInterrupted(ctx, "setting failover priority"); err != nil {
+		return nil, err
+	}
+
+	newTopologyReplicasets, err = setFailoverPriority(ctx, conn, replicasetsList, newTopologyReplicasets)
 	if err != nil {
 		return nil, err
 	}
@@ -164,7 +177,7 @@ func setupReplicasets(conn *connector.Conn, replicasetsList *ReplicasetsList, in
 	return newTopologyReplicasets, nil
 }
 
-func createAndUpdateReplicasets(conn *connector.Conn, replicasetsList *ReplicasetsList, instancesConf *cluster.InstancesConf,
+func createAndUpdateReplicasets(ctx *context.Ctx, conn *connector.Conn, replicasetsList *ReplicasetsList, instancesConf *cluster.InstancesConf,
 	topologyReplicasets *TopologyReplicasets) (*TopologyReplicasets, error) {
 
 	editReplicasetsOpts := &EditReplicasetsListOpts{}
@@ -186,7 +199,7 @@ func createAndUpdateReplicasets(conn *connector.Conn, replicasetsList *Replicase
 		}
 	}
 
-	newTopologyReplicasets, err := editReplicasetsList(conn, editReplicasetsOpts)
+	newTopologyReplicasets, err := editReplicasetsList(ctx.Cli.Context, conn, editReplicasetsOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -194,7 +207,7 @@ func createAndUpdateReplicasets(conn *connector.Conn, replicasetsList *Replicase
 	return newTopologyReplicasets, nil
 }
 
-func createFirstReplicasetInOldCartridge(conn *connector.Conn, replicasetsList *ReplicasetsList, instancesConf *cluster.InstancesConf) (*TopologyReplicaset, error) {
+func createFirstReplicasetInOldCartridge(ctx *context.Ctx, conn *connector.Conn, replicasetsList *ReplicasetsList, instancesConf *cluster.InstancesConf) (*TopologyReplicaset, error) {
 	firstReplicasetConf := *(*replicasetsList)[0]
 	firstReplicasetConf.InstanceNames = firstReplicasetConf.InstanceNames[:1]
 
@@ -203,19 +216,19 @@ func createFirstReplicasetInOldCartridge(conn *connector.Conn, replicasetsList *
 		return nil, fmt.Errorf("Failed to get edit_topology options for creating replicaset: %s", err)
 	}
 
-	newTopologyReplicaset, err := editReplicaset(conn, editReplicasetOpts)
+	newTopologyReplicaset, err := editReplicaset(ctx.Cli.Context, conn, editReplicasetOpts)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := waitForClusterIsHealthy(conn); err != nil {
+	if err := waitForClusterIsHealthy(ctx.Cli.Context, conn); err != nil {
 		return nil, fmt.Errorf("Failed to wait for cluster to become healthy: %s", err)
 	}
 
 	return newTopologyReplicaset, nil
 }
 
-func setFailoverPriority(conn *connector.Conn, replicasetsList *ReplicasetsList, topologyReplicasets *TopologyReplicasets) (*TopologyReplicasets, error) {
+func setFailoverPriority(ctx *context.Ctx, conn *connector.Conn, replicasetsList *ReplicasetsList, topologyReplicasets *TopologyReplicasets) (*TopologyReplicasets, error) {
 	editReplicasetsOpts := EditReplicasetsListOpts{}
 
 	for _, replicasetConf := range *replicasetsList {
@@ -230,7 +243,7 @@ func setFailoverPriority(conn *connector.Conn, replicasetsList *ReplicasetsList,
 		editReplicasetsOpts = append(editReplicasetsOpts, editReplicasetOpts)
 	}
 
-	newTopologyReplicasets, err := editReplicasetsList(conn, &editReplicasetsOpts)
+	newTopologyReplicasets, err := editReplicasetsList(ctx.Cli.Context, conn, &editReplicasetsOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -309,7 +322,7 @@ func getConnToSetupReplicasets(replicasetsList *ReplicasetsList, instancesConf *
 	}
 
 	consoleSockPath := project.GetInstanceConsoleSock(ctx, controlInstanceName)
-	conn, err := connector.Connect(consoleSockPath, connector.Opts{})
+	conn, err := connector.ConnectContext(ctx.Cli.Context, consoleSockPath, connector.Opts{})
 	if err != nil {
 		return nil, fmt.Errorf("Failed to connect to Tarantool instance: %s", err)
 	}
@@ -379,3 +392,15 @@ func getUpdateReplicasetEditReplicasetsOpts(topologyReplicaset *TopologyReplicas
 
 	return &editReplicasetOpts, nil
 }
+
+// checkSetupIsInterrupted is called between setup steps.
+// Each step is performed by one request and is applied by the cluster atomically.
+// On SIGINT or timeout the running step request is interrupted,
+// and the next step isn't started
+func checkSetupIsInterrupted(ctx *context.Ctx, nextStep string) error {
+	if err := ctx.Cli.Context.Err(); err != nil {
+		return fmt.Errorf("Setup is interrupted before %s: %s", nextStep, err)
+	}
+
+	return nil
+}
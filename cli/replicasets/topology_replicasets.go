@@ -17,6 +17,8 @@ type TopologyInstance struct {
 
 	Zone string
 
+	Status   string
+	Disabled bool
 	Expelled bool
 }
 
@@ -80,7 +82,8 @@ func (topologyReplicasets *TopologyReplicasets) GetSomeReplicaset() *TopologyRep
 	return nil
 }
 
-func getTopologyReplicasets(conn *connector.Conn) (*TopologyReplicasets, error) {
+// GetTopologyReplicasets returns current cluster replica sets
+func GetTopologyReplicasets(conn *connector.Conn) (*TopologyReplicasets, error) {
 	req := connector.EvalReq(getTopologyReplicasetsBody).SetReadTimeout(cluster.SimpleOperationTimeout)
 
 	var topologyReplicasetsList []*TopologyReplicaset
@@ -110,8 +113,41 @@ func (topologyReplicasets *TopologyReplicasets) GetByAlias(alias string) *Topolo
 	return nil
 }
 
+// FilterInstances returns aliases of the topology instances that match the filters.
+// Empty replicasetAlias and role match any replica set.
+// If leadersOnly is set, only replica sets leaders are matched.
+// An error is returned if the specified replica set isn't found in the topology
+func FilterInstances(topologyReplicasets *TopologyReplicasets,
+	replicasetAlias, role string, leadersOnly bool) (map[string]bool, error) {
+
+	if replicasetAlias != "" && topologyReplicasets.GetByAlias(replicasetAlias) == nil {
+		return nil, fmt.Errorf("Replica set %s isn't found in current topology", replicasetAlias)
+	}
+
+	matchingInstances := make(map[string]bool)
+	for _, topologyReplicaset := range *topologyReplicasets {
+		if replicasetAlias != "" && topologyReplicaset.Alias != replicasetAlias {
+			continue
+		}
+
+		if role != "" && !common.StringSliceContains(topologyReplicaset.Roles, role) {
+			continue
+		}
+
+		for _, topologyInstance := range topologyReplicaset.Instances {
+			if leadersOnly && topologyInstance.UUID != topologyReplicaset.LeaderUUID {
+				continue
+			}
+
+			matchingInstances[topologyInstance.Alias] = true
+		}
+	}
+
+	return matchingInstances, nil
+}
+
 func getTopologyReplicaset(conn *connector.Conn, replicasetAlias string) (*TopologyReplicaset, error) {
-	topologyReplicasets, err := getTopologyReplicasets(conn)
+	topologyReplicasets, err := GetTopologyReplicasets(conn)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to get current topology replica sets: %s", err)
 	}
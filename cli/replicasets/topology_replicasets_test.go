@@ -0,0 +1,55 @@
+package replicasets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterInstances(t *testing.T) {
+	assert := assert.New(t)
+
+	topologyReplicasets := &TopologyReplicasets{
+		"router-uuid": &TopologyReplicaset{
+			Alias: "router",
+			Roles: []string{"vshard-router"},
+			Instances: TopologyInstances{
+				{Alias: "router", UUID: "router-1"},
+			},
+			LeaderUUID: "router-1",
+		},
+		"s1-uuid": &TopologyReplicaset{
+			Alias: "s-1",
+			Roles: []string{"vshard-storage"},
+			Instances: TopologyInstances{
+				{Alias: "s1-master", UUID: "s1-1"},
+				{Alias: "s1-replica", UUID: "s1-2"},
+			},
+			LeaderUUID: "s1-1",
+		},
+	}
+
+	// no filters
+	matchingInstances, err := FilterInstances(topologyReplicasets, "", "", false)
+	assert.Nil(err)
+	assert.Equal(map[string]bool{"router": true, "s1-master": true, "s1-replica": true}, matchingInstances)
+
+	// replica set
+	matchingInstances, err = FilterInstances(topologyReplicasets, "s-1", "", false)
+	assert.Nil(err)
+	assert.Equal(map[string]bool{"s1-master": true, "s1-replica": true}, matchingInstances)
+
+	// role and leaders
+	matchingInstances, err = FilterInstances(topologyReplicasets, "", "vshard-storage", true)
+	assert.Nil(err)
+	assert.Equal(map[string]bool{"s1-master": true}, matchingInstances)
+
+	// replica set doesn't have the role
+	matchingInstances, err = FilterInstances(topologyReplicasets, "router", "vshard-storage", false)
+	assert.Nil(err)
+	assert.Len(matchingInstances, 0)
+
+	// unknown replica set
+	_, err = FilterInstances(topologyReplicasets, "unknown", "", false)
+	assert.EqualError(err, "Replica set unknown isn't found in current topology")
+}
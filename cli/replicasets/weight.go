@@ -43,7 +43,7 @@ func SetWeight(ctx *context.Ctx, args []string) error {
 		return fmt.Errorf("Failed to get edit_topology options for setting weight: %s", err)
 	}
 
-	newTopologyReplicaset, err := editReplicaset(conn, editReplicasetOpts)
+	newTopologyReplicaset, err := editReplicaset(ctx.Cli.Context, conn, editReplicasetOpts)
 	if err != nil {
 		return fmt.Errorf("Failed to update roles list: %s", err)
 	}
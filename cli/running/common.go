@@ -74,6 +74,11 @@ func CollectInstancesFromConf(ctx *context.Ctx) ([]string, error) {
 func collectProcesses(ctx *context.Ctx) (*ProcessesSet, error) {
 	processes := ProcessesSet{}
 
+	if ctx.Running.WithEtcd && !ctx.Running.StateboardOnly {
+		process := NewEtcdProcess(ctx)
+		processes.Add(process)
+	}
+
 	if ctx.Running.WithStateboard {
 		process := NewStateboardProcess(ctx)
 		processes.Add(process)
@@ -194,4 +194,31 @@ func TestCollectProcesses(t *testing.T) {
 		[]string{"myapp-stateboard"},
 		getProcessesIDs(processes),
 	)
+
+	// project w/ etcd
+	ctx.Project.Name = "myapp"
+	ctx.Running.WithStateboard = false
+	ctx.Running.StateboardOnly = false
+	ctx.Running.WithEtcd = true
+	ctx.Running.EtcdListen = "localhost:2379"
+	ctx.Running.EtcdEmbedded = true
+	ctx.Running.Instances = []string{"storage", "router"}
+
+	processes, err = collectProcesses(ctx)
+	assert.Nil(err)
+	assert.ElementsMatch(
+		[]string{"myapp.router", "myapp.storage", "myapp-etcd"},
+		getProcessesIDs(processes),
+	)
+
+	// etcd isn't managed w/ stateboard only
+	ctx.Running.WithStateboard = true
+	ctx.Running.StateboardOnly = true
+
+	processes, err = collectProcesses(ctx)
+	assert.Nil(err)
+	assert.ElementsMatch(
+		[]string{"myapp-stateboard"},
+		getProcessesIDs(processes),
+	)
 }
@@ -0,0 +1,157 @@
+package running
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/apex/log"
+
+	"github.com/tarantool/cartridge-cli/cli/context"
+	"github.com/tarantool/cartridge-cli/cli/project"
+)
+
+const (
+	// hidden command that runs built-in etcd v2 stand-in
+	etcdStandInCommand = "etcd-standin"
+)
+
+var (
+	etcdVersionRgx = regexp.MustCompile(`etcd Version:\s*(\d+)\.(\d+)`)
+)
+
+// NewEtcdProcess returns the process of the local etcd used as
+// a failover state provider.
+// etcd binary is used if it's available and supports v2 API (etcd < 3.6),
+// otherwise the built-in etcd v2 stand-in is started
+func NewEtcdProcess(ctx *context.Ctx) *Process {
+	var process Process
+
+	process.ID = project.GetEtcdName(ctx)
+
+	process.runDir = ctx.Running.RunDir
+	process.pidFile = project.GetEtcdPidFile(ctx)
+	process.workDir = project.GetEtcdWorkDir(ctx)
+	process.logDir = ctx.Running.LogDir
+	process.logFile = project.GetEtcdLogFile(ctx)
+
+	process.notifySockPath = project.GetEtcdNotifySockPath(ctx)
+
+	if err := setEtcdCommand(&process, ctx); err != nil {
+		process.Status = procStatusError
+		process.Error = err
+		return &process
+	}
+
+	process.SetPidAndStatus()
+
+	return &process
+}
+
+func setEtcdCommand(process *Process, ctx *context.Ctx) error {
+	host, portStr, err := net.SplitHostPort(ctx.Running.EtcdListen)
+	if err != nil {
+		return fmt.Errorf("Invalid etcd listen address %q: %s", ctx.Running.EtcdListen, err)
+	}
+
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return fmt.Errorf("Invalid etcd listen port %q: %s", portStr, err)
+	}
+
+	if !ctx.Running.EtcdEmbedded {
+		etcdPath, err := getEtcdPathWithV2Support(ctx.Running.EtcdBinary)
+		if err == nil {
+			process.executable = etcdPath
+			process.args = getEtcdArgs(process.ID, process.workDir, host, port)
+			process.processName = filepath.Base(etcdPath)
+
+			return nil
+		}
+
+		log.Debugf("Built-in etcd v2 stand-in is used: %s", err)
+	}
+
+	process.executable, err = os.Executable()
+	if err != nil {
+		return fmt.Errorf("Failed to get cartridge executable path: %s", err)
+	}
+
+	process.args = []string{
+		etcdStandInCommand,
+		"--name", process.ID,
+		"--listen", ctx.Running.EtcdListen,
+		"--data-dir", process.workDir,
+	}
+	process.processName = filepath.Base(process.executable)
+
+	return nil
+}
+
+func getEtcdPathWithV2Support(etcdBinary string) (string, error) {
+	etcdPath, err := exec.LookPath(etcdBinary)
+	if err != nil {
+		return "", fmt.Errorf("etcd executable isn't found: %s", err)
+	}
+
+	versionOutput, err := exec.Command(etcdPath, "--version").Output()
+	if err != nil {
+		return "", fmt.Errorf("Failed to get etcd version: %s", err)
+	}
+
+	supportsV2, err := etcdVersionSupportsV2(string(versionOutput))
+	if err != nil {
+		return "", err
+	}
+
+	if !supportsV2 {
+		return "", fmt.Errorf("etcd %s doesn't support v2 API", etcdPath)
+	}
+
+	return etcdPath, nil
+}
+
+// etcdVersionSupportsV2 checks `etcd --version` output.
+// v2 API is removed in etcd 3.6
+func etcdVersionSupportsV2(versionOutput string) (bool, error) {
+	matches := etcdVersionRgx.FindStringSubmatch(versionOutput)
+	if matches == nil {
+		return false, fmt.Errorf("Failed to parse etcd version: %q", versionOutput)
+	}
+
+	major, _ := strconv.Atoi(matches[1])
+	minor, _ := strconv.Atoi(matches[2])
+
+	return major < 3 || (major == 3 && minor < 6), nil
+}
+
+func getEtcdArgs(name, dataDir, host string, port int) []string {
+	if host == "" {
+		host = "localhost"
+	}
+
+	// etcd requires IP addresses in URLs to listen on
+	listenHost := host
+	if listenHost == "localhost" {
+		listenHost = "127.0.0.1"
+	}
+
+	clientURL := fmt.Sprintf("http://%s", net.JoinHostPort(host, strconv.Itoa(port)))
+	listenClientURL := fmt.Sprintf("http://%s", net.JoinHostPort(listenHost, strconv.Itoa(port)))
+	peerURL := fmt.Sprintf("http://%s", net.JoinHostPort(listenHost, strconv.Itoa(port+1)))
+
+	return []string{
+		"--name", name,
+		"--data-dir", dataDir,
+		"--listen-client-urls", listenClientURL,
+		"--advertise-client-urls", clientURL,
+		"--listen-peer-urls", peerURL,
+		"--initial-advertise-peer-urls", peerURL,
+		"--initial-cluster", fmt.Sprintf("%s=%s", name, peerURL),
+		"--enable-v2=true",
+	}
+}
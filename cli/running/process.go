@@ -70,6 +70,12 @@ type Process struct {
 
 	entrypoint string
 
+	// executable is started with args,
+	// processName is the expected name of the running process
+	executable  string
+	args        []string
+	processName string
+
 	runDir      string
 	workDir     string
 	pidFile     string
@@ -136,8 +142,8 @@ func (process *Process) SetPidAndStatus() {
 		return
 	}
 
-	if name != "tarantool" {
-		log.Warnf("Process %s does not seem to be tarantool", name)
+	if name != process.processName {
+		log.Warnf("Process %s does not seem to be %s", name, process.processName)
 	}
 
 	if err := process.osProcess.SendSignal(syscall.Signal(0)); err != nil {
@@ -154,8 +160,10 @@ func (process *Process) IsRunning() bool {
 func (process *Process) Start(daemonize bool, disableLogPrefix bool) error {
 	var err error
 
-	if _, err := os.Stat(process.entrypoint); err != nil {
-		return fmt.Errorf("Can't use instance entrypoint: %s", err)
+	if process.entrypoint != "" {
+		if _, err := os.Stat(process.entrypoint); err != nil {
+			return fmt.Errorf("Can't use instance entrypoint: %s", err)
+		}
 	}
 
 	// create run dir
@@ -175,7 +183,7 @@ func (process *Process) Start(daemonize bool, disableLogPrefix bool) error {
 	}
 
 	ctx := goContext.Background()
-	process.cmd = exec.CommandContext(ctx, "tarantool", process.entrypoint)
+	process.cmd = exec.CommandContext(ctx, process.executable, process.args...)
 
 	process.cmd.Env = append(os.Environ(), process.env...)
 
@@ -337,6 +345,9 @@ func NewInstanceProcess(ctx *context.Ctx, instanceName string) *Process {
 	process.ID = fmt.Sprintf("%s.%s", ctx.Project.Name, instanceName)
 
 	process.entrypoint = getEntrypointPath(ctx.Running.AppDir, ctx.Running.Entrypoint)
+	process.executable = "tarantool"
+	process.args = []string{process.entrypoint}
+	process.processName = "tarantool"
 	process.runDir = ctx.Running.RunDir
 	process.pidFile = project.GetInstancePidFile(ctx, instanceName)
 	process.workDir = project.GetInstanceWorkDir(ctx, instanceName)
@@ -366,6 +377,9 @@ func NewStateboardProcess(ctx *context.Ctx) *Process {
 	process.ID = ctx.Project.StateboardName
 
 	process.entrypoint = getEntrypointPath(ctx.Running.AppDir, ctx.Running.StateboardEntrypoint)
+	process.executable = "tarantool"
+	process.args = []string{process.entrypoint}
+	process.processName = "tarantool"
 	process.runDir = ctx.Running.RunDir
 	process.pidFile = project.GetStateboardPidFile(ctx)
 	process.workDir = project.GetStateboardWorkDir(ctx)
@@ -441,6 +455,11 @@ func (process *Process) Clean() common.Result {
 	var skipped = true
 
 	for _, path := range pathsToDelete {
+		if path == "" {
+			// e.g. etcd has no console socket
+			continue
+		}
+
 		if _, err := os.Stat(path); os.IsNotExist(err) {
 			nonExistedFiles = append(nonExistedFiles, path)
 		} else if err != nil {
@@ -27,6 +27,8 @@ func TestNewInstanceProcess(t *testing.T) {
 
 	assert.Equal("myapp.instance-1", process.ID)
 	assert.Equal("apps/myapp/init.lua", process.entrypoint)
+	assert.Equal("tarantool", process.executable)
+	assert.Equal([]string{"apps/myapp/init.lua"}, process.args)
 
 	assert.Equal("tmp/data/myapp.instance-1", process.workDir)
 	assert.Equal("tmp/run", process.runDir)
@@ -89,6 +91,105 @@ func TestNewStateboardProcess(t *testing.T) {
 	assert.ElementsMatch(expEnv, process.env)
 }
 
+func TestNewEtcdProcess(t *testing.T) {
+	t.Parallel()
+
+	assert := assert.New(t)
+
+	ctx := &context.Ctx{}
+	process := &Process{}
+
+	ctx.Project.Name = "myapp"
+	ctx.Running.RunDir = "tmp/run"
+	ctx.Running.DataDir = "tmp/data"
+	ctx.Running.LogDir = "tmp/log"
+	ctx.Running.EtcdListen = "localhost:2379"
+	ctx.Running.EtcdEmbedded = true
+
+	process = NewEtcdProcess(ctx)
+
+	assert.Equal("myapp-etcd", process.ID)
+	assert.Equal("", process.entrypoint)
+	assert.Equal([]string{
+		"etcd-standin",
+		"--name", "myapp-etcd",
+		"--listen", "localhost:2379",
+		"--data-dir", "tmp/data/myapp-etcd",
+	}, process.args)
+
+	assert.Equal("tmp/data/myapp-etcd", process.workDir)
+	assert.Equal("tmp/run", process.runDir)
+	assert.Equal("tmp/run/myapp-etcd.pid", process.pidFile)
+	assert.Equal("tmp/log", process.logDir)
+	assert.Equal("tmp/log/myapp-etcd.log", process.logFile)
+	assert.Equal("", process.consoleSock)
+
+	assert.Equal("tmp/run/myapp-etcd.notify", process.notifySockPath)
+
+	// etcd binary isn't found
+	ctx.Running.EtcdEmbedded = false
+	ctx.Running.EtcdBinary = "/non/existent/etcd"
+
+	process = NewEtcdProcess(ctx)
+	assert.Equal("etcd-standin", process.args[0])
+
+	// invalid listen address
+	ctx.Running.EtcdListen = "localhost"
+
+	process = NewEtcdProcess(ctx)
+	assert.Equal(procStatusError, process.Status)
+	assert.Contains(process.Error.Error(), `Invalid etcd listen address "localhost"`)
+}
+
+func TestEtcdVersionSupportsV2(t *testing.T) {
+	t.Parallel()
+
+	assert := assert.New(t)
+
+	supportsV2, err := etcdVersionSupportsV2("etcd Version: 3.4.27\nGit SHA: c92fb80f3\nGo Version: go1.19.10\n")
+	assert.Nil(err)
+	assert.True(supportsV2)
+
+	supportsV2, err = etcdVersionSupportsV2("etcd Version: 3.5.9\nGit SHA: bdbbde998\n")
+	assert.Nil(err)
+	assert.True(supportsV2)
+
+	supportsV2, err = etcdVersionSupportsV2("etcd Version: 3.6.0\nGit SHA: 1a5d44e\n")
+	assert.Nil(err)
+	assert.False(supportsV2)
+
+	_, err = etcdVersionSupportsV2("unknown output")
+	assert.NotNil(err)
+}
+
+func TestGetEtcdArgs(t *testing.T) {
+	t.Parallel()
+
+	assert := assert.New(t)
+
+	assert.Equal([]string{
+		"--name", "myapp-etcd",
+		"--data-dir", "tmp/data/myapp-etcd",
+		"--listen-client-urls", "http://127.0.0.1:2379",
+		"--advertise-client-urls", "http://localhost:2379",
+		"--listen-peer-urls", "http://127.0.0.1:2380",
+		"--initial-advertise-peer-urls", "http://127.0.0.1:2380",
+		"--initial-cluster", "myapp-etcd=http://127.0.0.1:2380",
+		"--enable-v2=true",
+	}, getEtcdArgs("myapp-etcd", "tmp/data/myapp-etcd", "localhost", 2379))
+
+	assert.Equal([]string{
+		"--name", "myapp-etcd",
+		"--data-dir", "tmp/data/myapp-etcd",
+		"--listen-client-urls", "http://10.0.0.1:12379",
+		"--advertise-client-urls", "http://10.0.0.1:12379",
+		"--listen-peer-urls", "http://10.0.0.1:12380",
+		"--initial-advertise-peer-urls", "http://10.0.0.1:12380",
+		"--initial-cluster", "myapp-etcd=http://10.0.0.1:12380",
+		"--enable-v2=true",
+	}, getEtcdArgs("myapp-etcd", "tmp/data/myapp-etcd", "10.0.0.1", 12379))
+}
+
 func TestPathToEntrypoint(t *testing.T) {
 	t.Parallel()
 
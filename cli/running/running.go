@@ -58,6 +58,11 @@ func FillCtx(ctx *context.Ctx, args []string) error {
 		ctx.Running.WithStateboard = false
 	}
 
+	// the same for the local etcd
+	if len(args) > 0 && !ctx.Running.EtcdFlagIsSet {
+		ctx.Running.WithEtcd = false
+	}
+
 	if len(ctx.Running.Instances) > 0 && ctx.Running.StateboardOnly {
 		log.Warnf("Specified instances are ignored due to stateboard-only flag")
 	}
@@ -0,0 +1,210 @@
+local fiber = require('fiber')
+local cartridge = require('cartridge')
+local pool = require('cartridge.pool')
+local vshard_utils = require('cartridge.vshard-utils')
+
+local timeout = ...
+
+local STORAGE_INFO_BODY = [[
+    local vshard = require('vshard')
+
+    if box.space._bucket == nil then
+        return nil, 'vshard storage is not bootstrapped'
+    end
+
+    local buckets = {}
+    for _, status in ipairs({'active', 'pinned', 'sending', 'receiving', 'garbage', 'sent'}) do
+        buckets[status] = box.space._bucket.index.status:count(status)
+    end
+
+    local internal = vshard.storage.internal or {}
+
+    local rebalancing = buckets.sending > 0 or buckets.receiving > 0
+    if type(vshard.storage.rebalancing_is_in_progress) == 'function' then
+        rebalancing = rebalancing or vshard.storage.rebalancing_is_in_progress()
+    end
+
+    return {
+        active = buckets.active,
+        pinned = buckets.pinned,
+        sending = buckets.sending,
+        receiving = buckets.receiving,
+        garbage = buckets.garbage + buckets.sent,
+        rebalancer = internal.rebalancer_fiber ~= nil,
+        rebalancing = rebalancing,
+    }
+]]
+
+local ROUTER_INFO_BODY = [[
+    local group_name = ...
+
+    local vshard_router = require('cartridge').service_get('vshard-router')
+    if vshard_router == nil then
+        return nil, 'vshard-router role is not enabled'
+    end
+
+    local router = vshard_router.get(group_name)
+    if router == nil then
+        return nil, string.format('router for group %q is not configured', group_name)
+    end
+
+    local info = router:info()
+
+    -- vshard returns status as a number
+    local status_names = {[0] = 'green', [1] = 'yellow', [2] = 'orange', [3] = 'red'}
+
+    local alerts = {}
+    for _, alert in ipairs(info.alerts or {}) do
+        table.insert(alerts, string.format('%s: %s', alert[1], alert[2]))
+    end
+
+    return {
+        status = status_names[info.status] or tostring(info.status),
+        available_rw = info.bucket.available_rw,
+        available_ro = info.bucket.available_ro,
+        unreachable = info.bucket.unreachable,
+        unknown = info.bucket.unknown,
+        alerts = alerts,
+    }
+]]
+
+local function eval_on(uri, body, ...)
+    local conn, err = pool.connect(uri, {wait_connected = false})
+    if conn == nil then
+        return nil, tostring(err)
+    end
+
+    local ok, res, err = pcall(conn.eval, conn, body, {...}, {timeout = timeout})
+    if not ok then
+        return nil, tostring(res)
+    end
+
+    if res == nil then
+        return nil, tostring(err)
+    end
+
+    return res
+end
+
+-- Instances are polled in parallel, each request is limited by the timeout
+local tasks = {}
+
+local function add_task(target, uri, body, ...)
+    table.insert(tasks, {target = target, uri = uri, body = body, args = {...}})
+end
+
+local function run_tasks()
+    local done = fiber.channel(#tasks)
+
+    for _, task in ipairs(tasks) do
+        fiber.create(function()
+            local info, err = eval_on(task.uri, task.body, unpack(task.args))
+            if info == nil then
+                task.target.error = err
+            else
+                for key, value in pairs(info) do
+                    task.target[key] = value
+                end
+            end
+
+            task.done = true
+            done:put(true, 0)
+        end)
+    end
+
+    -- connection establishment is included in the eval timeout,
+    -- so all tasks should be finished by the deadline
+    local deadline = fiber.clock() + timeout + 1
+    for _ = 1, #tasks do
+        if done:get(math.max(deadline - fiber.clock(), 0)) == nil then
+            break
+        end
+    end
+
+    for _, task in ipairs(tasks) do
+        if not task.done then
+            task.target.error = 'Timed out'
+        end
+    end
+end
+
+local replicasets, err = cartridge.admin_get_replicasets()
+
+if err ~= nil then
+    err = err.err
+end
+
+assert(err == nil, tostring(err))
+
+local known_groups = vshard_utils.get_known_groups()
+
+local groups = {}
+local groups_names = {}
+for group_name, group_params in pairs(known_groups) do
+    groups[group_name] = {
+        name = group_name,
+        bucket_count = group_params.bucket_count,
+        rebalancer_disbalance_threshold = group_params.rebalancer_disbalance_threshold,
+        storages = {},
+        routers = {},
+    }
+    table.insert(groups_names, group_name)
+end
+table.sort(groups_names)
+
+local routers = {}
+
+for _, replicaset in pairs(replicasets) do
+    local roles = {}
+    for _, role in pairs(replicaset.roles) do
+        roles[role] = true
+    end
+
+    if roles['vshard-storage'] then
+        local group_name = replicaset.vshard_group or 'default'
+        local master = replicaset.active_master or replicaset.master
+
+        local storage = {
+            replicaset_uuid = replicaset.uuid,
+            alias = replicaset.alias,
+            weight = replicaset.weight,
+            master_uri = master.uri,
+        }
+
+        add_task(storage, master.uri, STORAGE_INFO_BODY)
+
+        if groups[group_name] ~= nil then
+            table.insert(groups[group_name].storages, storage)
+        end
+    end
+
+    if roles['vshard-router'] then
+        for _, server in pairs(replicaset.servers) do
+            if not server.disabled then
+                table.insert(routers, server)
+            end
+        end
+    end
+end
+
+for _, group_name in ipairs(groups_names) do
+    for _, server in ipairs(routers) do
+        local router = {
+            alias = server.alias,
+            uri = server.uri,
+        }
+
+        add_task(router, server.uri, ROUTER_INFO_BODY, group_name)
+
+        table.insert(groups[group_name].routers, router)
+    end
+end
+
+run_tasks()
+
+local result = {}
+for _, group_name in ipairs(groups_names) do
+    table.insert(result, groups[group_name])
+end
+
+return unpack(result)
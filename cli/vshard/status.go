@@ -0,0 +1,385 @@
+package vshard
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/tarantool/cartridge-cli/cli/cluster"
+	"github.com/tarantool/cartridge-cli/cli/common"
+	"github.com/tarantool/cartridge-cli/cli/connector"
+	"github.com/tarantool/cartridge-cli/cli/context"
+	"github.com/tarantool/cartridge-cli/cli/project"
+)
+
+const (
+	// vshard default
+	defaultRebalancerDisbalanceThreshold = 1.0
+
+	// time to wait for a response from each storage and router
+	instanceInfoTimeout = 3 * time.Second
+
+	// error returned by get_vshard_status_body.lua for the storage without _bucket space
+	storageNotBootstrappedErr = "vshard storage is not bootstrapped"
+)
+
+type StorageStatus struct {
+	ReplicasetUUID string  `mapstructure:"replicaset_uuid"`
+	Alias          string  `mapstructure:"alias"`
+	Weight         float64 `mapstructure:"weight"`
+	MasterURI      string  `mapstructure:"master_uri"`
+
+	Active    int `mapstructure:"active"`
+	Pinned    int `mapstructure:"pinned"`
+	Sending   int `mapstructure:"sending"`
+	Receiving int `mapstructure:"receiving"`
+	Garbage   int `mapstructure:"garbage"`
+
+	Rebalancer  bool `mapstructure:"rebalancer"`
+	Rebalancing bool `mapstructure:"rebalancing"`
+
+	Error string `mapstructure:"error"`
+}
+
+type RouterStatus struct {
+	Alias string `mapstructure:"alias"`
+	URI   string `mapstructure:"uri"`
+
+	Status      string   `mapstructure:"status"`
+	AvailableRW int      `mapstructure:"available_rw"`
+	AvailableRO int      `mapstructure:"available_ro"`
+	Unreachable int      `mapstructure:"unreachable"`
+	Unknown     int      `mapstructure:"unknown"`
+	Alerts      []string `mapstructure:"alerts"`
+
+	Error string `mapstructure:"error"`
+}
+
+type GroupStatus struct {
+	Name                          string   `mapstructure:"name"`
+	BucketCount                   int      `mapstructure:"bucket_count"`
+	RebalancerDisbalanceThreshold *float64 `mapstructure:"rebalancer_disbalance_threshold"`
+
+	Storages []*StorageStatus `mapstructure:"storages"`
+	Routers  []*RouterStatus  `mapstructure:"routers"`
+}
+
+func (groupStatus *GroupStatus) DecodeMsgpack(d *msgpack.Decoder) error {
+	return common.DecodeMsgpackStruct(d, groupStatus)
+}
+
+func Status(ctx *context.Ctx) error {
+	if err := project.FillCtx(ctx); err != nil {
+		return err
+	}
+
+	conn, err := cluster.ConnectToSomeJoinedInstance(ctx)
+	if err != nil {
+		return err
+	}
+
+	groupsStatus, err := GetStatus(conn)
+	if err != nil {
+		return err
+	}
+
+	if len(groupsStatus) == 0 {
+		log.Infof(
+			"No vshard groups available. " +
+				"It's possible that your application hasn't vshard-router role registered",
+		)
+		return nil
+	}
+
+	log.Infof("Vshard status:\n%s", getVshardStatusSummary(groupsStatus))
+
+	return nil
+}
+
+// GetStatus returns buckets status of each vshard group.
+// Status is collected from all vshard-storage masters and vshard-router instances
+func GetStatus(conn *connector.Conn) ([]*GroupStatus, error) {
+	// Instances are polled in parallel, each one is limited by instanceInfoTimeout,
+	// so the whole request doesn't depend on the number of instances
+	req := connector.EvalReq(getVshardStatusBody, instanceInfoTimeout.Seconds()).
+		SetReadTimeout(cluster.SimpleOperationTimeout)
+
+	var groupsStatus []*GroupStatus
+	if err := conn.ExecTyped(req, &groupsStatus); err != nil {
+		return nil, fmt.Errorf("Failed to get vshard status: %s", err)
+	}
+
+	for _, groupStatus := range groupsStatus {
+		sort.Slice(groupStatus.Storages, func(i, j int) bool {
+			return groupStatus.Storages[i].Alias < groupStatus.Storages[j].Alias
+		})
+
+		sort.Slice(groupStatus.Routers, func(i, j int) bool {
+			return groupStatus.Routers[i].Alias < groupStatus.Routers[j].Alias
+		})
+	}
+
+	return groupsStatus, nil
+}
+
+// GetStorageStatus returns the status of the specified replicaset storage
+func GetStorageStatus(groupsStatus []*GroupStatus, replicasetUUID string) *StorageStatus {
+	for _, groupStatus := range groupsStatus {
+		for _, storage := range groupStatus.Storages {
+			if storage.ReplicasetUUID == replicasetUUID {
+				return storage
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsBootstrapped returns false if vshard wasn't bootstrapped on the storage,
+// i.e. buckets were never created on it
+func (storage *StorageStatus) IsBootstrapped() bool {
+	return storage.Error != storageNotBootstrappedErr
+}
+
+// getNotBalancedReason returns a reason why group buckets aren't balanced.
+// Empty string is returned if buckets are balanced.
+// Buckets are considered balanced if there are no buckets in transfer
+// and each storage has the number of buckets that is proportional to
+// its weight with respect to the rebalancer disbalance threshold
+func (groupStatus *GroupStatus) getNotBalancedReason() string {
+	totalBuckets := 0
+
+	for _, storage := range groupStatus.Storages {
+		if storage.Error != "" {
+			return fmt.Sprintf("Failed to get %s status: %s", storage.Alias, storage.Error)
+		}
+
+		if storage.Sending > 0 || storage.Receiving > 0 {
+			return fmt.Sprintf("%s has buckets in transfer", storage.Alias)
+		}
+
+		if storage.Garbage > 0 {
+			return fmt.Sprintf("%s has garbage buckets", storage.Alias)
+		}
+
+		if storage.Rebalancing {
+			return fmt.Sprintf("Rebalancing is in progress on %s", storage.Alias)
+		}
+
+		totalBuckets += storage.Active + storage.Pinned
+	}
+
+	if groupStatus.BucketCount > 0 && totalBuckets != groupStatus.BucketCount {
+		return fmt.Sprintf("Only %d of %d buckets are active", totalBuckets, groupStatus.BucketCount)
+	}
+
+	threshold := defaultRebalancerDisbalanceThreshold
+	if groupStatus.RebalancerDisbalanceThreshold != nil {
+		threshold = *groupStatus.RebalancerDisbalanceThreshold
+	}
+
+	idealBuckets := groupStatus.getIdealBuckets(totalBuckets)
+
+	for _, storage := range groupStatus.Storages {
+		ideal := idealBuckets[storage.ReplicasetUUID]
+		actual := float64(storage.Active + storage.Pinned)
+
+		var disbalance float64
+		if ideal == 0 {
+			if actual == 0 {
+				continue
+			}
+			disbalance = math.Inf(1)
+		} else {
+			disbalance = math.Abs(ideal-actual) / ideal * 100
+		}
+
+		if disbalance > threshold {
+			return fmt.Sprintf(
+				"%s has %d buckets, expected about %d",
+				storage.Alias, int(actual), int(math.Round(ideal)),
+			)
+		}
+	}
+
+	return ""
+}
+
+// getIdealBuckets returns the number of buckets that each storage should have
+// according to its weight.
+// As vshard does, storages that have more pinned buckets than their ideal
+// number are excluded from balancing and keep only pinned buckets
+func (groupStatus *GroupStatus) getIdealBuckets(totalBuckets int) map[string]float64 {
+	idealBuckets := make(map[string]float64)
+	locked := make(map[string]bool)
+
+	for {
+		bucketsToBalance := float64(totalBuckets)
+		totalWeight := 0.0
+
+		for _, storage := range groupStatus.Storages {
+			if locked[storage.ReplicasetUUID] {
+				bucketsToBalance -= float64(storage.Pinned)
+			} else {
+				totalWeight += storage.Weight
+			}
+		}
+
+		lockedNew := false
+		for _, storage := range groupStatus.Storages {
+			if locked[storage.ReplicasetUUID] {
+				idealBuckets[storage.ReplicasetUUID] = float64(storage.Pinned)
+				continue
+			}
+
+			ideal := 0.0
+			if totalWeight > 0 {
+				ideal = bucketsToBalance * storage.Weight / totalWeight
+			}
+
+			if float64(storage.Pinned) > ideal {
+				locked[storage.ReplicasetUUID] = true
+				lockedNew = true
+			}
+
+			idealBuckets[storage.ReplicasetUUID] = ideal
+		}
+
+		if !lockedNew {
+			return idealBuckets
+		}
+	}
+}
+
+// getNotBalancedReason returns a reason why some group buckets aren't balanced.
+// Empty string is returned if all groups are balanced
+func getNotBalancedReason(groupsStatus []*GroupStatus) string {
+	for _, groupStatus := range groupsStatus {
+		if reason := groupStatus.getNotBalancedReason(); reason != "" {
+			if len(groupsStatus) > 1 {
+				return fmt.Sprintf("%s: %s", groupStatus.Name, reason)
+			}
+			return reason
+		}
+	}
+
+	return ""
+}
+
+func getVshardStatusSummary(groupsStatus []*GroupStatus) string {
+	groupsSummary := make([]string, len(groupsStatus))
+	for i, groupStatus := range groupsStatus {
+		groupsSummary[i] = getGroupStatusSummary(groupStatus)
+	}
+
+	return strings.Join(groupsSummary, "\n")
+}
+
+func getGroupStatusSummary(groupStatus *GroupStatus) string {
+	// example group summary:
+	//
+	// • default                         3000 buckets | balanced
+	//   Storages:
+	//     s-1   active: 1500 | pinned: 0 | sending: 0 | receiving: 0 | garbage: 0 | weight: 1 | rebalancer
+	//     s-2   active: 1500 | pinned: 0 | sending: 0 | receiving: 0 | garbage: 0 | weight: 1
+	//   Routers:
+	//     router   green | available rw: 3000 | available ro: 0 | unreachable: 0 | unknown: 0
+
+	var balanceState string
+	if reason := groupStatus.getNotBalancedReason(); reason == "" {
+		balanceState = common.ColorGreen.Sprint("balanced")
+	} else {
+		balanceState = common.ColorWarn.Sprintf("not balanced: %s", reason)
+	}
+
+	summary := []string{
+		fmt.Sprintf(
+			"%-30s    %s",
+			fmt.Sprintf("• %s", common.ColorHiMagenta.Sprint(groupStatus.Name)),
+			common.ColorHiBlue.Sprintf("%d buckets", groupStatus.BucketCount)+" | "+balanceState,
+		),
+	}
+
+	// storages
+	if len(groupStatus.Storages) == 0 {
+		summary = append(summary, "  No storages")
+	} else {
+		summary = append(summary, "  Storages:")
+	}
+
+	for _, storage := range groupStatus.Storages {
+		title := fmt.Sprintf("    %s", common.ColorHiCyan.Sprint(storage.Alias))
+
+		if storage.Error != "" {
+			summary = append(summary, fmt.Sprintf("%s   %s", title, common.ColorErr.Sprint(storage.Error)))
+			continue
+		}
+
+		info := []string{
+			fmt.Sprintf("active: %d", storage.Active),
+			fmt.Sprintf("pinned: %d", storage.Pinned),
+			fmt.Sprintf("sending: %d", storage.Sending),
+			fmt.Sprintf("receiving: %d", storage.Receiving),
+			fmt.Sprintf("garbage: %d", storage.Garbage),
+			fmt.Sprintf("weight: %s", strconv.FormatFloat(storage.Weight, 'f', -1, 64)),
+		}
+
+		if storage.Rebalancer {
+			info = append(info, "rebalancer")
+		}
+
+		if storage.Rebalancing {
+			info = append(info, common.ColorWarn.Sprint("rebalancing"))
+		}
+
+		summary = append(summary, fmt.Sprintf("%s   %s", title, strings.Join(info, " | ")))
+	}
+
+	// routers
+	if len(groupStatus.Routers) == 0 {
+		summary = append(summary, "  No routers")
+	} else {
+		summary = append(summary, "  Routers:")
+	}
+
+	for _, router := range groupStatus.Routers {
+		title := fmt.Sprintf("    %s", common.ColorHiCyan.Sprint(router.Alias))
+
+		if router.Error != "" {
+			summary = append(summary, fmt.Sprintf("%s   %s", title, common.ColorErr.Sprint(router.Error)))
+			continue
+		}
+
+		var status string
+		switch router.Status {
+		case "green":
+			status = common.ColorGreen.Sprint(router.Status)
+		case "yellow":
+			status = common.ColorYellow.Sprint(router.Status)
+		default:
+			status = common.ColorRed.Sprint(router.Status)
+		}
+
+		info := []string{
+			status,
+			fmt.Sprintf("available rw: %d", router.AvailableRW),
+			fmt.Sprintf("available ro: %d", router.AvailableRO),
+			fmt.Sprintf("unreachable: %d", router.Unreachable),
+			fmt.Sprintf("unknown: %d", router.Unknown),
+		}
+
+		summary = append(summary, fmt.Sprintf("%s   %s", title, strings.Join(info, " | ")))
+
+		for _, alert := range router.Alerts {
+			summary = append(summary, fmt.Sprintf("      %s", common.ColorWarn.Sprintf("! %s", alert)))
+		}
+	}
+
+	return strings.Join(summary, "\n")
+}
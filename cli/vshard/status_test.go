@@ -0,0 +1,193 @@
+package vshard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestGetNotBalancedReason(t *testing.T) {
+	assert := assert.New(t)
+
+	var groupStatus *GroupStatus
+
+	// balanced
+	groupStatus = &GroupStatus{
+		Name:        "default",
+		BucketCount: 3000,
+		Storages: []*StorageStatus{
+			{ReplicasetUUID: "rpl-1", Alias: "s-1", Weight: 1, Active: 1500},
+			{ReplicasetUUID: "rpl-2", Alias: "s-2", Weight: 1, Active: 1500},
+		},
+	}
+	assert.Equal("", groupStatus.getNotBalancedReason())
+
+	// small disbalance is allowed by threshold
+	groupStatus.Storages[0].Active = 1510
+	groupStatus.Storages[1].Active = 1490
+	assert.Equal("", groupStatus.getNotBalancedReason())
+
+	// disbalance is greater than threshold
+	threshold := 0.5
+	groupStatus.RebalancerDisbalanceThreshold = &threshold
+	assert.Equal("s-1 has 1510 buckets, expected about 1500", groupStatus.getNotBalancedReason())
+
+	// new replicaset is added
+	groupStatus = &GroupStatus{
+		Name:        "default",
+		BucketCount: 3000,
+		Storages: []*StorageStatus{
+			{ReplicasetUUID: "rpl-1", Alias: "s-1", Weight: 1, Active: 1500},
+			{ReplicasetUUID: "rpl-2", Alias: "s-2", Weight: 1, Active: 1500},
+			{ReplicasetUUID: "rpl-3", Alias: "s-3", Weight: 1, Active: 0},
+		},
+	}
+	assert.Equal("s-1 has 1500 buckets, expected about 1000", groupStatus.getNotBalancedReason())
+
+	// buckets are in transfer
+	groupStatus.Storages[0].Active = 1400
+	groupStatus.Storages[0].Sending = 100
+	assert.Equal("s-1 has buckets in transfer", groupStatus.getNotBalancedReason())
+
+	groupStatus.Storages[0].Sending = 0
+	groupStatus.Storages[0].Garbage = 100
+	assert.Equal("s-1 has garbage buckets", groupStatus.getNotBalancedReason())
+
+	groupStatus.Storages[0].Garbage = 0
+	assert.Equal("Only 2900 of 3000 buckets are active", groupStatus.getNotBalancedReason())
+
+	groupStatus.Storages[0].Active = 1000
+	groupStatus.Storages[1].Active = 1000
+	groupStatus.Storages[2].Active = 1000
+	groupStatus.Storages[2].Rebalancing = true
+	assert.Equal("Rebalancing is in progress on s-3", groupStatus.getNotBalancedReason())
+
+	groupStatus.Storages[2].Rebalancing = false
+	assert.Equal("", groupStatus.getNotBalancedReason())
+
+	// zero weight
+	groupStatus.Storages[2].Weight = 0
+	assert.Equal("s-1 has 1000 buckets, expected about 1500", groupStatus.getNotBalancedReason())
+
+	groupStatus.Storages[0].Active = 1500
+	groupStatus.Storages[1].Active = 1500
+	groupStatus.Storages[2].Active = 0
+	assert.Equal("", groupStatus.getNotBalancedReason())
+
+	// failed to get status
+	groupStatus.Storages[1].Error = "Connection refused"
+	assert.Equal("Failed to get s-2 status: Connection refused", groupStatus.getNotBalancedReason())
+
+	// several groups
+	groupsStatus := []*GroupStatus{
+		{
+			Name:        "cold",
+			BucketCount: 100,
+			Storages: []*StorageStatus{
+				{ReplicasetUUID: "rpl-1", Alias: "s-1", Weight: 1, Active: 100},
+			},
+		},
+		{
+			Name:        "hot",
+			BucketCount: 100,
+			Storages: []*StorageStatus{
+				{ReplicasetUUID: "rpl-2", Alias: "s-2", Weight: 1, Active: 100},
+				{ReplicasetUUID: "rpl-3", Alias: "s-3", Weight: 1, Active: 0},
+			},
+		},
+	}
+	assert.Equal("hot: s-2 has 100 buckets, expected about 50", getNotBalancedReason(groupsStatus))
+
+	groupsStatus[1].Storages[0].Active = 50
+	groupsStatus[1].Storages[1].Active = 50
+	assert.Equal("", getNotBalancedReason(groupsStatus))
+}
+
+func TestGetIdealBuckets(t *testing.T) {
+	assert := assert.New(t)
+
+	groupStatus := &GroupStatus{
+		Storages: []*StorageStatus{
+			{ReplicasetUUID: "rpl-1", Weight: 1},
+			{ReplicasetUUID: "rpl-2", Weight: 2},
+			{ReplicasetUUID: "rpl-3", Weight: 1},
+		},
+	}
+
+	assert.Equal(map[string]float64{
+		"rpl-1": 750,
+		"rpl-2": 1500,
+		"rpl-3": 750,
+	}, groupStatus.getIdealBuckets(3000))
+
+	// replicaset with too many pinned buckets is excluded from balancing
+	groupStatus.Storages[0].Pinned = 1000
+	assert.Equal(map[string]float64{
+		"rpl-1": 1000,
+		"rpl-2": 4000.0 / 3,
+		"rpl-3": 2000.0 / 3,
+	}, groupStatus.getIdealBuckets(3000))
+}
+
+func TestDecodeGroupStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	encoded, err := msgpack.Marshal([]interface{}{
+		map[string]interface{}{
+			"name":                            "default",
+			"bucket_count":                    3000,
+			"rebalancer_disbalance_threshold": 1,
+			"storages": []interface{}{
+				map[string]interface{}{
+					"replicaset_uuid": "rpl-1",
+					"alias":           "s-1",
+					"weight":          1.5,
+					"master_uri":      "localhost:3302",
+					"active":          1500,
+					"pinned":          int8(2),
+					"rebalancer":      true,
+				},
+			},
+			"routers": []interface{}{
+				map[string]interface{}{
+					"alias":        "router",
+					"uri":          "localhost:3301",
+					"status":       "green",
+					"available_rw": uint16(3000),
+					"alerts":       []interface{}{"UNREACHABLE_MASTER: Master is unreachable"},
+				},
+			},
+		},
+	})
+	assert.Nil(err)
+
+	var groupsStatus []*GroupStatus
+	assert.Nil(msgpack.Unmarshal(encoded, &groupsStatus))
+
+	assert.Len(groupsStatus, 1)
+	groupStatus := groupsStatus[0]
+	assert.Equal("default", groupStatus.Name)
+	assert.Equal(3000, groupStatus.BucketCount)
+	assert.Equal(1.0, *groupStatus.RebalancerDisbalanceThreshold)
+
+	assert.Len(groupStatus.Storages, 1)
+	assert.Equal(&StorageStatus{
+		ReplicasetUUID: "rpl-1",
+		Alias:          "s-1",
+		Weight:         1.5,
+		MasterURI:      "localhost:3302",
+		Active:         1500,
+		Pinned:         2,
+		Rebalancer:     true,
+	}, groupStatus.Storages[0])
+
+	assert.Len(groupStatus.Routers, 1)
+	assert.Equal(&RouterStatus{
+		Alias:       "router",
+		URI:         "localhost:3301",
+		Status:      "green",
+		AvailableRW: 3000,
+		Alerts:      []string{"UNREACHABLE_MASTER: Master is unreachable"},
+	}, groupStatus.Routers[0])
+}
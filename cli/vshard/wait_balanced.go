@@ -0,0 +1,64 @@
+package vshard
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apex/log"
+
+	"github.com/tarantool/cartridge-cli/cli/cluster"
+	"github.com/tarantool/cartridge-cli/cli/context"
+	"github.com/tarantool/cartridge-cli/cli/project"
+)
+
+const (
+	waitBalancedCheckInterval = 1 * time.Second
+)
+
+func WaitBalanced(ctx *context.Ctx) error {
+	if err := project.FillCtx(ctx); err != nil {
+		return err
+	}
+
+	conn, err := cluster.ConnectToSomeJoinedInstance(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Wait until vshard buckets are balanced")
+
+	deadline := time.Now().Add(ctx.Vshard.WaitTimeout)
+	lastReason := ""
+
+	for {
+		groupsStatus, err := GetStatus(conn)
+		if err != nil {
+			return err
+		}
+
+		if len(groupsStatus) == 0 {
+			return fmt.Errorf(
+				"No vshard groups available. " +
+					"It's possible that your application hasn't vshard-router role registered",
+			)
+		}
+
+		reason := getNotBalancedReason(groupsStatus)
+		if reason == "" {
+			log.Infof("Vshard buckets are balanced")
+			return nil
+		}
+
+		if reason != lastReason {
+			log.Debugf("%s", reason)
+			lastReason = reason
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Timeout %s was reached while waiting for buckets to be balanced: %s",
+				ctx.Vshard.WaitTimeout, reason)
+		}
+
+		time.Sleep(waitBalancedCheckInterval)
+	}
+}
@@ -238,7 +238,7 @@ func Build() error {
 	return nil
 }
 
-// Generate completion scripts for bash and zsh
+// Generate completion scripts for bash, zsh, fish and PowerShell
 func GenCompletion() error {
 	if err := Build(); err != nil {
 		return err
@@ -195,6 +195,37 @@ local func_print = {
     end,
 }
 
+local func_structured = {
+    usage = 'func_structured usage',
+    args = {
+        user = {
+            type = 'table',
+            usage = 'User info',
+        },
+        tags = {
+            type = 'array',
+            usage = 'User tags',
+        },
+        verbose = {
+            type = 'boolean',
+            usage = 'Push a message',
+        },
+    },
+    call = function(opts)
+        opts = opts or {}
+
+        if opts.verbose then
+            box.session.push('Processing user')
+        end
+
+        return {
+            name = opts.user and opts.user.name,
+            tags_count = opts.tags and #opts.tags or 0,
+            tags = opts.tags,
+        }
+    end,
+}
+
 assert(cli_admin.register('echo_user', echo_user.usage, echo_user.args, echo_user.call))
 assert(cli_admin.register('func.long.name', func_long_name.usage, func_long_name.args, func_long_name.call))
 assert(cli_admin.register('func_no_args', func_no_args.usage, func_no_args.args, func_no_args.call))
@@ -205,3 +236,4 @@ assert(cli_admin.register('func_conflicting', func_conflicting.usage, func_confl
 assert(cli_admin.register('func_rets_err', func_rets_err.usage, func_rets_err.args, func_rets_err.call))
 assert(cli_admin.register('func_raises_err', func_raises_err.usage, func_raises_err.args, func_raises_err.call))
 assert(cli_admin.register('func_print', func_print.usage, func_print.args, func_print.call))
+assert(cli_admin.register('func_structured', func_structured.usage, func_structured.args, func_structured.call))
@@ -1,3 +1,6 @@
+import json
+import subprocess
+
 import pytest
 from utils import (get_admin_connection_params, get_log_lines,
                    run_command_and_get_output)
@@ -188,3 +191,93 @@ def test_print(cartridge_cmd, custom_admin_running_instances, connection_type, t
     assert get_log_lines(output) == iterations_output + [
         'I am some great result',
     ]
+
+
+@pytest.mark.parametrize('connection_type', ['find-socket', 'connect'])
+def test_call_args_json(cartridge_cmd, custom_admin_running_instances, connection_type, tmpdir):
+    project = custom_admin_running_instances['project']
+
+    base_cmd = [cartridge_cmd, 'admin', 'echo_user']
+    base_cmd.extend(get_admin_connection_params(connection_type, project))
+
+    # args from JSON
+    cmd = base_cmd + ['--args-json', json.dumps({'username': 'Elizabeth', 'age': 24, 'loves-cakes': True})]
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 0, output
+
+    assert get_log_lines(output) == [
+        'Hi, Elizabeth!',
+        'You are 24 years old',
+        'I know that you like cakes!',
+    ]
+
+    # args from file, flags have priority
+    args_file = tmpdir.join('args.json')
+    args_file.write(json.dumps({'username': 'Elizabeth', 'loves_cakes': True}))
+
+    cmd = base_cmd + ['--args-file', str(args_file), '--username', 'Jane']
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 0, output
+
+    assert get_log_lines(output) == [
+        'Hi, Jane!',
+        "I don't know your age",
+        'I know that you like cakes!',
+    ]
+
+    # invalid args
+    cmd = base_cmd + ['--args-json', json.dumps({'username': 'Elizabeth', 'age': '24'})]
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 1
+    assert 'Failed to parse function call args: Argument "age" should be a number' in output
+
+    cmd = base_cmd + ['--args-json', json.dumps({'name': 'Elizabeth'})]
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 1
+    assert 'Unknown argument "name"' in output
+
+
+@pytest.mark.parametrize('connection_type', ['find-socket', 'connect'])
+def test_call_structured(cartridge_cmd, custom_admin_running_instances, connection_type, tmpdir):
+    project = custom_admin_running_instances['project']
+
+    base_cmd = [cartridge_cmd, 'admin', 'func_structured', '--output', 'json']
+    base_cmd.extend(get_admin_connection_params(connection_type, project))
+
+    # table and array args via flags
+    cmd = base_cmd + ['--user', json.dumps({'name': 'Elizabeth'}), '--tags', json.dumps(['admin', 'dev'])]
+    process = subprocess.run(cmd, cwd=tmpdir, stdout=subprocess.PIPE, stderr=subprocess.PIPE)
+    assert process.returncode == 0, process.stderr.decode()
+
+    assert json.loads(process.stdout.decode()) == {
+        'name': 'Elizabeth',
+        'tags_count': 2,
+        'tags': ['admin', 'dev'],
+    }
+
+    # table and array args via JSON, pushed messages aren't mixed with the result
+    cmd = base_cmd + [
+        '--args-json', json.dumps({'user': {'name': 'Jane'}, 'tags': ['dev'], 'verbose': True}),
+    ]
+    process = subprocess.run(cmd, cwd=tmpdir, stdout=subprocess.PIPE, stderr=subprocess.PIPE)
+    assert process.returncode == 0, process.stderr.decode()
+
+    assert json.loads(process.stdout.decode()) == {
+        'name': 'Jane',
+        'tags_count': 1,
+        'tags': ['dev'],
+    }
+    assert 'Processing user' in process.stderr.decode()
+
+    # array arg should be an array
+    cmd = base_cmd + ['--tags', json.dumps({'tag': 'dev'})]
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 1
+    assert 'Argument "tags" should be an array (JSON array)' in output
+
+    # invalid output format
+    cmd = [cartridge_cmd, 'admin', 'func_structured', '--output', 'yaml']
+    cmd.extend(get_admin_connection_params(connection_type, project))
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 1
+    assert 'Unknown output format "yaml". Supported formats: text, json' in output
@@ -0,0 +1,57 @@
+from utils import get_completions
+
+
+def test_complete_func_names(cartridge_cmd, custom_admin_running_instances, tmpdir):
+    project = custom_admin_running_instances['project']
+
+    args = ['admin', '--name', project.name, '--run-dir', project.get_run_dir(), '']
+
+    # the second call uses cached functions list
+    for _ in range(2):
+        completions = get_completions(cartridge_cmd, args, tmpdir)
+
+        assert 'echo_user\techo_user usage' in completions
+        assert 'func.long.name\tfunc_long_name usage' in completions
+        assert 'func_structured\tfunc_structured usage' in completions
+
+
+def test_complete_func_names_in_app_dir(cartridge_cmd, custom_admin_running_instances):
+    project = custom_admin_running_instances['project']
+
+    # application name and run directory are detected
+    completions = get_completions(cartridge_cmd, ['admin', ''], project.path)
+    assert 'echo_user\techo_user usage' in completions
+
+    # function is already specified
+    completions = get_completions(cartridge_cmd, ['admin', 'echo_user', ''], project.path)
+    assert completions == []
+
+
+def test_complete_func_args(cartridge_cmd, custom_admin_running_instances, tmpdir):
+    project = custom_admin_running_instances['project']
+
+    args = ['admin', '--name', project.name, '--run-dir', project.get_run_dir(), 'echo_user', '--']
+    completions = get_completions(cartridge_cmd, args, tmpdir)
+
+    assert '--username\tusername usage' in completions
+    assert '--age\tage usage' in completions
+    assert '--loves-cakes\tloves_cakes usage' in completions
+
+    # `cartridge admin` flags are completed too
+    assert any(completion.startswith('--run-dir') for completion in completions)
+
+    # arguments of another function aren't completed
+    args = ['admin', '--name', project.name, '--run-dir', project.get_run_dir(), 'func_long_arg', '--']
+    completions = get_completions(cartridge_cmd, args, tmpdir)
+
+    assert '--long-arg\tlong_arg usage' in completions
+    assert '--username\tusername usage' not in completions
+
+
+def test_complete_no_instances(cartridge_cmd, custom_admin_project, tmpdir):
+    project = custom_admin_project
+
+    args = ['admin', '--name', project.name, '--run-dir', str(tmpdir), '']
+    completions = get_completions(cartridge_cmd, args, tmpdir)
+
+    assert completions == []
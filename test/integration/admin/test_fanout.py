@@ -0,0 +1,119 @@
+import json
+import subprocess
+
+from utils import run_command_and_get_output
+
+INSTANCE1 = 'instance-1'
+INSTANCE2 = 'instance-2'
+
+
+def get_fanout_cmd(cartridge_cmd, project, func_name):
+    return [
+        cartridge_cmd, 'admin', func_name,
+        '--name', project.name,
+        '--run-dir', project.get_run_dir(),
+    ]
+
+
+def test_all(cartridge_cmd, custom_admin_running_instances, tmpdir):
+    project = custom_admin_running_instances['project']
+
+    cmd = get_fanout_cmd(cartridge_cmd, project, 'func_print') + ['--all', '--num', '2']
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 0, output
+
+    for instance_name in [INSTANCE1, INSTANCE2]:
+        assert '%s: OK' % instance_name in output
+
+    assert output.count('Iteration 1 (pushed)') == 2
+    assert output.count('Iteration 2 (pushed)') == 2
+    assert output.count('I am some great result') == 2
+
+    # instances are reported in order
+    assert output.index('%s: OK' % INSTANCE1) < output.index('%s: OK' % INSTANCE2)
+
+    # --parallel
+    cmd = get_fanout_cmd(cartridge_cmd, project, 'func_rets_str') + ['--all', '--parallel', '1']
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 0, output
+    assert output.count('func_rets_str was called') == 2
+
+    cmd = get_fanout_cmd(cartridge_cmd, project, 'func_rets_str') + ['--all', '--parallel', '0']
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 1
+    assert '--parallel should be greater than zero' in output
+
+
+def test_all_json(cartridge_cmd, custom_admin_running_instances, tmpdir):
+    project = custom_admin_running_instances['project']
+
+    cmd = get_fanout_cmd(cartridge_cmd, project, 'func_structured') + [
+        '--all', '--output', 'json',
+        '--args-json', json.dumps({'user': {'name': 'Elizabeth'}, 'tags': ['dev'], 'verbose': True}),
+    ]
+    process = subprocess.run(cmd, cwd=tmpdir, stdout=subprocess.PIPE, stderr=subprocess.PIPE)
+    assert process.returncode == 0, process.stderr.decode()
+
+    exp_result = {
+        'messages': ['Processing user'],
+        'result': {
+            'name': 'Elizabeth',
+            'tags_count': 1,
+            'tags': ['dev'],
+        },
+    }
+
+    assert json.loads(process.stdout.decode()) == {
+        INSTANCE1: exp_result,
+        INSTANCE2: exp_result,
+    }
+
+
+def test_all_errors(cartridge_cmd, custom_admin_running_instances, tmpdir):
+    project = custom_admin_running_instances['project']
+
+    cmd = get_fanout_cmd(cartridge_cmd, project, 'func_rets_err') + ['--all']
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 1
+
+    for instance_name in [INSTANCE1, INSTANCE2]:
+        assert '%s: Failed to call "func_rets_err": Some horrible error' % instance_name in output
+
+    assert 'Failed to call "func_rets_err" on 2 instance(s): %s, %s' % (INSTANCE1, INSTANCE2) in output
+
+    cmd = get_fanout_cmd(cartridge_cmd, project, 'func_rets_err') + ['--all', '--output', 'json']
+    process = subprocess.run(cmd, cwd=tmpdir, stdout=subprocess.PIPE, stderr=subprocess.PIPE)
+    assert process.returncode == 1
+
+    results = json.loads(process.stdout.decode())
+    assert set(results.keys()) == {INSTANCE1, INSTANCE2}
+    for instance_name in [INSTANCE1, INSTANCE2]:
+        assert results[instance_name]['error'] == 'Failed to call "func_rets_err": Some horrible error'
+
+
+def test_all_stopped_instance(cartridge_cmd, start_stop_cli, custom_admin_running_instances, tmpdir):
+    project = custom_admin_running_instances['project']
+
+    start_stop_cli.stop(project, [INSTANCE2])
+
+    cmd = get_fanout_cmd(cartridge_cmd, project, 'func_rets_str') + ['--all']
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 0, output
+
+    assert '%s: OK' % INSTANCE1 in output
+    assert INSTANCE2 not in output
+
+
+def test_fanout_invalid_flags(cartridge_cmd, custom_admin_running_instances, tmpdir):
+    project = custom_admin_running_instances['project']
+
+    cmd = get_fanout_cmd(cartridge_cmd, project, 'func_rets_str') + ['--all', '--instance', INSTANCE1]
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 1
+    assert "--all, --replicaset and --role can't be used with --instance or --conn" in output
+
+    cmd = [cartridge_cmd, 'admin', 'func_rets_str', '--all', '--conn', 'localhost:3301']
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 1
+    assert 'Please, specify one of --name, --instance or --conn' not in output
+    assert "--all, --replicaset and --role can't be used with --instance or --conn" in output
@@ -28,4 +28,5 @@ def test_list(cartridge_cmd, custom_admin_running_instances, connection_type, tm
         'func_rets_err      func_rets_err usage',
         'func_rets_non_str  func_rets_non_str usage',
         'func_rets_str      func_rets_str usage',
+        'func_structured    func_structured usage',
     ]
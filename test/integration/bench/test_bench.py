@@ -5,6 +5,7 @@ from subprocess import PIPE, STDOUT, Popen
 from threading import Thread
 
 import tenacity
+import yaml
 from utils import consume_lines, run_command_and_get_output
 
 
@@ -13,12 +14,13 @@ def wait_for_connect():
     socket.create_connection(('127.0.0.1', 3301))
 
 
-def test_bench(cartridge_cmd, request, tmpdir):
-    base_cmd = [cartridge_cmd, 'bench', '--duration=1']
+def start_tarantool(request, tmpdir):
     tarantool_cmd = [
         "tarantool",
         "-e", f"""box.cfg{{listen="127.0.0.1:3301",work_dir=[[{tmpdir}]]}}""",
-        "-e", """box.schema.user.grant("guest","super",nil,nil,{if_not_exists=true})"""
+        "-e", """box.schema.user.grant("guest","super",nil,nil,{if_not_exists=true})""",
+        "-e", """function get_user(id) return box.space.users:get(id) end""",
+        "-e", """box.schema.space.create("existing", {if_not_exists=true})""",
     ]
 
     env = os.environ.copy()
@@ -34,6 +36,11 @@ def test_bench(cartridge_cmd, request, tmpdir):
 
     wait_for_connect()
 
+
+def test_bench(cartridge_cmd, request, tmpdir):
+    base_cmd = [cartridge_cmd, 'bench', '--duration=1']
+    start_tarantool(request, tmpdir)
+
     rc, output = run_command_and_get_output(base_cmd, cwd=tmpdir)
     assert rc == 0
 
@@ -44,3 +51,66 @@ def test_bench(cartridge_cmd, request, tmpdir):
     base_cmd = [cartridge_cmd, 'bench', '--duration=1', '--insert=0', '--select=50', '--update=50']
     rc, output = run_command_and_get_output(base_cmd, cwd=tmpdir)
     assert rc == 0
+
+
+def test_bench_workload(cartridge_cmd, request, tmpdir):
+    start_tarantool(request, tmpdir)
+
+    workload = {
+        'spaces': [{
+            'name': 'users',
+            'format': [
+                {'name': 'id', 'type': 'unsigned', 'gen': {'type': 'sequence', 'start': 1}},
+                {'name': 'uuid', 'type': 'string', 'gen': {'type': 'uuid'}},
+                {'name': 'name', 'type': 'string', 'gen': {'type': 'string', 'size': 16}},
+                {'name': 'age', 'type': 'unsigned', 'gen': {'type': 'int', 'min': 18, 'max': 99}},
+            ],
+            'indexes': [
+                {'name': 'primary', 'parts': ['id']},
+                {'name': 'age', 'parts': ['age'], 'unique': False},
+            ],
+            'fill': 1000,
+        }],
+        'operations': [
+            {'type': 'insert', 'space': 'users', 'weight': 20},
+            {'type': 'select', 'space': 'users', 'index': 'age', 'weight': 30},
+            {'type': 'update', 'space': 'users', 'fields': ['age'], 'weight': 10},
+            {'type': 'replace', 'space': 'users', 'weight': 10},
+            {'type': 'upsert', 'space': 'users', 'weight': 10},
+            {'type': 'delete', 'space': 'users', 'weight': 10},
+            {'type': 'call', 'function': 'get_user', 'args': [{'type': 'int', 'min': 1, 'max': 1000}], 'weight': 10},
+        ],
+    }
+
+    workload_path = os.path.join(tmpdir, 'bench.yml')
+    with open(workload_path, 'w') as f:
+        yaml.dump(workload, f)
+
+    cmd = [cartridge_cmd, 'bench', '--duration=1', '--workload', workload_path]
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 0, output
+
+    assert 'Data schema of users' in output
+    assert 'sequence(1)' in output
+    assert 'call get_user: 10.00 percentages' in output
+    assert 'Pre-filling is finished. Number of records: 1000' in output
+    assert 'Failed  operations: 0' in output
+
+    # invalid workload
+    workload['operations'].append({'type': 'truncate', 'space': 'users', 'weight': 1})
+    with open(workload_path, 'w') as f:
+        yaml.dump(workload, f)
+
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 1
+    assert 'Invalid operation #8 (truncate): Unknown operation type' in output
+
+    # existing space isn't dropped without drop_existing
+    workload['spaces'][0]['name'] = 'existing'
+    workload['operations'] = [{'type': 'insert', 'space': 'existing', 'weight': 1}]
+    with open(workload_path, 'w') as f:
+        yaml.dump(workload, f)
+
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 1
+    assert 'Space existing already exists. Specify drop_existing: true' in output
@@ -1,8 +1,14 @@
+import os
+
+import pytest
+import yaml
 from integration.connect.utils import (assert_error,
                                        assert_exited_piped_commands,
                                        assert_session_push_commands,
+                                       assert_sql_piped_commands,
                                        assert_successful_piped_commands)
-from utils import DEFAULT_CLUSTER_COOKIE
+from utils import (DEFAULT_CLUSTER_COOKIE, run_command_and_get_output,
+                   tarantool_short_version)
 
 
 def test_bad_uri(cartridge_cmd, project_with_instances):
@@ -39,8 +45,7 @@ def test_uri_piped(cartridge_cmd, project_with_instances):
         '--password', DEFAULT_CLUSTER_COOKIE,
     ]
 
-    assert_successful_piped_commands(project, cmd, exp_connect='%s.%s' % (project.name, router.name),
-                                     remote_control=True)
+    assert_successful_piped_commands(project, cmd, exp_connect='%s.%s' % (project.name, router.name))
 
 
 def test_socket_piped(cartridge_cmd, project_with_instances):
@@ -54,8 +59,7 @@ def test_socket_piped(cartridge_cmd, project_with_instances):
         cartridge_cmd, 'connect', console_sock_path,
     ]
 
-    assert_successful_piped_commands(project, cmd, exp_connect='%s.%s' % (project.name, router.name),
-                                     remote_control=False)
+    assert_successful_piped_commands(project, cmd, exp_connect='%s.%s' % (project.name, router.name))
 
 
 def test_socket_no_title(cartridge_cmd, project_with_instances_no_cartridge):
@@ -69,7 +73,7 @@ def test_socket_no_title(cartridge_cmd, project_with_instances_no_cartridge):
         cartridge_cmd, 'connect', console_sock_path,
     ]
 
-    assert_successful_piped_commands(project, cmd, exp_connect=console_sock_path, remote_control=False)
+    assert_successful_piped_commands(project, cmd, exp_connect=console_sock_path)
 
 
 def test_uri_instance_exited(cartridge_cmd, project_with_instances):
@@ -113,3 +117,99 @@ def test_socket_session_push(cartridge_cmd, project_with_instances):
     ]
 
     assert_session_push_commands(project, cmd, exp_connect='%s.%s' % (project.name, router.name))
+
+
+@pytest.mark.skipif(tarantool_short_version().startswith('1.10'), reason="SQL isn't supported by Tarantool 1.10")
+def test_socket_sql(cartridge_cmd, project_with_instances_no_cartridge):
+    project = project_with_instances_no_cartridge.project
+    instances = project_with_instances_no_cartridge.instances
+
+    router = instances['router']
+    console_sock_path = project.get_console_sock(router.name)
+
+    cmd = [
+        cartridge_cmd, 'connect', console_sock_path,
+    ]
+
+    assert_sql_piped_commands(project, cmd, exp_connect=console_sock_path)
+
+
+def test_bad_language(cartridge_cmd, project_with_instances):
+    project = project_with_instances.project
+    instances = project_with_instances.instances
+
+    router = instances['router']
+    console_sock_path = project.get_console_sock(router.name)
+
+    cmd = [
+        cartridge_cmd, 'connect', console_sock_path,
+        '--language', 'unknown',
+    ]
+
+    assert_error(project, cmd, 'Unknown language "unknown". Supported languages: lua, sql')
+
+
+def write_profiles_file(config_dir, content):
+    profiles_dir = os.path.join(config_dir, 'cartridge')
+    os.makedirs(profiles_dir, exist_ok=True)
+
+    with open(os.path.join(profiles_dir, 'profiles.yml'), 'w') as f:
+        yaml.dump(content, f)
+
+
+def test_profile(cartridge_cmd, project_with_instances, tmpdir):
+    project = project_with_instances.project
+    instances = project_with_instances.instances
+
+    router = instances['router']
+
+    config_dir = os.path.join(tmpdir, 'config')
+    write_profiles_file(config_dir, {
+        'local': {
+            'hosts': [
+                {'name': 'router', 'uri': router.advertise_uri},
+            ],
+            'user': 'admin',
+            'password_env': 'TEST_CLUSTER_PASSWORD',
+        },
+    })
+
+    env = os.environ.copy()
+    env['XDG_CONFIG_HOME'] = config_dir
+    env['TEST_CLUSTER_PASSWORD'] = DEFAULT_CLUSTER_COOKIE
+
+    # default instance
+    cmd = [
+        cartridge_cmd, 'connect', '--profile', 'local',
+        '--eval', 'return box.info.ro', '--output', 'lua',
+    ]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path, env=env)
+    assert rc == 0
+    assert output.strip() == 'false;'
+
+    # host name
+    cmd = [
+        cartridge_cmd, 'connect', 'router', '--profile', 'local',
+        '--eval', 'return box.info.ro', '--output', 'lua',
+    ]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path, env=env)
+    assert rc == 0
+    assert output.strip() == 'false;'
+
+    # flags have priority over the profile
+    cmd = [
+        cartridge_cmd, 'connect', '--profile', 'local',
+        '--password', 'wrong-password',
+        '--eval', 'return box.info.ro',
+    ]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path, env=env)
+    assert rc == 1
+    assert 'Incorrect password supplied for user' in output
+
+    # unknown profile
+    cmd = [
+        cartridge_cmd, 'connect', '--profile', 'unknown',
+    ]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path, env=env)
+    assert rc == 1
+    assert 'Profile unknown isn\'t found in %s' % os.path.join(config_dir, 'cartridge', 'profiles.yml') in output
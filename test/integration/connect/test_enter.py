@@ -1,3 +1,7 @@
+import json
+import os
+import subprocess
+
 from integration.connect.utils import (assert_error,
                                        assert_exited_piped_commands,
                                        assert_session_push_commands,
@@ -24,8 +28,7 @@ def test_enter_piped(cartridge_cmd, project_with_instances):
         cartridge_cmd, 'enter', router.name,
     ]
 
-    assert_successful_piped_commands(project, cmd, exp_connect='%s.%s' % (project.name, router.name),
-                                     remote_control=False)
+    assert_successful_piped_commands(project, cmd, exp_connect='%s.%s' % (project.name, router.name))
 
 
 def test_instance_exited(cartridge_cmd, project_with_instances):
@@ -52,3 +55,111 @@ def test_session_push(cartridge_cmd, project_with_instances):
     ]
 
     assert_session_push_commands(project, cmd, exp_connect='%s.%s' % (project.name, router.name))
+
+
+def run_eval(project, cmd):
+    process = subprocess.run(cmd, cwd=project.path, stdout=subprocess.PIPE, stderr=subprocess.PIPE)
+    return process.returncode, process.stdout.decode('utf-8'), process.stderr.decode('utf-8')
+
+
+def test_eval(cartridge_cmd, project_with_instances):
+    project = project_with_instances.project
+    instances = project_with_instances.instances
+
+    router = instances['router']
+
+    cmd = [
+        cartridge_cmd, 'enter', router.name,
+        '-e', 'return 1 + 1, "a"',
+    ]
+
+    rc, stdout, _ = run_eval(project, cmd)
+    assert rc == 0
+    assert stdout == '---\n- 2\n- a\n...\n'
+
+    cmd = [
+        cartridge_cmd, 'enter', router.name,
+        '--eval', 'return {a = 1}',
+        '--output', 'json',
+    ]
+
+    rc, stdout, _ = run_eval(project, cmd)
+    assert rc == 0
+    assert json.loads(stdout) == [{'a': 1}]
+
+
+def test_eval_file(cartridge_cmd, project_with_instances, tmpdir):
+    project = project_with_instances.project
+    instances = project_with_instances.instances
+
+    router = instances['router']
+
+    script_path = os.path.join(tmpdir, 'script.lua')
+    with open(script_path, 'w') as f:
+        f.write('\n'.join([
+            '#!/usr/bin/env tarantool',
+            'local x = 40',
+            'local y = 2',
+            'return x + y',
+        ]))
+
+    cmd = [
+        cartridge_cmd, 'enter', router.name,
+        '-f', script_path,
+        '--output', 'lua',
+    ]
+
+    rc, stdout, _ = run_eval(project, cmd)
+    assert rc == 0
+    assert stdout == '42;\n'
+
+    # error in script
+    with open(script_path, 'w') as f:
+        f.write('\n'.join([
+            'local x = 40',
+            'error("boom")',
+        ]))
+
+    rc, stdout, stderr = run_eval(project, cmd)
+    assert rc == 1
+    assert stdout == '{error = "%s:2: boom"};\n' % script_path
+    assert "Failed to evaluate code on %s: %s:2: boom" % (router.name, script_path) in stderr
+
+
+def test_eval_several_instances(cartridge_cmd, project_with_instances):
+    project = project_with_instances.project
+    instances = project_with_instances.instances
+
+    router = instances['router']
+    s1_master = instances['s1-master']
+
+    cmd = [
+        cartridge_cmd, 'enter', router.name, s1_master.name,
+        '-e', "return box.info.status ~= nil",
+    ]
+
+    rc, stdout, _ = run_eval(project, cmd)
+    assert rc == 0
+    assert stdout == '\n'.join([
+        '%s | ---' % router.name,
+        '%s | - true' % router.name,
+        '%s | ...' % router.name,
+        '%s | ---' % s1_master.name,
+        '%s | - true' % s1_master.name,
+        '%s | ...' % s1_master.name,
+    ]) + '\n'
+
+    # error on one instance
+    cmd = [
+        cartridge_cmd, 'enter', router.name, s1_master.name,
+        '-e', "if os.getenv('TARANTOOL_INSTANCE_NAME') == '%s' then error('bad instance') end" % s1_master.name,
+        '--output', 'lua',
+    ]
+
+    rc, stdout, stderr = run_eval(project, cmd)
+    assert rc == 1
+    assert stdout == '\n'.join([
+        '%s | ;' % router.name,
+        '%s | {error = "eval:1: bad instance"};' % s1_master.name,
+    ]) + '\n'
+    assert "Failed to evaluate code on %s: eval:1: bad instance" % s1_master.name in stderr
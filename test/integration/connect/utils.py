@@ -1,7 +1,5 @@
 import subprocess
 
-from utils import tarantool_short_version
-
 
 class Command:
     def __init__(self, command, yaml_output=None, lua_output=None, exp_output=None):
@@ -14,7 +12,7 @@ class Command:
             self.exp_output = exp_output
 
 
-def get_successful_commands(remote_control):
+def get_successful_commands():
     common_commands = [
         # YAML output
 
@@ -29,20 +27,8 @@ def get_successful_commands(remote_control):
         Command('if+1', yaml_output='error: \'[string "if+1 "]:1: unexpected symbol near \'\'+\'\'\''),
     ]
 
-    set_output_error_commands = [
-        Command(
-            '\\set output lua',
-            yaml_output='error: \'[string "\\set output lua "]:1: unexpected symbol near \'\'\\\'\'\''
-        ),
-        Command('return 666', yaml_output='666'),
-
-        Command(
-            '\\set output yaml',
-            yaml_output='error: \'[string "\\set output yaml "]:1: unexpected symbol near \'\'\\\'\'\''
-        ),
-        Command('return 666', yaml_output='666'),
-    ]
-
+    # output format is set on the client side,
+    # so it doesn't depend on Tarantool version
     set_output_commands = [
         # Lua output
         Command('\\set output lua', lua_output='true'),
@@ -52,34 +38,38 @@ def get_successful_commands(remote_control):
         Command('777', lua_output='777'),
         # multiline statement
         Command('if\ntrue\nthen\nreturn 999\nend', lua_output='999'),
+        # several values
+        Command("return 1, 'a', nil", lua_output='1, "a", nil'),
+
+        # JSON output
+        Command('\\set output json', exp_output='[\n  true\n]\n'),
+        Command("return {a = 1}, 'b'", exp_output='[\n  {\n    "a": 1\n  },\n  "b"\n]\n'),
+
+        # table output
+        Command('\\set output table', exp_output='true\n'),
+        Command(
+            "return {{1, 'Alice'}, {2, 'Bob'}}",
+            exp_output='\n'.join([
+                '+---+-------+',
+                '| 1 | 2     |',
+                '+---+-------+',
+                '| 1 | Alice |',
+                '| 2 | Bob   |',
+                '+---+-------+',
+                '(2 rows)',
+            ]) + '\n',
+        ),
+
+        # unknown output
+        Command('\\set output xml', exp_output='error: Unknown output format "xml". '
+                                                'Supported formats: yaml, lua, json, table\n'),
 
         # YAML output again
         Command('\\set output yaml', yaml_output='true'),
         Command('return 666', yaml_output='666'),
     ]
 
-    # Since cartridge 2.7.0, cartridge process remote control requests
-    # in separate fibers, so setting output is reset after each request.
-    # https://github.com/tarantool/cartridge/commit/a66754967f99593039fabca4f31f9ca8f6340df1
-    set_output_remote_commands = [
-        # valid commands
-        Command('return 666', yaml_output='666'),
-        Command('777', yaml_output='777'),
-        # multiline statement
-        Command('if\ntrue\nthen\nreturn 999\nend', yaml_output='999'),
-    ]
-
-    commands = common_commands
-
-    if tarantool_short_version().startswith('1.10'):
-        commands.extend(set_output_error_commands)
-    else:
-        if remote_control:
-            commands.extend(set_output_remote_commands)
-        else:
-            commands.extend(set_output_commands)
-
-    return commands
+    return common_commands + set_output_commands
 
 
 def run_commands_in_pipe(project, cmd, commands):
@@ -101,8 +91,8 @@ def run_commands_in_pipe(project, cmd, commands):
     return process.returncode, output
 
 
-def assert_successful_piped_commands(project, cmd, exp_connect, remote_control):
-    commands = get_successful_commands(remote_control)
+def assert_successful_piped_commands(project, cmd, exp_connect):
+    commands = get_successful_commands()
 
     rc, output = run_commands_in_pipe(project, cmd, commands)
     assert rc == 0
@@ -146,8 +136,7 @@ def get_push_tag_yaml_output(message):
 
 
 def get_push_tag_lua_output(message):
-    fmt = ''''"%s"'
-
+    fmt = '''"%s";
 true;'''
 
     return fmt % message
@@ -156,15 +145,11 @@ true;'''
 def assert_session_push_commands(project, cmd, exp_connect):
     commands = [
         Command("box.session.push('666')", exp_output=get_push_tag_yaml_output('666')),
+        Command("\\set output lua", lua_output='true'),
+        Command("box.session.push('777')", exp_output=get_push_tag_lua_output('777')),
+        Command("\\set output yaml", yaml_output='true'),
     ]
 
-    if tarantool_short_version().startswith('2'):
-        commands.extend([
-            Command("\\set output lua", lua_output='true'),
-            Command("box.session.push('777')", exp_output=get_push_tag_lua_output('777')),
-            Command("\\set output yaml", yaml_output='true'),
-        ])
-
     rc, output = run_commands_in_pipe(project, cmd, commands)
     assert rc == 0
 
@@ -178,6 +163,62 @@ def assert_session_push_commands(project, cmd, exp_connect):
     assert commands_output == exp_output
 
 
+def get_sql_commands():
+    return [
+        Command('box.cfg{}', exp_output='---\n...\n'),
+
+        Command('\\set language sql', yaml_output='true'),
+        Command(
+            "SELECT 1 AS a, 'x' AS b;",
+            exp_output='\n'.join([
+                '+---+---+',
+                '| A | B |',
+                '+---+---+',
+                '| 1 | x |',
+                '+---+---+',
+                '(1 row)',
+            ]) + '\n',
+        ),
+        # multiline statement
+        Command(
+            'SELECT\n2 AS c;',
+            exp_output='\n'.join([
+                '+---+',
+                '| C |',
+                '+---+',
+                '| 2 |',
+                '+---+',
+                '(1 row)',
+            ]) + '\n',
+        ),
+        # error
+        Command("SELECT * FROM unknown_space;", yaml_output="error: Space 'UNKNOWN_SPACE' does not exist"),
+
+        Command(
+            '\\set language unknown',
+            yaml_output='error: \'Unknown language "unknown". Supported languages: lua, sql\'',
+        ),
+
+        Command('\\set language lua', yaml_output='true'),
+        Command('return 666', yaml_output='666'),
+    ]
+
+
+def assert_sql_piped_commands(project, cmd, exp_connect):
+    commands = get_sql_commands()
+
+    rc, output = run_commands_in_pipe(project, cmd, commands)
+    assert rc == 0
+
+    out_lines = output.split('\n', maxsplit=1)
+    connected_line, commands_output = out_lines
+
+    assert connected_line == 'connected to %s' % exp_connect
+
+    exp_output = '\n'.join(c.exp_output for c in commands)+'\n'
+    assert commands_output == exp_output
+
+
 def assert_error(project, cmd, errmsg):
     process = subprocess.Popen(
         cmd,
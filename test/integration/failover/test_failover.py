@@ -252,3 +252,37 @@ def test_invalid_stateful_failover_opts(cartridge_cmd, project_without_dependenc
     assert rc == 1
     assert "Please, specify params for stateboard state provider, using " \
         "--provider-params '{\"uri\": \"localhost:4401\", \"password\": \"passwd\"}'" in output
+
+
+def test_setup_invalid_file(cartridge_cmd, project_without_dependencies):
+    project = project_without_dependencies
+
+    failover_conf_path = os.path.join(project.path, "failover.yml")
+    with open(failover_conf_path, "w") as f:
+        f.write("mode: stateful\nstate_provider: stateboard\nfailover_timout: 10\n")
+
+    cmd = [cartridge_cmd, "failover", "setup"]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 1
+    assert "line 3, column 1: Unknown parameter \"failover_timout\", " \
+        "did you mean \"failover_timeout\"?" in output
+
+    with open(failover_conf_path, "w") as f:
+        f.write("mode: stateful\nstate_provider: stateboard\nstateboard_params:\n  uri: localhost:4401\n")
+
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 1
+    assert "line 4, column 3: \"stateboard_params.password\" is required" in output
+
+
+def test_set_invalid_params(cartridge_cmd, project_without_dependencies):
+    project = project_without_dependencies
+
+    cmd = [
+        cartridge_cmd, "failover", "set", "eventual",
+        "--params", "{\"fencing_enabled\": true, \"fencing_pause\": -1}",
+    ]
+
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 1
+    assert "Failed to parse failover parameters: line 1, column 44: \"fencing_pause\" should be positive" in output
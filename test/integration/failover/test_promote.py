@@ -0,0 +1,107 @@
+import pytest
+import tenacity
+from integration.failover.utils import get_replicaset_leader
+from utils import run_command_and_get_output
+
+
+@pytest.fixture(scope="function")
+def project_with_stateful_failover(cartridge_cmd, project_with_topology_and_vshard):
+    project = project_with_topology_and_vshard
+
+    cmd = [cartridge_cmd, "failover", "setup"]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+    assert "Failover configured successfully" in output
+
+    return project
+
+
+@tenacity.retry(stop=tenacity.stop_after_delay(10), wait=tenacity.wait_fixed(1))
+def wait_for_replicaset_leader(replicaset_alias, leader_alias):
+    assert get_replicaset_leader(replicaset_alias) == leader_alias
+
+
+def test_promote(cartridge_cmd, project_with_stateful_failover):
+    project = project_with_stateful_failover
+
+    wait_for_replicaset_leader("s-1", "s1-master")
+
+    cmd = [cartridge_cmd, "failover", "promote", "s-1", "s1-replica"]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+    assert "Promote instance s1-replica to be a leader of replica set s-1" in output
+    assert "Leader promoted successfully" in output
+
+    wait_for_replicaset_leader("s-1", "s1-replica")
+
+    # promote current leader
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+    assert "Instance s1-replica is already a leader of replica set s-1" in output
+
+    # promote back with --force-inconsistency
+    cmd = [cartridge_cmd, "failover", "promote", "s-1", "s1-master", "--force-inconsistency"]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+    assert "Leader promoted successfully" in output
+
+    wait_for_replicaset_leader("s-1", "s1-master")
+
+
+def test_promote_invalid_args(cartridge_cmd, project_with_stateful_failover):
+    project = project_with_stateful_failover
+
+    cmd = [cartridge_cmd, "failover", "promote", "unknown-rpl", "s1-replica"]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 1
+    assert "Replica set unknown-rpl isn't found in current topology" in output
+
+    cmd = [cartridge_cmd, "failover", "promote", "s-1", "s2-replica"]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 1
+    assert "Instance s2-replica not found in replica set s-1" in output
+
+
+def test_promote_eventual_failover(cartridge_cmd, project_with_topology_and_vshard):
+    project = project_with_topology_and_vshard
+
+    cmd = [cartridge_cmd, "failover", "set", "eventual"]
+    rc, _ = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+
+    cmd = [cartridge_cmd, "failover", "promote", "s-1", "s1-replica"]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 1
+    assert "Failed to promote leader" in output
+
+
+def test_switchover(cartridge_cmd, project_with_stateful_failover):
+    project = project_with_stateful_failover
+
+    wait_for_replicaset_leader("s-2", "s2-master")
+
+    cmd = [cartridge_cmd, "failover", "switchover", "s-2"]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+    assert "Switch replica set s-2 leader to s2-replica" in output
+    assert "Wait until s2-replica becomes writable" in output
+    assert "Leader switched successfully" in output
+
+    wait_for_replicaset_leader("s-2", "s2-replica")
+
+    # switch back
+    cmd = [cartridge_cmd, "failover", "switchover", "s-2", "--timeout", "20s"]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+    assert "Switch replica set s-2 leader to s2-master" in output
+
+    wait_for_replicaset_leader("s-2", "s2-master")
+
+
+def test_switchover_no_replicas(cartridge_cmd, project_with_stateful_failover):
+    project = project_with_stateful_failover
+
+    cmd = [cartridge_cmd, "failover", "switchover", "router"]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 1
+    assert "Failed to choose a new leader of replica set router: No available replicas found" in output
@@ -1,3 +1,7 @@
+import signal
+import subprocess
+import time
+
 from integration.failover.utils import (assert_mode_and_params_state,
                                         get_common_failover_info,
                                         get_etcd2_failover_info,
@@ -80,6 +84,9 @@ def test_status_stateful_stateboard(cartridge_cmd, project_with_topology_and_vsh
     assert f"uri: {failover_info['tarantool_params']['uri']}" in output
     assert "password: pass" in output
 
+    assert "Current failover state" in output
+    assert "state provider: stateboard" in output
+
 
 def test_status_stateful_etcd2(cartridge_cmd, project_with_topology_and_vshard):
     project = project_with_topology_and_vshard
@@ -129,3 +136,57 @@ def test_status_disabled(cartridge_cmd, project_with_topology_and_vshard):
 
     assert "stateboard_params" not in output
     assert "etcd2_params" not in output
+
+
+def test_status_state(cartridge_cmd, project_with_topology_and_vshard):
+    project = project_with_topology_and_vshard
+
+    cmd = [cartridge_cmd, "failover", "setup"]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+    assert "Failover configured successfully" in output
+
+    cmd = [cartridge_cmd, "failover", "status"]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+
+    assert "Current failover state" in output
+    assert "active leaders:" in output
+    assert "s-1: s1-master (localhost:3302)" in output
+    assert "s-2: s2-master (localhost:3304)" in output
+    assert "coordinator: router (localhost:3301)" in output
+    assert "state provider: stateboard | connected" in output
+    assert "suspected instances: none" in output
+
+
+def test_status_watch(cartridge_cmd, project_with_topology_and_vshard):
+    project = project_with_topology_and_vshard
+
+    cmd = [cartridge_cmd, "failover", "set", "eventual"]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+
+    cmd = [cartridge_cmd, "failover", "status", "--watch", "--interval", "1s"]
+    process = subprocess.Popen(
+        cmd, cwd=project.path,
+        stdout=subprocess.PIPE, stderr=subprocess.STDOUT,
+    )
+
+    time.sleep(3)
+    process.send_signal(signal.SIGINT)
+
+    output, _ = process.communicate(timeout=10)
+    output = output.decode()
+
+    assert process.returncode == 0
+    assert output.count("Every 1s: cartridge failover status") >= 2
+    assert "s-1: s1-master (localhost:3302)" in output
+
+
+def test_status_invalid_interval(cartridge_cmd, project_with_topology_and_vshard):
+    project = project_with_topology_and_vshard
+
+    cmd = [cartridge_cmd, "failover", "status", "--watch", "--interval", "0"]
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 1
+    assert '"--interval" flag value should be positive' in output
@@ -82,3 +82,23 @@ def assert_mode_and_params_state(failover_info, output):
     assert f"fencing_enabled: {failover_info['fencing_enabled']}".lower() in output
     assert f"fencing_pause: {failover_info['fencing_pause']}" in output
     assert f"failover_timeout: {failover_info['failover_timeout']}" in output
+
+
+def get_replicaset_leader(replicaset_alias):
+    query = """
+        query {
+          replicasets {
+            alias
+            active_master {
+              alias
+            }
+          }
+        }
+    """
+
+    response = requests.post(get_admin_url(8081), json={'query': query})
+    for replicaset in get_response_data(response)["replicasets"]:
+        if replicaset["alias"] == replicaset_alias:
+            return replicaset["active_master"]["alias"]
+
+    return None
@@ -140,3 +140,69 @@ Replicasets
 '''
 
     assert summary == exp_summary
+
+
+def test_list_topology_dot(cartridge_cmd, tmpdir):
+    data_dir = os.path.join(tmpdir, 'tmp', 'data')
+    os.makedirs(data_dir)
+
+    old_conf = copy.deepcopy(SIMPLE_CONF)
+
+    instances = ['instance-1', 'instance-2']
+    conf_paths = write_instances_topology_conf(data_dir, APPNAME, old_conf, instances)
+
+    cmd = [
+        cartridge_cmd, 'repair', 'list-topology',
+        '--name', APPNAME,
+        '--data-dir', data_dir,
+        '--format', 'dot',
+    ]
+
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 0
+
+    assert_conf_not_changed(conf_paths, old_conf)
+
+    exp_graph = r'''digraph topology {
+  rankdir=LR;
+  node [shape=box];
+
+  subgraph "cluster_rpl-1-uuid" {
+    label="rpl-1-uuid\nroles: vshard-storage\nvshard group: default | weight: 1";
+    "srv-1-uuid" [label="★ srv-1-uuid\nlocalhost:3301", style=bold];
+    "srv-2-uuid" [label="srv-2-uuid\nlocalhost:3302"];
+    "srv-3-uuid" [label="srv-3-uuid\nlocalhost:3303"];
+    "srv-5-uuid" [label="srv-5-uuid\nlocalhost:3305"];
+  }
+
+  subgraph "cluster_rpl-2-uuid" {
+    label="rpl-2-uuid\nroles: vshard-storage\nvshard group: default | weight: 1";
+    "srv-4-uuid" [label="★ srv-4-uuid\nlocalhost:3304", style=bold];
+    "srv-6-uuid" [label="srv-6-uuid\nlocalhost:3306\ndisabled", style=dashed];
+  }
+
+  "srv-1-uuid" -> "srv-2-uuid" [label="leader", style=dotted];
+  "srv-1-uuid" -> "srv-3-uuid" [label="leader", style=dotted];
+  "srv-1-uuid" -> "srv-5-uuid" [label="leader", style=dotted];
+  "srv-4-uuid" -> "srv-6-uuid" [label="leader", style=dotted];
+}'''
+
+    assert exp_graph in output
+
+
+def test_list_topology_bad_format(cartridge_cmd, tmpdir):
+    data_dir = os.path.join(tmpdir, 'tmp', 'data')
+    os.makedirs(data_dir)
+
+    write_instances_topology_conf(data_dir, APPNAME, copy.deepcopy(SIMPLE_CONF), ['instance-1'])
+
+    cmd = [
+        cartridge_cmd, 'repair', 'list-topology',
+        '--name', APPNAME,
+        '--data-dir', data_dir,
+        '--format', 'svg',
+    ]
+
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 1
+    assert 'Unknown graph format "svg". Supported formats: dot, mermaid' in output
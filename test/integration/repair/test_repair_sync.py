@@ -0,0 +1,155 @@
+import os
+
+import pytest
+from clusterwide_conf import (ClusterwideConfig, assert_conf_changed,
+                              assert_conf_not_changed, get_rpl_conf,
+                              get_srv_conf, get_topology_conf,
+                              write_instances_topology_conf)
+from utils import (assert_ok_for_all_instances, get_logs,
+                   run_command_and_get_output)
+
+APPNAME = 'myapp'
+
+
+##########
+# FIXTURES
+@pytest.fixture(scope="function")
+def clusterwide_conf_v1():
+    conf = get_topology_conf(
+        instances=[
+            get_srv_conf('srv-1', rpl_uuid='rpl-1'),
+            get_srv_conf('srv-2', rpl_uuid='rpl-1'),
+        ],
+        replicasets=[
+            get_rpl_conf('rpl-1', leaders=['srv-1']),
+        ]
+    )
+
+    return ClusterwideConfig(conf)
+
+
+@pytest.fixture(scope="function")
+def clusterwide_conf_v2():
+    conf = get_topology_conf(
+        instances=[
+            get_srv_conf('srv-1', rpl_uuid='rpl-1'),
+            get_srv_conf('srv-2', rpl_uuid='rpl-1'),
+            get_srv_conf('srv-3', rpl_uuid='rpl-1'),  # <= one more instance in rpl-1
+        ],
+        replicasets=[
+            get_rpl_conf('rpl-1', leaders=['srv-1']),
+        ]
+    )
+
+    return ClusterwideConfig(conf)
+
+
+#######
+# TESTS
+def test_sync_same_configs(cartridge_cmd, tmpdir, clusterwide_conf_v1):
+    data_dir = os.path.join(tmpdir, 'tmp', 'data')
+    os.makedirs(data_dir)
+
+    instances = ['instance-1', 'instance-2']
+    conf_paths = write_instances_topology_conf(data_dir, APPNAME, clusterwide_conf_v1.conf, instances)
+
+    cmd = [
+        cartridge_cmd, 'repair', 'sync',
+        '--name', APPNAME,
+        '--data-dir', data_dir,
+    ]
+
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 0
+    assert "Clusterwide config is the same on all instances, nothing to sync" in output
+
+    assert_conf_not_changed(conf_paths, clusterwide_conf_v1.conf)
+
+
+@pytest.mark.parametrize('source', [None, 'instance-1', 'instance-3'])
+def test_sync(cartridge_cmd, source, tmpdir, clusterwide_conf_v1, clusterwide_conf_v2):
+    data_dir = os.path.join(tmpdir, 'tmp', 'data')
+    os.makedirs(data_dir)
+
+    conf1_instances = ['instance-1', 'instance-2']
+    conf1_paths = write_instances_topology_conf(data_dir, APPNAME, clusterwide_conf_v1.conf, conf1_instances)
+
+    conf2_instances = ['instance-3']
+    conf2_paths = write_instances_topology_conf(data_dir, APPNAME, clusterwide_conf_v2.conf, conf2_instances)
+
+    cmd = [
+        cartridge_cmd, 'repair', 'sync',
+        '--name', APPNAME,
+        '--data-dir', data_dir,
+    ]
+
+    if source is not None:
+        cmd.extend(['--source', source])
+
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 0
+
+    assert "Clusterwide config is diverged between instances" in output
+
+    if source == 'instance-3':
+        # config v2 is used as a source
+        assert "(instance-3) is used as a source" in output
+        assert_ok_for_all_instances(get_logs(output), conf1_instances)
+        assert_conf_changed(conf1_paths, None, clusterwide_conf_v1.conf, clusterwide_conf_v2.conf)
+        assert_conf_not_changed(conf2_paths, clusterwide_conf_v2.conf)
+    else:
+        # config v1 is used by the majority of instances
+        assert "(instance-1, instance-2) is used as a source" in output
+        assert_ok_for_all_instances(get_logs(output), conf2_instances)
+        assert_conf_changed(conf2_paths, None, clusterwide_conf_v2.conf, clusterwide_conf_v1.conf)
+        assert_conf_not_changed(conf1_paths, clusterwide_conf_v1.conf)
+
+
+def test_sync_dry_run(cartridge_cmd, tmpdir, clusterwide_conf_v1, clusterwide_conf_v2):
+    data_dir = os.path.join(tmpdir, 'tmp', 'data')
+    os.makedirs(data_dir)
+
+    conf1_instances = ['instance-1', 'instance-2']
+    conf1_paths = write_instances_topology_conf(data_dir, APPNAME, clusterwide_conf_v1.conf, conf1_instances)
+
+    conf2_instances = ['instance-3']
+    conf2_paths = write_instances_topology_conf(data_dir, APPNAME, clusterwide_conf_v2.conf, conf2_instances)
+
+    cmd = [
+        cartridge_cmd, 'repair', 'sync',
+        '--name', APPNAME,
+        '--data-dir', data_dir,
+        '--dry-run',
+    ]
+
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 0
+
+    assert '''-  srv-3:
+-    disabled: false
+-    replicaset_uuid: rpl-1
+-    uri: srv-3-uri''' in output
+
+    assert "Write application cluster-wide configurations..." not in output
+
+    assert_conf_not_changed(conf1_paths, clusterwide_conf_v1.conf)
+    assert_conf_not_changed(conf2_paths, clusterwide_conf_v2.conf)
+
+
+def test_sync_bad_source(cartridge_cmd, tmpdir, clusterwide_conf_v1, clusterwide_conf_v2):
+    data_dir = os.path.join(tmpdir, 'tmp', 'data')
+    os.makedirs(data_dir)
+
+    write_instances_topology_conf(data_dir, APPNAME, clusterwide_conf_v1.conf, ['instance-1'])
+    write_instances_topology_conf(data_dir, APPNAME, clusterwide_conf_v2.conf, ['instance-2'])
+
+    cmd = [
+        cartridge_cmd, 'repair', 'sync',
+        '--name', APPNAME,
+        '--data-dir', data_dir,
+        '--source', 'unknown-instance',
+    ]
+
+    rc, output = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 1
+    assert "No instance or config hash unknown-instance found" in output
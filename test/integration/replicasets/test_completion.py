@@ -0,0 +1,68 @@
+from utils import get_completions
+
+
+def test_complete_instances(cartridge_cmd, project_with_vshard_replicasets):
+    project = project_with_vshard_replicasets.project
+
+    completions = get_completions(cartridge_cmd, ['replicasets', 'expel', ''], project.path)
+    assert set(completions) == {'router', 'hot-master', 'hot-replica', 'cold-master'}
+
+    # specified instances aren't completed
+    completions = get_completions(cartridge_cmd, ['replicasets', 'expel', 'router', ''], project.path)
+    assert set(completions) == {'hot-master', 'hot-replica', 'cold-master'}
+
+
+def test_complete_replicasets(cartridge_cmd, project_with_vshard_replicasets):
+    project = project_with_vshard_replicasets.project
+
+    replicasets = ['cold-storage', 'hot-storage', 'router']
+
+    # --replicaset flag
+    for subcmd in ['join', 'add-roles', 'set-weight']:
+        completions = get_completions(cartridge_cmd, ['replicasets', subcmd, '--replicaset', ''], project.path)
+        assert completions == replicasets
+
+    completions = get_completions(cartridge_cmd, ['eval', '--replicaset', ''], project.path)
+    assert completions == replicasets
+
+    # replica set argument
+    completions = get_completions(cartridge_cmd, ['replicasets', 'decommission', ''], project.path)
+    assert completions == replicasets
+
+    completions = get_completions(cartridge_cmd, ['replicasets', 'decommission', 'router', ''], project.path)
+    assert completions == []
+
+    completions = get_completions(cartridge_cmd, ['failover', 'switchover', ''], project.path)
+    assert completions == replicasets
+
+    # replica set and instance arguments
+    completions = get_completions(cartridge_cmd, ['failover', 'promote', ''], project.path)
+    assert completions == replicasets
+
+    completions = get_completions(cartridge_cmd, ['failover', 'promote', 'hot-storage', ''], project.path)
+    assert set(completions) == {'hot-master', 'hot-replica'}
+
+
+def test_complete_roles(cartridge_cmd, project_with_vshard_replicasets):
+    project = project_with_vshard_replicasets.project
+
+    completions = get_completions(cartridge_cmd, ['eval', '--role', ''], project.path)
+    assert 'vshard-router' in completions
+    assert 'vshard-storage' in completions
+
+    cmd = ['replicasets', 'remove-roles', '--replicaset', 'router', '']
+    completions = get_completions(cartridge_cmd, cmd, project.path)
+    assert completions == ['vshard-router']
+
+    cmd = ['replicasets', 'add-roles', '--replicaset', 'router', '']
+    completions = get_completions(cartridge_cmd, cmd, project.path)
+    assert 'vshard-router' not in completions
+    assert 'vshard-storage' in completions
+
+
+def test_complete_vshard_groups(cartridge_cmd, project_with_vshard_replicasets):
+    project = project_with_vshard_replicasets.project
+
+    cmd = ['replicasets', 'add-roles', '--vshard-group', '']
+    completions = get_completions(cartridge_cmd, cmd, project.path)
+    assert completions == ['cold', 'hot']
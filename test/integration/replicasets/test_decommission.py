@@ -0,0 +1,88 @@
+from utils import (get_log_lines, get_replicasets, is_instance_expelled,
+                   run_command_and_get_output)
+
+
+def test_bad_replicaset_name(cartridge_cmd, project_with_vshard_replicasets):
+    project = project_with_vshard_replicasets.project
+
+    cmd = [
+        cartridge_cmd, 'replicasets', 'decommission',
+        'unknown-replicaset',
+    ]
+
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 1
+    assert "Replica set unknown-replicaset isn't found in current topology" in output
+
+
+def test_decommission_last_router(cartridge_cmd, project_with_vshard_replicasets):
+    project = project_with_vshard_replicasets.project
+
+    cmd = [
+        cartridge_cmd, 'replicasets', 'decommission', 'router',
+    ]
+
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 1
+    assert "Replica set router can't be decommissioned: " \
+        "It's the last replica set with vshard-router role" in output
+
+
+def test_decommission_last_storage(cartridge_cmd, project_with_vshard_replicasets):
+    project = project_with_vshard_replicasets.project
+
+    cmd = [
+        cartridge_cmd, 'replicasets', 'decommission', 'hot-storage',
+    ]
+
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 1
+    assert "Replica set hot-storage can't be decommissioned: " \
+        "It's the last replica set with vshard-storage role and non-zero weight in vshard group hot" in output
+
+
+def test_decommission_storage(cartridge_cmd, project_with_vshard_replicasets):
+    project = project_with_vshard_replicasets.project
+    instances = project_with_vshard_replicasets.instances
+
+    router = instances['router']
+    cold_master = instances['cold-master']
+    admin_api_url = router.get_admin_api_url()
+
+    # router replica set becomes the second storage of the cold group
+    cmd = [
+        cartridge_cmd, 'replicasets', 'add-roles',
+        '--replicaset', 'router',
+        '--vshard-group', 'cold',
+        'vshard-storage',
+    ]
+
+    rc, _ = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+
+    cmd = [
+        cartridge_cmd, 'replicasets', 'bootstrap-vshard',
+    ]
+
+    rc, _ = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+
+    cmd = [
+        cartridge_cmd, 'replicasets', 'decommission', 'cold-storage',
+        '--timeout', '2m',
+    ]
+
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+    assert get_log_lines(output) == [
+        '• Replica set cold-storage weight is set to 0',
+        '• Wait until buckets are moved from replica set cold-storage',
+        '• All buckets are moved from replica set cold-storage',
+        '• Replica set cold-storage instances are disabled',
+        '• Replica set cold-storage has been successfully decommissioned',
+    ]
+
+    assert is_instance_expelled(admin_api_url, cold_master.name)
+
+    replicasets = get_replicasets(admin_api_url)
+    assert 'cold-storage' not in [r['alias'] for r in replicasets]
@@ -0,0 +1,114 @@
+import json
+
+from utils import run_command_and_get_output
+
+
+def run_eval(cartridge_cmd, project, args):
+    cmd = [cartridge_cmd, 'eval']
+    cmd.extend(args)
+
+    return run_command_and_get_output(cmd, cwd=project.path)
+
+
+def get_eval_json_result(output):
+    return json.loads(output[output.index('{'):])
+
+
+def test_eval_all_instances(cartridge_cmd, project_with_vshard_replicasets):
+    project = project_with_vshard_replicasets.project
+
+    rc, output = run_eval(cartridge_cmd, project, ['return 1', '--output', 'json'])
+    assert rc == 0
+
+    assert get_eval_json_result(output) == {
+        'router': {'results': [1]},
+        'hot-master': {'results': [1]},
+        'hot-replica': {'results': [1]},
+        'cold-master': {'results': [1]},
+    }
+
+
+def test_eval_table_output(cartridge_cmd, project_with_vshard_replicasets):
+    project = project_with_vshard_replicasets.project
+
+    rc, output = run_eval(cartridge_cmd, project, ['1, 2', '--replicaset', 'router'])
+    assert rc == 0
+
+    assert output.strip() == '\n'.join([
+        '+----------+--------+--------+',
+        '| instance | status | result |',
+        '+----------+--------+--------+',
+        '| router   | ok     | 1, 2   |',
+        '+----------+--------+--------+',
+    ])
+
+
+def test_eval_filters(cartridge_cmd, project_with_vshard_replicasets):
+    project = project_with_vshard_replicasets.project
+
+    # role
+    rc, output = run_eval(cartridge_cmd, project, [
+        'return box.info.ro', '--role', 'vshard-storage', '--output', 'json',
+    ])
+    assert rc == 0
+    assert get_eval_json_result(output) == {
+        'hot-master': {'results': [False]},
+        'hot-replica': {'results': [True]},
+        'cold-master': {'results': [False]},
+    }
+
+    # leaders only
+    rc, output = run_eval(cartridge_cmd, project, [
+        'return box.info.ro', '--role', 'vshard-storage', '--leaders-only', '--output', 'json',
+    ])
+    assert rc == 0
+    assert get_eval_json_result(output) == {
+        'hot-master': {'results': [False]},
+        'cold-master': {'results': [False]},
+    }
+
+    # unknown replica set
+    rc, output = run_eval(cartridge_cmd, project, ['1', '--replicaset', 'unknown-replicaset'])
+    assert rc == 1
+    assert "Replica set unknown-replicaset isn't found in current topology" in output
+
+
+def test_eval_errors(cartridge_cmd, project_with_vshard_replicasets, tmpdir):
+    project = project_with_vshard_replicasets.project
+
+    script_path = tmpdir.join('script.lua')
+    script_path.write("if box.info.ro then\n    error('replica')\nend\nreturn 'ok'\n")
+
+    rc, output = run_eval(cartridge_cmd, project, [
+        '--file', str(script_path), '--replicaset', 'hot-storage', '--output', 'json',
+    ])
+    assert rc == 1
+
+    result = get_eval_json_result(output[:output.rindex('}') + 1])
+    assert result['hot-master'] == {'results': ['ok']}
+    assert result['hot-replica'] == {'error': '%s:2: replica' % script_path}
+
+    assert "Failed to evaluate code on 1 instance(s): hot-replica" in output
+
+    # timeout
+    rc, output = run_eval(cartridge_cmd, project, [
+        'require("fiber").sleep(10)', '--replicaset', 'router', '--timeout', '1s',
+    ])
+    assert rc == 1
+    assert "Failed to evaluate code on 1 instance(s): router" in output
+
+
+def test_eval_bad_args(cartridge_cmd, project_with_vshard_replicasets):
+    project = project_with_vshard_replicasets.project
+
+    rc, output = run_eval(cartridge_cmd, project, [])
+    assert rc == 1
+    assert "Expression or --file flag should be specified" in output
+
+    rc, output = run_eval(cartridge_cmd, project, ['1', '--file', 'script.lua'])
+    assert rc == 1
+    assert "Expression and --file flag can't be specified together" in output
+
+    rc, output = run_eval(cartridge_cmd, project, ['1', '--output', 'yaml'])
+    assert rc == 1
+    assert 'Unknown output format "yaml". Supported formats: table, json' in output
@@ -37,6 +37,53 @@ def test_default_application(cartridge_cmd, default_project_with_instances):
     • s2-replica localhost:3305"""
 
 
+def test_default_application_mermaid(cartridge_cmd, default_project_with_instances):
+    project = default_project_with_instances.project
+
+    # setup replicasets
+    cmd = [
+        cartridge_cmd, 'replicasets', 'setup',
+        '--bootstrap-vshard',
+    ]
+
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+
+    # list replicasets as a graph
+    cmd = [
+        cartridge_cmd, 'replicasets', 'list',
+        '--format', 'mermaid',
+    ]
+
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+
+    assert output.strip() == """graph LR
+  classDef leader stroke-width:3px
+  classDef disabled stroke-dasharray:5 5
+
+  subgraph rs1["router<br/>roles: failover-coordinator, vshard-router, app.roles.custom"]
+    rs1_i1["★ router<br/>localhost:3301"]
+  end
+
+  subgraph rs2["s-1<br/>roles: vshard-storage<br/>vshard group: default | weight: 1"]
+    rs2_i1["★ s1-master<br/>localhost:3302"]
+    rs2_i2["s1-replica<br/>localhost:3303"]
+  end
+
+  subgraph rs3["s-2<br/>roles: vshard-storage<br/>vshard group: default | weight: 1"]
+    rs3_i1["★ s2-master<br/>localhost:3304"]
+    rs3_i2["s2-replica<br/>localhost:3305"]
+  end
+
+  rs2_i2 -->|follow| rs2_i1
+  rs2_i1 -->|follow| rs2_i2
+  rs3_i2 -->|follow| rs3_i1
+  rs3_i1 -->|follow| rs3_i2
+
+  class rs1_i1,rs2_i1,rs3_i1 leader"""
+
+
 def test_no_joined_instances(cartridge_cmd, project_with_instances):
     project = project_with_instances.project
 
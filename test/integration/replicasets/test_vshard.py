@@ -0,0 +1,88 @@
+from utils import run_command_and_get_output
+
+
+def bootstrap_vshard(cartridge_cmd, project):
+    cmd = [
+        cartridge_cmd, 'replicasets', 'bootstrap-vshard',
+    ]
+
+    rc, _ = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+
+
+def test_status(cartridge_cmd, project_with_vshard_replicasets):
+    project = project_with_vshard_replicasets.project
+
+    bootstrap_vshard(cartridge_cmd, project)
+
+    cmd = [
+        cartridge_cmd, 'vshard', 'status',
+    ]
+
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+
+    assert "Vshard status:" in output
+
+    # each group has one storage that owns all buckets
+    for group, storage in [('cold', 'cold-storage'), ('hot', 'hot-storage')]:
+        assert "• %s" % group in output
+        assert "    %s   active: " % storage in output
+
+    assert "| balanced" in output
+    assert "    router   green | available rw: " in output
+
+
+def test_status_not_bootstrapped(cartridge_cmd, project_with_vshard_replicasets):
+    project = project_with_vshard_replicasets.project
+
+    cmd = [
+        cartridge_cmd, 'vshard', 'status',
+    ]
+
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+
+    assert "vshard storage is not bootstrapped" in output
+    assert "not balanced" in output
+
+
+def test_wait_balanced(cartridge_cmd, project_with_vshard_replicasets):
+    project = project_with_vshard_replicasets.project
+
+    bootstrap_vshard(cartridge_cmd, project)
+
+    cmd = [
+        cartridge_cmd, 'vshard', 'wait-balanced',
+        '--timeout', '30s',
+    ]
+
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 0
+    assert "Vshard buckets are balanced" in output
+
+
+def test_wait_balanced_timeout(cartridge_cmd, project_with_vshard_replicasets):
+    project = project_with_vshard_replicasets.project
+
+    cmd = [
+        cartridge_cmd, 'vshard', 'wait-balanced',
+        '--timeout', '1s',
+    ]
+
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 1
+    assert "Timeout 1s was reached while waiting for buckets to be balanced" in output
+
+
+def test_wait_balanced_bad_timeout(cartridge_cmd, project_with_vshard_replicasets):
+    project = project_with_vshard_replicasets.project
+
+    cmd = [
+        cartridge_cmd, 'vshard', 'wait-balanced',
+        '--timeout', 'forever',
+    ]
+
+    rc, output = run_command_and_get_output(cmd, cwd=project.path)
+    assert rc == 1
+    assert 'Invalid argument "forever" for "--timeout" flag' in output
@@ -0,0 +1,74 @@
+import os
+
+import requests
+from utils import run_command_and_get_output, write_conf
+
+ETCD_LISTEN = 'localhost:23790'
+ETCD_URL = 'http://%s' % ETCD_LISTEN
+
+
+def write_etcd_cartridge_conf(project):
+    write_conf(os.path.join(project.path, '.cartridge.yml'), {
+        'etcd': True,
+        'etcd-listen': ETCD_LISTEN,
+        'etcd-embedded': True,
+    })
+
+
+def run_cartridge(cli, project, args, exp_rc=0):
+    rc, output = run_command_and_get_output([cli._cartridge_cmd] + args, cwd=project.path)
+    assert rc == exp_rc, output
+    return output
+
+
+def test_start_stop_embedded_etcd(start_stop_cli, project_without_dependencies):
+    project = project_without_dependencies
+    cli = start_stop_cli
+
+    ETCD_ID = '%s-etcd' % project.name
+    INSTANCE1 = 'instance-1'
+
+    write_etcd_cartridge_conf(project)
+
+    try:
+        # etcd is started with instances
+        run_cartridge(cli, project, ['start', '-d', '--etcd', INSTANCE1])
+
+        output = run_cartridge(cli, project, ['status', '--etcd', INSTANCE1])
+        assert '%s: RUNNING' % ETCD_ID in output
+
+        # etcd v2 API is available
+        r = requests.put('%s/v2/keys/cartridge/leaders' % ETCD_URL, data={'value': 'leaders'})
+        assert r.status_code == 201
+        assert r.json()['node']['value'] == 'leaders'
+
+        r = requests.get('%s/v2/members' % ETCD_URL)
+        assert r.status_code == 200
+        assert r.json()['members'][0]['clientURLs'] == [ETCD_URL]
+
+        # etcd isn't managed when instances are specified w/o --etcd flag
+        output = run_cartridge(cli, project, ['stop', INSTANCE1])
+        assert ETCD_ID not in output
+
+        run_cartridge(cli, project, ['stop', '--etcd'])
+        output = run_cartridge(cli, project, ['status', '--etcd'])
+        assert '%s: STOPPED' % ETCD_ID in output
+
+        # data is kept between restarts
+        run_cartridge(cli, project, ['start', '-d', '--etcd', INSTANCE1])
+
+        r = requests.get('%s/v2/keys/cartridge/leaders' % ETCD_URL)
+        assert r.status_code == 200
+        assert r.json()['node']['value'] == 'leaders'
+
+        output = run_cartridge(cli, project, ['log', '--etcd', INSTANCE1])
+        assert 'etcd v2 stand-in is listening on %s' % ETCD_LISTEN in output
+    finally:
+        run_cartridge(cli, project, ['stop', '--etcd', INSTANCE1])
+
+    # etcd data is removed by clean
+    etcd_workdir = os.path.join(project.get_data_dir(), ETCD_ID)
+    assert os.path.exists(etcd_workdir)
+
+    run_cartridge(cli, project, ['clean', '--etcd', INSTANCE1])
+    assert not os.path.exists(etcd_workdir)
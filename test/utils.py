@@ -676,6 +676,20 @@ def get_rockspec_path(path, project_name, version):
     return os.path.join(path, '%s-%s.rockspec' % (project_name, version))
 
 
+def get_completions(cartridge_cmd, args, cwd):
+    cmd = [cartridge_cmd, '__complete']
+    cmd.extend(args)
+
+    process = subprocess.run(cmd, cwd=cwd, stdout=subprocess.PIPE, stderr=subprocess.PIPE)
+    assert process.returncode == 0, process.stderr.decode()
+
+    lines = process.stdout.decode().splitlines()
+
+    # the last line is a completion directive
+    assert lines[-1].startswith(':')
+    return lines[:-1]
+
+
 def run_command_and_get_output(cmd, cwd=None, env=None):
     process = subprocess.Popen(
         cmd,